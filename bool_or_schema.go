@@ -57,3 +57,39 @@ func NewBoolOrSchema(v any) *BoolOrSchema {
 		return nil
 	}
 }
+
+// BoolOrSchemaBuilder is a builder for BoolOrSchema object.
+//
+// The boolean value and the schema are mutually exclusive, so setting one of them resets the other one.
+type BoolOrSchemaBuilder struct {
+	spec *BoolOrSchema
+}
+
+func NewBoolOrSchemaBuilder() *BoolOrSchemaBuilder {
+	return &BoolOrSchemaBuilder{
+		spec: &BoolOrSchema{},
+	}
+}
+
+func (b *BoolOrSchemaBuilder) Build() *BoolOrSchema {
+	return b.spec
+}
+
+// Allow sets the boolean value and resets the schema.
+func (b *BoolOrSchemaBuilder) Allow(v bool) *BoolOrSchemaBuilder {
+	b.spec.Schema = nil
+	b.spec.Allowed = v
+	return b
+}
+
+// Schema sets the schema, which means that the value is allowed, if it matches the schema.
+func (b *BoolOrSchemaBuilder) Schema(v *RefOrSpec[Schema]) *BoolOrSchemaBuilder {
+	b.spec.Schema = v
+	b.spec.Allowed = v != nil
+	return b
+}
+
+// SchemaBuilder sets the schema built by the given builder, see Schema method.
+func (b *BoolOrSchemaBuilder) SchemaBuilder(v *SchemaBuilder) *BoolOrSchemaBuilder {
+	return b.Schema(v.Build())
+}
@@ -67,3 +67,39 @@ func TestAdditionalPropertiesJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestBoolOrSchemaBuilder(t *testing.T) {
+	schema := openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+
+	t.Run("allow", func(t *testing.T) {
+		v := openapi.NewBoolOrSchemaBuilder().Allow(true).Build()
+		require.Equal(t, true, v.Allowed)
+		require.Nil(t, v.Schema)
+	})
+	t.Run("schema", func(t *testing.T) {
+		v := openapi.NewBoolOrSchemaBuilder().Schema(schema).Build()
+		require.Equal(t, true, v.Allowed)
+		require.Equal(t, schema, v.Schema)
+	})
+	t.Run("schema builder", func(t *testing.T) {
+		v := openapi.NewBoolOrSchemaBuilder().SchemaBuilder(openapi.NewSchemaBuilder().Type(openapi.StringType)).Build()
+		require.Equal(t, true, v.Allowed)
+		require.Equal(t, schema, v.Schema)
+	})
+	t.Run("schema resets allow", func(t *testing.T) {
+		v := openapi.NewBoolOrSchemaBuilder().Allow(false).Schema(schema).Build()
+		require.Equal(t, true, v.Allowed)
+		require.Equal(t, schema, v.Schema)
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type": "string"}`, string(data))
+	})
+	t.Run("allow resets schema", func(t *testing.T) {
+		v := openapi.NewBoolOrSchemaBuilder().Schema(schema).Allow(false).Build()
+		require.Equal(t, false, v.Allowed)
+		require.Nil(t, v.Schema)
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		require.JSONEq(t, `false`, string(data))
+	})
+}
@@ -0,0 +1,312 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// The names of the built-in rules of BreakingChanges.
+const (
+	RuleRemovedEndpoint        = "removed-endpoint"
+	RuleRemovedResponse        = "removed-response"
+	RuleNewRequiredParameter   = "new-required-parameter"
+	RuleRemovedEnumValue       = "removed-enum-value"
+	RuleRemovedProperty        = "removed-property"
+	RuleNewRequiredProperty    = "new-required-property"
+	RuleTightenedConstraint    = "tightened-constraint"
+	RuleTypeChanged            = "type-changed"
+	RuleNewRequiredRequestBody = "new-required-request-body"
+)
+
+// RuleContext is a pair of the old and the new versions of an object passed to BreakingRule.
+//
+// The Old and New values are the pointers of the same type, one of them is nil if the object is added or removed:
+// *PathItem, *Operation, *Parameter, *RequestBody, *Response, *MediaType or *Schema.
+// The schemas are passed only if both versions exist, the references are already resolved.
+type RuleContext struct {
+	// The location of the object in the form of JSON Pointer, e.g. `/paths/~1pets/get`.
+	Location string
+	Old      any
+	New      any
+	// Request is true if the object describes the data sent by the clients,
+	// e.g. a parameter or a schema of a request body.
+	Request bool
+}
+
+// Breaking creates a breaking change at the location of the context.
+func (c *RuleContext) Breaking(kind ChangeKind, format string, args ...any) Change {
+	return Change{
+		Location: c.Location,
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: true,
+	}
+}
+
+// BreakingRule checks a pair of the objects and returns the breaking changes.
+type BreakingRule func(c *RuleContext) []Change
+
+type breakingOptions struct {
+	rules map[string]BreakingRule
+}
+
+// BreakingOption is a type for the options of BreakingChanges function.
+type BreakingOption func(*breakingOptions)
+
+// WithBreakingRule is an option to register a custom rule or to replace a built-in rule with the same name.
+func WithBreakingRule(name string, rule BreakingRule) BreakingOption {
+	return func(o *breakingOptions) {
+		o.rules[name] = rule
+	}
+}
+
+// WithoutBreakingRule is an option to disable the rule with the given name.
+func WithoutBreakingRule(name string) BreakingOption {
+	return func(o *breakingOptions) {
+		delete(o.rules, name)
+	}
+}
+
+// BreakingChanges compares two versions of a document using the set of rules and returns the breaking changes.
+//
+// The built-in rules detect:
+//   - removed paths and operations;
+//   - removed responses and removed media types of the responses;
+//   - new required parameters and optional parameters became required;
+//   - new required request bodies and optional request bodies became required;
+//   - removed enum values of the request schemas;
+//   - new required properties of the request schemas and optional properties became required;
+//   - removed properties of the response schemas;
+//   - tightened constraints of the request schemas, e.g. lower `maximum` or higher `minLength`;
+//   - changed types, narrowed for the requests or widened for the responses.
+//
+// The rules are called in the order of their names.
+// The objects, which cannot be compared, e.g. due to an unresolved reference, are reported as breaking changes too.
+func BreakingChanges(oldSpec, newSpec *OpenAPI, opts ...BreakingOption) []Change {
+	options := breakingOptions{rules: builtinBreakingRules()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if oldSpec == nil || newSpec == nil {
+		return []Change{{Kind: ChangeModified, Message: "unable to compare: " + ErrRequired.Error(), Breaking: true}}
+	}
+	d := differ{
+		oldComponents: oldSpec.Components,
+		newComponents: newSpec.Components,
+		visited:       make(map[[2]*Schema]bool),
+		rules:         sortedRules(options.rules),
+	}
+	if err := d.paths(oldSpec.Paths, newSpec.Paths); err != nil {
+		d.breaking = append(d.breaking, Change{Kind: ChangeModified, Message: "unable to compare: " + err.Error(), Breaking: true})
+	}
+	return d.breaking
+}
+
+// builtinBreakingRules returns the built-in rules by their names, they are used by Diff too.
+func builtinBreakingRules() map[string]BreakingRule {
+	return map[string]BreakingRule{
+		RuleRemovedEndpoint:        removedEndpointRule,
+		RuleRemovedResponse:        removedResponseRule,
+		RuleNewRequiredParameter:   newRequiredParameterRule,
+		RuleNewRequiredRequestBody: newRequiredRequestBodyRule,
+		RuleRemovedEnumValue:       removedEnumValueRule,
+		RuleNewRequiredProperty:    newRequiredPropertyRule,
+		RuleRemovedProperty:        removedPropertyRule,
+		RuleTightenedConstraint:    tightenedConstraintRule,
+		RuleTypeChanged:            typeChangedRule,
+	}
+}
+
+// sortedRules returns the rules in the order of their names.
+func sortedRules(rules map[string]BreakingRule) []BreakingRule {
+	sorted := make([]BreakingRule, 0, len(rules))
+	for _, name := range sortedKeys(rules) {
+		sorted = append(sorted, rules[name])
+	}
+	return sorted
+}
+
+func removedEndpointRule(c *RuleContext) []Change {
+	switch v := c.Old.(type) {
+	case *PathItem:
+		if v != nil && c.New.(*PathItem) == nil {
+			return []Change{c.Breaking(ChangeRemoved, "path removed")}
+		}
+	case *Operation:
+		if v != nil && c.New.(*Operation) == nil {
+			return []Change{c.Breaking(ChangeRemoved, "operation removed")}
+		}
+	}
+	return nil
+}
+
+func removedResponseRule(c *RuleContext) []Change {
+	switch v := c.Old.(type) {
+	case *Response:
+		if v != nil && c.New.(*Response) == nil {
+			return []Change{c.Breaking(ChangeRemoved, "response removed")}
+		}
+	case *MediaType:
+		if !c.Request && v != nil && c.New.(*MediaType) == nil {
+			return []Change{c.Breaking(ChangeRemoved, "media type removed")}
+		}
+	}
+	return nil
+}
+
+func newRequiredParameterRule(c *RuleContext) []Change {
+	oldParam, ok := c.Old.(*Parameter)
+	if !ok {
+		return nil
+	}
+	newParam := c.New.(*Parameter)
+	switch {
+	case newParam == nil || !newParam.Required:
+	case oldParam == nil:
+		return []Change{c.Breaking(ChangeAdded, "required parameter added")}
+	case !oldParam.Required:
+		change := c.Breaking(ChangeModified, "parameter became required")
+		change.Location = joinLoc(c.Location, "required")
+		return []Change{change}
+	}
+	return nil
+}
+
+func newRequiredRequestBodyRule(c *RuleContext) []Change {
+	oldBody, ok := c.Old.(*RequestBody)
+	if !ok {
+		return nil
+	}
+	newBody := c.New.(*RequestBody)
+	switch {
+	case newBody == nil || !newBody.Required:
+	case oldBody == nil:
+		return []Change{c.Breaking(ChangeAdded, "required request body added")}
+	case !oldBody.Required:
+		change := c.Breaking(ChangeModified, "request body became required")
+		change.Location = joinLoc(c.Location, "required")
+		return []Change{change}
+	}
+	return nil
+}
+
+func removedEnumValueRule(c *RuleContext) []Change {
+	oldSchema, ok := c.Old.(*Schema)
+	if !ok || !c.Request || len(oldSchema.Enum) == 0 {
+		return nil
+	}
+	newSchema := c.New.(*Schema)
+	if len(newSchema.Enum) == 0 {
+		return nil
+	}
+	var changes []Change
+	for _, v := range oldSchema.Enum {
+		if !slices.ContainsFunc(newSchema.Enum, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+			changes = append(changes, c.Breaking(ChangeRemoved, "enum value %v removed", v))
+		}
+	}
+	return changes
+}
+
+func newRequiredPropertyRule(c *RuleContext) []Change {
+	oldSchema, ok := c.Old.(*Schema)
+	if !ok || !c.Request {
+		return nil
+	}
+	newSchema := c.New.(*Schema)
+	var changes []Change
+	for _, name := range sortedKeys(newSchema.Properties) {
+		if !slices.Contains(newSchema.Required, name) {
+			continue
+		}
+		switch {
+		case oldSchema.Properties[name] == nil:
+			change := c.Breaking(ChangeAdded, "required property added")
+			change.Location = joinLoc(c.Location, "properties", name)
+			changes = append(changes, change)
+		case !slices.Contains(oldSchema.Required, name):
+			change := c.Breaking(ChangeModified, "property became required")
+			change.Location = joinLoc(c.Location, "properties", name, "required")
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func removedPropertyRule(c *RuleContext) []Change {
+	oldSchema, ok := c.Old.(*Schema)
+	if !ok || c.Request {
+		return nil
+	}
+	newSchema := c.New.(*Schema)
+	var changes []Change
+	for _, name := range sortedKeys(oldSchema.Properties) {
+		if _, found := newSchema.Properties[name]; !found {
+			change := c.Breaking(ChangeRemoved, "property removed")
+			change.Location = joinLoc(c.Location, "properties", name)
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func tightenedConstraintRule(c *RuleContext) []Change {
+	oldSchema, ok := c.Old.(*Schema)
+	if !ok || !c.Request {
+		return nil
+	}
+	newSchema := c.New.(*Schema)
+
+	var changes []Change
+	lower := func(name string, oldValue, newValue *int) {
+		if newValue != nil && (oldValue == nil || *newValue < *oldValue) {
+			changes = append(changes, c.Breaking(ChangeModified, "%s decreased to %d", name, *newValue))
+		}
+	}
+	higher := func(name string, oldValue, newValue *int) {
+		if newValue != nil && (oldValue == nil || *newValue > *oldValue) {
+			changes = append(changes, c.Breaking(ChangeModified, "%s increased to %d", name, *newValue))
+		}
+	}
+	lower("maximum", oldSchema.Maximum, newSchema.Maximum)
+	lower("exclusiveMaximum", oldSchema.ExclusiveMaximum, newSchema.ExclusiveMaximum)
+	lower("maxLength", oldSchema.MaxLength, newSchema.MaxLength)
+	lower("maxItems", oldSchema.MaxItems, newSchema.MaxItems)
+	lower("maxProperties", oldSchema.MaxProperties, newSchema.MaxProperties)
+	higher("minimum", oldSchema.Minimum, newSchema.Minimum)
+	higher("exclusiveMinimum", oldSchema.ExclusiveMinimum, newSchema.ExclusiveMinimum)
+	higher("minLength", oldSchema.MinLength, newSchema.MinLength)
+	higher("minItems", oldSchema.MinItems, newSchema.MinItems)
+	higher("minProperties", oldSchema.MinProperties, newSchema.MinProperties)
+	if newSchema.Pattern != "" && newSchema.Pattern != oldSchema.Pattern {
+		changes = append(changes, c.Breaking(ChangeModified, "pattern changed to %q", newSchema.Pattern))
+	}
+	// the old values stay valid only if the old divisor is a multiple of the new one, e.g. 4 -> 2
+	if newSchema.MultipleOf != nil && (oldSchema.MultipleOf == nil || !isMultipleOf(*oldSchema.MultipleOf, *newSchema.MultipleOf)) {
+		changes = append(changes, c.Breaking(ChangeModified, "multipleOf changed to %v", *newSchema.MultipleOf))
+	}
+	if newSchema.UniqueItems != nil && *newSchema.UniqueItems && (oldSchema.UniqueItems == nil || !*oldSchema.UniqueItems) {
+		changes = append(changes, c.Breaking(ChangeModified, "uniqueItems enabled"))
+	}
+	if len(oldSchema.Enum) == 0 && len(newSchema.Enum) > 0 {
+		changes = append(changes, c.Breaking(ChangeModified, "enum added"))
+	}
+	return changes
+}
+
+func typeChangedRule(c *RuleContext) []Change {
+	oldSchema, ok := c.Old.(*Schema)
+	if !ok {
+		return nil
+	}
+	newSchema := c.New.(*Schema)
+	narrowed, widened := compareTypes(oldSchema.Type, newSchema.Type)
+	if c.Request && narrowed || !c.Request && widened {
+		change := c.Breaking(ChangeModified, "type changed from %s to %s", typesString(oldSchema.Type), typesString(newSchema.Type))
+		change.Location = joinLoc(c.Location, "type")
+		return []Change{change}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func breakingSpec(t *testing.T, paths string) *openapi.OpenAPI {
+	t.Helper()
+	var spec openapi.Extendable[openapi.OpenAPI]
+	data := `{"openapi": "3.1.1", "info": {"title": "test", "version": "1.0.0"}, "paths": ` + paths + `}`
+	require.NoError(t, json.Unmarshal([]byte(data), &spec))
+	return spec.Spec
+}
+
+// breakingSchemaPaths returns the paths with a single operation, which uses the given schema for the request body and for the response.
+func breakingSchemaPaths(schema string) string {
+	return `{"/pets": {"post": {
+		"requestBody": {"content": {"application/json": {"schema": ` + schema + `}}},
+		"responses": {"200": {"description": "ok", "content": {"application/json": {"schema": ` + schema + `}}}}
+	}}}`
+}
+
+func TestBreakingChanges(t *testing.T) {
+	const (
+		requestSchema  = "/paths/~1pets/post/requestBody/content/application~1json/schema"
+		responseSchema = "/paths/~1pets/post/responses/200/content/application~1json/schema"
+	)
+
+	for _, tt := range []struct {
+		name     string
+		oldPaths string
+		newPaths string
+		expected []openapi.Change
+	}{
+		{
+			name:     "no changes",
+			oldPaths: breakingSchemaPaths(`{"type": "string"}`),
+			newPaths: breakingSchemaPaths(`{"type": "string"}`),
+		},
+		{
+			name:     "removed path",
+			oldPaths: `{"/pets": {"get": {}}, "/users": {"get": {}}}`,
+			newPaths: `{"/pets": {"get": {}}}`,
+			expected: []openapi.Change{
+				{Location: "/paths/~1users", Kind: openapi.ChangeRemoved, Message: "path removed", Breaking: true},
+			},
+		},
+		{
+			name:     "removed operation",
+			oldPaths: `{"/pets": {"get": {}, "delete": {}}}`,
+			newPaths: `{"/pets": {"get": {}, "post": {}}}`,
+			expected: []openapi.Change{
+				{Location: "/paths/~1pets/delete", Kind: openapi.ChangeRemoved, Message: "operation removed", Breaking: true},
+			},
+		},
+		{
+			name:     "removed response",
+			oldPaths: `{"/pets": {"get": {"responses": {"200": {"description": "ok"}, "404": {"description": "not found"}}}}}`,
+			newPaths: `{"/pets": {"get": {"responses": {"200": {"description": "ok"}, "500": {"description": "error"}}}}}`,
+			expected: []openapi.Change{
+				{Location: "/paths/~1pets/get/responses/404", Kind: openapi.ChangeRemoved, Message: "response removed", Breaking: true},
+			},
+		},
+		{
+			name:     "narrowed response",
+			oldPaths: `{"/pets": {"get": {"responses": {"200": {"description": "ok", "content": {"application/json": {}, "application/xml": {}}}}}}}`,
+			newPaths: `{"/pets": {"get": {"responses": {"200": {"description": "ok", "content": {"application/json": {}}}}}}}`,
+			expected: []openapi.Change{
+				{Location: "/paths/~1pets/get/responses/200/content/application~1xml", Kind: openapi.ChangeRemoved, Message: "media type removed", Breaking: true},
+			},
+		},
+		{
+			name:     "new required parameter",
+			oldPaths: `{"/pets": {"parameters": [{"name": "limit", "in": "query"}], "get": {}}}`,
+			newPaths: `{"/pets": {"get": {"parameters": [
+				{"name": "limit", "in": "query", "required": true},
+				{"name": "tenant", "in": "header", "required": true},
+				{"name": "offset", "in": "query"}
+			]}}}`,
+			expected: []openapi.Change{
+				{Location: "/paths/~1pets/get/parameters/header/tenant", Kind: openapi.ChangeAdded, Message: "required parameter added", Breaking: true},
+				{Location: "/paths/~1pets/get/parameters/query/limit/required", Kind: openapi.ChangeModified, Message: "parameter became required", Breaking: true},
+			},
+		},
+		{
+			name:     "new required request body",
+			oldPaths: `{"/pets": {"post": {}, "put": {"requestBody": {"content": {}}}}}`,
+			newPaths: `{"/pets": {"post": {"requestBody": {"required": true, "content": {}}}, "put": {"requestBody": {"required": true, "content": {}}}}}`,
+			expected: []openapi.Change{
+				{Location: "/paths/~1pets/put/requestBody/required", Kind: openapi.ChangeModified, Message: "request body became required", Breaking: true},
+				{Location: "/paths/~1pets/post/requestBody", Kind: openapi.ChangeAdded, Message: "required request body added", Breaking: true},
+			},
+		},
+		{
+			name:     "removed enum value",
+			oldPaths: breakingSchemaPaths(`{"type": "string", "enum": ["cat", "dog", "fish"]}`),
+			newPaths: breakingSchemaPaths(`{"type": "string", "enum": ["cat", "fish", "bird"]}`),
+			expected: []openapi.Change{
+				{Location: requestSchema, Kind: openapi.ChangeRemoved, Message: "enum value dog removed", Breaking: true},
+			},
+		},
+		{
+			name:     "tightened constraint",
+			oldPaths: breakingSchemaPaths(`{"type": "object", "properties": {"age": {"type": "integer", "maximum": 100}, "name": {"type": "string", "maxLength": 10}}}`),
+			newPaths: breakingSchemaPaths(`{"type": "object", "properties": {"age": {"type": "integer", "maximum": 50, "minimum": 1}, "name": {"type": "string", "maxLength": 20}}}`),
+			expected: []openapi.Change{
+				{Location: requestSchema + "/properties/age", Kind: openapi.ChangeModified, Message: "maximum decreased to 50", Breaking: true},
+				{Location: requestSchema + "/properties/age", Kind: openapi.ChangeModified, Message: "minimum increased to 1", Breaking: true},
+			},
+		},
+		{
+			name:     "decimal multipleOf",
+			oldPaths: breakingSchemaPaths(`{"type": "object", "properties": {"price": {"type": "number", "multipleOf": 0.01}, "ratio": {"type": "number", "multipleOf": 0.3}}}`),
+			newPaths: breakingSchemaPaths(`{"type": "object", "properties": {"price": {"type": "number", "multipleOf": 0.1}, "ratio": {"type": "number", "multipleOf": 0.1}}}`),
+			expected: []openapi.Change{
+				{Location: requestSchema + "/properties/price", Kind: openapi.ChangeModified, Message: "multipleOf changed to 0.1", Breaking: true},
+			},
+		},
+		{
+			name:     "integer multipleOf",
+			oldPaths: breakingSchemaPaths(`{"type": "object", "properties": {"even": {"type": "integer", "multipleOf": 2}, "quad": {"type": "integer", "multipleOf": 4}}}`),
+			newPaths: breakingSchemaPaths(`{"type": "object", "properties": {"even": {"type": "integer", "multipleOf": 4}, "quad": {"type": "integer", "multipleOf": 2}}}`),
+			expected: []openapi.Change{
+				{Location: requestSchema + "/properties/even", Kind: openapi.ChangeModified, Message: "multipleOf changed to 4", Breaking: true},
+			},
+		},
+		{
+			name:     "new required property",
+			oldPaths: breakingSchemaPaths(`{"type": "object", "properties": {"name": {"type": "string"}}}`),
+			newPaths: breakingSchemaPaths(`{"type": "object", "required": ["name", "owner"], "properties": {"name": {"type": "string"}, "owner": {"type": "string"}}}`),
+			expected: []openapi.Change{
+				{Location: requestSchema + "/properties/name/required", Kind: openapi.ChangeModified, Message: "property became required", Breaking: true},
+				{Location: requestSchema + "/properties/owner", Kind: openapi.ChangeAdded, Message: "required property added", Breaking: true},
+			},
+		},
+		{
+			name:     "type changed",
+			oldPaths: breakingSchemaPaths(`{"type": "object", "properties": {"age": {"type": "number"}, "id": {"type": "integer"}}}`),
+			newPaths: breakingSchemaPaths(`{"type": "object", "properties": {"age": {"type": "integer"}, "id": {"type": "number"}}}`),
+			expected: []openapi.Change{
+				{Location: requestSchema + "/properties/age/type", Kind: openapi.ChangeModified, Message: "type changed from number to integer", Breaking: true},
+				{Location: responseSchema + "/properties/id/type", Kind: openapi.ChangeModified, Message: "type changed from integer to number", Breaking: true},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := openapi.BreakingChanges(breakingSpec(t, tt.oldPaths), breakingSpec(t, tt.newPaths))
+			require.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestBreakingChanges_SharedSchema(t *testing.T) {
+	newSpec := func(pet string) *openapi.OpenAPI {
+		var spec openapi.Extendable[openapi.OpenAPI]
+		data := `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {"/pets": {"post": {
+    "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+    "responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+  }}},
+  "components": {"schemas": {"Pet": ` + pet + `}}
+}`
+		require.NoError(t, json.Unmarshal([]byte(data), &spec))
+		return spec.Spec
+	}
+	oldSpec := newSpec(`{"type": "object", "properties": {"name": {"type": "string"}, "tag": {"type": "string"}, "age": {"type": "integer"}}}`)
+	changes := openapi.BreakingChanges(oldSpec, newSpec(`{"type": "object", "properties": {"name": {"type": "string"}, "age": {"type": "number"}}}`))
+	const responseSchema = "/paths/~1pets/post/responses/200/content/application~1json/schema"
+	require.Equal(t, []openapi.Change{
+		{Location: responseSchema + "/properties/tag", Kind: openapi.ChangeRemoved, Message: "property removed", Breaking: true},
+		{Location: responseSchema + "/properties/age/type", Kind: openapi.ChangeModified, Message: "type changed from integer to number", Breaking: true},
+	}, changes)
+
+	changes = openapi.BreakingChanges(oldSpec, newSpec(`{"type": "object", "properties": {"name": {"type": "string"}, "age": {"type": "integer"}}}`), openapi.WithoutBreakingRule(openapi.RuleRemovedProperty))
+	require.Empty(t, changes)
+}
+
+func TestBreakingChanges_Diff(t *testing.T) {
+	oldSpec := breakingSpec(t, breakingSchemaPaths(`{"type": "object", "properties": {"name": {"type": "string"}, "tag": {"type": "string"}}}`))
+	newSpec := breakingSpec(t, breakingSchemaPaths(`{"type": "object", "required": ["owner"], "properties": {"name": {"type": "string"}, "owner": {"type": "string"}}}`))
+
+	diff, err := openapi.Diff(oldSpec, newSpec)
+	require.NoError(t, err)
+	changes := openapi.BreakingChanges(oldSpec, newSpec)
+	require.Len(t, changes, 2)
+	require.Equal(t, changes, diff.Breaking())
+}
+
+func TestBreakingChanges_CustomRule(t *testing.T) {
+	oldSpec := breakingSpec(t, `{"/pets": {"get": {"operationId": "listPets"}, "delete": {}}}`)
+	newSpec := breakingSpec(t, `{"/pets": {"get": {"operationId": "getPets"}}}`)
+
+	renamed := func(c *openapi.RuleContext) []openapi.Change {
+		oldOp, ok := c.Old.(*openapi.Operation)
+		if !ok || oldOp == nil {
+			return nil
+		}
+		newOp := c.New.(*openapi.Operation)
+		if newOp != nil && oldOp.OperationID != newOp.OperationID {
+			return []openapi.Change{c.Breaking(openapi.ChangeModified, "operationId changed from %s to %s", oldOp.OperationID, newOp.OperationID)}
+		}
+		return nil
+	}
+
+	changes := openapi.BreakingChanges(oldSpec, newSpec,
+		openapi.WithBreakingRule("renamed-operation", renamed),
+		openapi.WithoutBreakingRule(openapi.RuleRemovedEndpoint),
+	)
+	require.Equal(t, []openapi.Change{
+		{Location: "/paths/~1pets/get", Kind: openapi.ChangeModified, Message: "operationId changed from listPets to getPets", Breaking: true},
+	}, changes)
+}
+
+func TestBreakingChanges_UnresolvedRef(t *testing.T) {
+	oldSpec := breakingSpec(t, `{"/pets": {"get": {}}}`)
+	newSpec := breakingSpec(t, `{"/pets": {"$ref": "#/components/paths/pets"}}`)
+
+	changes := openapi.BreakingChanges(oldSpec, newSpec)
+	require.Len(t, changes, 1)
+	require.Equal(t, true, changes[0].Breaking)
+	require.Equal(t, `unable to compare: /paths/~1pets: spec not found: components is required, but got nil; visited refs: `, changes[0].Message)
+}
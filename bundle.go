@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Resolver loads the external documents referenced by `$ref` fields.
+type Resolver interface {
+	// Resolve returns the content of the JSON document located by the given URI.
+	// The URI is either an absolute URL or a path relative to the root document, without a fragment.
+	Resolve(uri string) ([]byte, error)
+}
+
+// ResolverFunc is an adapter to allow the use of ordinary functions as Resolver.
+type ResolverFunc func(uri string) ([]byte, error)
+
+// Resolve calls f(uri).
+func (f ResolverFunc) Resolve(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+// refOrSpec is implemented by all RefOrSpec types to process them regardless of the type of the Spec.
+type refOrSpec interface {
+	getRef() *Ref
+	// decode creates an object of the same type from the given JSON data.
+	decode(data []byte) (refOrSpec, error)
+	// inline replaces the Ref with a deep copy of the referenced Spec and returns the Spec.
+	inline(c *Extendable[Components]) (any, error)
+}
+
+func (o *RefOrSpec[T]) getRef() *Ref {
+	return o.Ref
+}
+
+func (o *RefOrSpec[T]) decode(data []byte) (refOrSpec, error) {
+	var v RefOrSpec[T]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (o *RefOrSpec[T]) inline(c *Extendable[Components]) (any, error) {
+	spec, err := o.GetSpec(c)
+	if err != nil {
+		return nil, err
+	}
+	// copy before changing the object, because the spec can contain the object itself
+	spec = DeepCopy(spec)
+	o.Ref = nil
+	o.Spec = spec
+	return spec, nil
+}
+
+// componentKindOf returns the kind of the component that can store the given object.
+func componentKindOf(v refOrSpec) (ComponentKind, bool) {
+	switch v.(type) {
+	case *RefOrSpec[Schema]:
+		return ComponentSchemas, true
+	case *RefOrSpec[Extendable[Response]]:
+		return ComponentResponses, true
+	case *RefOrSpec[Extendable[Parameter]]:
+		return ComponentParameters, true
+	case *RefOrSpec[Extendable[Example]]:
+		return ComponentExamples, true
+	case *RefOrSpec[Extendable[RequestBody]]:
+		return ComponentRequestBodies, true
+	case *RefOrSpec[Extendable[Header]]:
+		return ComponentHeaders, true
+	case *RefOrSpec[Extendable[SecurityScheme]]:
+		return ComponentSecuritySchemes, true
+	case *RefOrSpec[Extendable[Link]]:
+		return ComponentLinks, true
+	case *RefOrSpec[Extendable[Callback]]:
+		return ComponentCallbacks, true
+	case *RefOrSpec[Extendable[PathItem]]:
+		return ComponentPaths, true
+	default:
+		return "", false
+	}
+}
+
+// Bundle creates a copy of the given document with all external references moved into the components,
+// so the result is a single self-contained document.
+//
+// The resolver is used to load the external documents.
+// The identical targets are added only once, the names of the components are derived from the last token
+// of the JSON Pointer or from the name of the file, if the whole document is referenced.
+// The local references, e.g. `#/components/schemas/Pet`, are left untouched,
+// the local references inside the external documents are bundled as well.
+// The circular references are preserved as references to the bundled components.
+func Bundle(doc *OpenAPI, resolver Resolver) (*OpenAPI, error) {
+	bundled := doc.Clone()
+	if bundled.Components == nil {
+		bundled.Components = NewComponents()
+	}
+
+	b := bundler{
+		resolver:   resolver,
+		components: bundled.Components.Spec,
+		docs:       make(map[string]any),
+		names:      make(map[string]string),
+		visited:    make(map[uintptr]bool),
+	}
+	if err := b.walk(reflect.ValueOf(bundled), ""); err != nil {
+		return nil, err
+	}
+	return bundled, nil
+}
+
+type bundler struct {
+	resolver   Resolver
+	components *Components
+	// docs is the cache of the decoded external documents by URI
+	docs map[string]any
+	// names is the names of the bundled components by absolute references
+	names   map[string]string
+	visited map[uintptr]bool
+}
+
+// walk searches the references in the given object, the base is the URI of the document the object is loaded from.
+func (b *bundler) walk(v reflect.Value, base string) error {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			return b.walk(v.Elem(), base)
+		}
+	case reflect.Pointer:
+		if v.IsNil() || b.visited[v.Pointer()] {
+			return nil
+		}
+		b.visited[v.Pointer()] = true
+		if o, ok := v.Interface().(refOrSpec); ok && o.getRef() != nil {
+			return b.bundle(o, base)
+		}
+		return b.walk(v.Elem(), base)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := b.walk(v.Field(i), base); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// sort the keys to generate the stable names
+		keys := v.MapKeys()
+		if v.Type().Key().Kind() == reflect.String {
+			slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		}
+		for _, k := range keys {
+			if err := b.walk(v.MapIndex(k), base); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := b.walk(v.Index(i), base); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bundle moves the target of the given reference into the components and updates the reference.
+func (b *bundler) bundle(o refOrSpec, base string) error {
+	ref := o.getRef()
+	uri, fragment, err := resolveURI(base, ref.Ref)
+	if err != nil {
+		return err
+	}
+	if uri == "" {
+		// the reference to the root document
+		ref.Ref = "#" + fragment
+		return nil
+	}
+	kind, ok := componentKindOf(o)
+	if !ok {
+		return fmt.Errorf("unable to bundle %q: unsupported type %T", ref.Ref, o)
+	}
+	absRef := uri + "#" + fragment
+	if name, found := b.names[absRef]; found {
+		ref.Ref = kind.Ref(name)
+		return nil
+	}
+
+	target, err := b.load(uri, fragment)
+	if err != nil {
+		return fmt.Errorf("unable to bundle %q: %w", ref.Ref, err)
+	}
+	data, err := json.Marshal(target)
+	if err != nil {
+		return fmt.Errorf("unable to bundle %q: %w", ref.Ref, err)
+	}
+	component, err := o.decode(data)
+	if err != nil {
+		return fmt.Errorf("unable to bundle %q: %w", ref.Ref, err)
+	}
+
+	m, err := b.components.componentsMap(kind)
+	if err != nil {
+		return err
+	}
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	name := b.uniqueName(m, componentName(uri, fragment))
+	m.SetMapIndex(reflect.ValueOf(name), reflect.ValueOf(component))
+	// the name must be registered before walking the component to preserve the circular references
+	b.names[absRef] = name
+	ref.Ref = kind.Ref(name)
+
+	return b.walk(reflect.ValueOf(component), uri)
+}
+
+// load returns the object located by the fragment in the document with the given URI.
+func (b *bundler) load(uri, fragment string) (any, error) {
+	doc, found := b.docs[uri]
+	if !found {
+		data, err := b.resolver.Resolve(uri)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("decoding %q failed: %w", uri, err)
+		}
+		b.docs[uri] = doc
+	}
+	return ResolvePointer(doc, "#"+fragment)
+}
+
+func (b *bundler) uniqueName(m reflect.Value, name string) string {
+	unique := name
+	for i := 1; m.MapIndex(reflect.ValueOf(unique)).IsValid(); i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	return unique
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]+`)
+
+// componentName generates the name of the component from the last token of the pointer or from the file name.
+func componentName(uri, fragment string) string {
+	var name string
+	if tokens, err := splitPointer("#" + fragment); err == nil && len(tokens) > 0 {
+		name = tokens[len(tokens)-1]
+	}
+	if name == "" {
+		name = path.Base(uri)
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	if name == "" || name == "." {
+		name = "component"
+	}
+	return name
+}
+
+// resolveURI resolves the given reference against the base URI and returns the URI of the document and the fragment.
+// The URI is empty for the references to the root document.
+func resolveURI(base, ref string) (uri, fragment string, err error) {
+	uri, fragment, _ = strings.Cut(ref, "#")
+	if uri == "" {
+		return base, fragment, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid ref %q: %w", ref, err)
+	}
+	if u.IsAbs() || path.IsAbs(uri) {
+		return uri, fragment, nil
+	}
+	if b, err := url.Parse(base); err == nil && b.IsAbs() {
+		return b.ResolveReference(u).String(), fragment, nil
+	}
+	return path.Join(path.Dir(base), uri), fragment, nil
+}
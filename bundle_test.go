@@ -0,0 +1,147 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const bundleSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Bundle Example", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "list of pets",
+            "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "schemas/common.json#/schemas/Pet"}}}}
+          },
+          "default": {"$ref": "responses/error.json"}
+        }
+      },
+      "post": {
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "./schemas/common.json#/schemas/Pet"}}}
+        },
+        "responses": {
+          "201": {
+            "description": "created",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Created"}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Created": {"type": "object", "properties": {"pet": {"$ref": "other.json#/Pet"}}}
+    }
+  }
+}`
+
+var bundleFiles = map[string]string{
+	"schemas/common.json": `{
+  "schemas": {
+    "Pet": {"type": "object", "properties": {"tree": {"$ref": "#/schemas/Node"}, "owner": {"$ref": "../users.json#/User"}}},
+    "Node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/schemas/Node"}}}}
+  }
+}`,
+	"responses/error.json": `{"description": "error", "content": {"application/json": {"schema": {"$ref": "../schemas/common.json#/schemas/Pet"}}}}`,
+	"users.json":           `{"User": {"type": "object", "properties": {"pets": {"type": "array", "items": {"$ref": "schemas/common.json#/schemas/Pet"}}}}}`,
+	"other.json":           `{"Pet": {"type": "string"}}`,
+}
+
+func TestBundle(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(bundleSpec), &spec))
+
+	var resolved []string
+	resolver := openapi.ResolverFunc(func(uri string) ([]byte, error) {
+		resolved = append(resolved, uri)
+		data, found := bundleFiles[uri]
+		if !found {
+			return nil, errors.New("not found")
+		}
+		return []byte(data), nil
+	})
+
+	bundled, err := openapi.Bundle(spec.Spec, resolver)
+	require.NoError(t, err)
+	require.Equal(t, []string{"other.json", "responses/error.json", "schemas/common.json", "users.json"}, resolved)
+
+	data, err := json.Marshal(bundled)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "openapi": "3.1.1",
+  "info": {"title": "Bundle Example", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "list of pets",
+            "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet_1"}}}}
+          },
+          "default": {"$ref": "#/components/responses/error"}
+        }
+      },
+      "post": {
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet_1"}}}
+        },
+        "responses": {
+          "201": {
+            "description": "created",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Created"}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Created": {"type": "object", "properties": {"pet": {"$ref": "#/components/schemas/Pet"}}},
+      "Pet_1": {"type": "object", "properties": {"tree": {"$ref": "#/components/schemas/Node"}, "owner": {"$ref": "#/components/schemas/User"}}},
+      "Node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/components/schemas/Node"}}}},
+      "User": {"type": "object", "properties": {"pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet_1"}}}},
+      "Pet": {"type": "string"}
+    },
+    "responses": {
+      "error": {"description": "error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet_1"}}}}
+    }
+  }
+}`, string(data))
+
+	// the original document is not changed
+	require.Equal(t, "other.json#/Pet", spec.Spec.Components.Spec.Schemas["Created"].Spec.Properties["pet"].Ref.Ref)
+}
+
+func TestBundle_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{name: "missing file", files: map[string]string{}, err: "not found"},
+		{name: "invalid json", files: map[string]string{"other.json": `{`}, err: "decoding \"other.json\" failed"},
+		{name: "missing pointer", files: map[string]string{"other.json": `{"Dog": {}}`}, err: "pointer not found"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := openapi.NewOpenAPIBuilder().
+				AddComponent("Pet", openapi.NewSchemaBuilder().Ref("other.json#/Pet").Build()).
+				Build()
+			_, err := openapi.Bundle(doc.Spec, openapi.ResolverFunc(func(uri string) ([]byte, error) {
+				data, found := tt.files[uri]
+				if !found {
+					return nil, errors.New("not found")
+				}
+				return []byte(data), nil
+			}))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
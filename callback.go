@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"encoding/json"
+	"strings"
 )
 
 // Callback is a map of possible out-of band callbacks related to the parent operation.
@@ -44,11 +45,47 @@ func (o *Callback) UnmarshalJSON(data []byte) error {
 func (o *Callback) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	for k, v := range o.Paths {
+		if err := checkCallbackExpression(k); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, k), err))
+		}
 		errs = append(errs, v.validateSpec(joinLoc(location, k), validator)...)
 	}
 	return errs
 }
 
+// checkCallbackExpression validates the key of a callback, which is either a runtime expression,
+// e.g. `$request.body#/url`, or a URL with the runtime expressions embedded in curly braces,
+// e.g. `{$request.query.queryUrl}` or `https://example.com/data?id={$request.body#/id}`.
+func checkCallbackExpression(key string) error {
+	if strings.HasPrefix(key, "$") {
+		_, err := ParseRuntimeExpression(key)
+		return err
+	}
+	rest := key
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			return nil
+		}
+		if rest[start] == '}' {
+			return NewInvalidRuntimeExpressionError(key, "unexpected '}'")
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return NewInvalidRuntimeExpressionError(key, "missing '}'")
+		}
+		expression := rest[start+1 : start+end]
+		if !strings.HasPrefix(expression, "$") {
+			return NewInvalidRuntimeExpressionError(key, "expected runtime expression in '{"+expression+"}'")
+		}
+		if _, err := ParseRuntimeExpression(expression); err != nil {
+			return err
+		}
+		rest = rest[start+end+1:]
+	}
+	return nil
+}
+
 func (o *Callback) Add(expression string, item *RefOrSpec[Extendable[PathItem]]) *Callback {
 	if o.Paths == nil {
 		o.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]], 1)
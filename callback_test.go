@@ -0,0 +1,49 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestCallback_validateSpec(t *testing.T) {
+	for _, tt := range []struct {
+		key string
+		err string
+	}{
+		{key: "{$request.query.url}"},
+		{key: "{$request.body#/callbackUrl}/data?id={$response.header.X-Id}"},
+		{key: "$request.body#/url"},
+		{key: "https://example.com/callback"},
+		{key: "{$request.foo}", err: "/components/callbacks/onEvent/{$request.foo}: invalid runtime expression"},
+		{key: "{$request.query.url", err: "missing '}'"},
+		{key: "$foo", err: "invalid runtime expression"},
+		{key: "https://example.com/{id}", err: "expected runtime expression in '{id}'"},
+		{key: "https://example.com/id}", err: "unexpected '}'"},
+	} {
+		t.Run(tt.key, func(t *testing.T) {
+			operation := openapi.NewOperationBuilder().Build()
+			operation.Spec.Responses = openapi.NewResponsesBuilder().
+				AddResponse("200", openapi.NewResponseBuilder().Description("ok").Build()).
+				Build().Spec
+			callback := openapi.NewCallbackBuilder().
+				AddPathItem(tt.key, openapi.NewPathItemBuilder().Post(operation).Build()).
+				Build()
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("onEvent", callback).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			if tt.err == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.ErrorContains(t, errs[0], tt.err)
+		})
+	}
+}
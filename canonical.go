@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MarshalCanonical returns the JSON encoding of the given document in the canonical form,
+// so the same document always produces the same output and the committed specs are diff-friendly.
+//
+// All keys of the objects are sorted lexically, except:
+//   - the paths, which are sorted by PathPrecedence, so the concrete paths go before the templated ones;
+//   - the response codes, which are sorted numerically, the ranges like `2XX` after the codes of the same class,
+//     then `default` and then the extensions.
+//
+// The output is indented with two spaces.
+func MarshalCanonical(doc *OpenAPI) ([]byte, error) {
+	return marshalOrdered(doc, canonicalComparator)
+}
+
+// marshalOrdered marshals the document with the keys of each object sorted by the comparator,
+// returned by the given function for the keys of the object location.
+func marshalOrdered(doc *OpenAPI, comparator func(keys []string) func(a, b string) int) ([]byte, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var root any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if err := writeOrdered(&compact, root, nil, comparator); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func writeOrdered(buf *bytes.Buffer, v any, keys []string, comparator func(keys []string) func(a, b string) int) error {
+	switch t := v.(type) {
+	case map[string]any:
+		names := make([]string, 0, len(t))
+		for k := range t {
+			names = append(names, k)
+		}
+		slices.SortFunc(names, comparator(keys))
+		buf.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			data, err := json.Marshal(name)
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+			buf.WriteByte(':')
+			if err := writeOrdered(buf, t[name], append(slices.Clip(keys), name), comparator); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrdered(buf, item, append(slices.Clip(keys), strconv.Itoa(i)), comparator); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+// canonicalComparator returns the function to sort the keys of the object located by the given keys.
+func canonicalComparator(keys []string) func(a, b string) int {
+	switch {
+	case len(keys) == 1 && keys[0] == "paths":
+		return func(a, b string) int {
+			// the extensions of the paths object go after the paths
+			if ax, bx := strings.HasPrefix(a, ExtensionPrefix), strings.HasPrefix(b, ExtensionPrefix); ax != bx {
+				if ax {
+					return 1
+				}
+				return -1
+			}
+			switch {
+			case PathPrecedence(a, b):
+				return -1
+			case PathPrecedence(b, a):
+				return 1
+			default:
+				return 0
+			}
+		}
+	case len(keys) > 0 && keys[len(keys)-1] == "responses" && (len(keys) != 2 || keys[0] != "components"):
+		return compareResponseCodes
+	default:
+		return strings.Compare
+	}
+}
+
+// compareResponseCodes sorts the status codes numerically, the ranges after the codes of the same class,
+// then `default` and the other keys, like the extensions, lexically.
+func compareResponseCodes(a, b string) int {
+	rank := func(k string) int {
+		switch {
+		case len(k) == 3 && k[0] >= '1' && k[0] <= '5' && (k[1:] == "XX" || isDigits(k[1:])):
+			return 0
+		case k == "default":
+			return 1
+		default:
+			return 2
+		}
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+	// the codes have the same length and the `X` is bigger than the digits, so the lexical order is the numeric one
+	return strings.Compare(a, b)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
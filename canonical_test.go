@@ -0,0 +1,78 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "petstore.json"))
+	require.NoError(t, err)
+	var doc openapi.OpenAPI
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	first, err := openapi.MarshalCanonical(&doc)
+	require.NoError(t, err)
+	second, err := openapi.MarshalCanonical(doc.Clone())
+	require.NoError(t, err)
+	require.Equal(t, string(first), string(second))
+	require.JSONEq(t, string(first), mustMarshal(t, &doc))
+	require.Equal(t, true, strings.HasPrefix(string(first), "{\n  \"components\": {"))
+}
+
+func TestMarshalCanonical_ResponseCodes(t *testing.T) {
+	var doc openapi.OpenAPI
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "x-order": "last",
+          "default": {"description": "default"},
+          "500": {"description": "500"},
+          "2XX": {"description": "2XX"},
+          "404": {"description": "404"},
+          "201": {"description": "201"},
+          "200": {"description": "200"}
+        }
+      }
+    }
+  }
+}`), &doc))
+
+	data, err := openapi.MarshalCanonical(&doc)
+	require.NoError(t, err)
+	pos := -1
+	for _, key := range []string{`"200": {`, `"201": {`, `"2XX": {`, `"404": {`, `"500": {`, `"default": {`, `"x-order": `} {
+		i := strings.Index(string(data), key)
+		require.Truef(t, i > pos, "%s is out of order:\n%s", key, data)
+		pos = i
+	}
+}
+
+func TestMarshalCanonical_Paths(t *testing.T) {
+	doc := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		AddPath("/pets/{id}", openapi.NewPathItemBuilder().Build()).
+		AddPath("/pets/mine", openapi.NewPathItemBuilder().Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().Build()).
+		Build()
+	doc.Spec.Paths.AddExt("x-internal", true)
+
+	data, err := openapi.MarshalCanonical(doc.Spec)
+	require.NoError(t, err)
+	pos := -1
+	for _, key := range []string{`"/pets": {`, `"/pets/mine": {`, `"/pets/{id}": {`, `"x-internal": `} {
+		i := strings.Index(string(data), key)
+		require.Truef(t, i > pos, "%s is out of order:\n%s", key, data)
+		pos = i
+	}
+}
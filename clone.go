@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"reflect"
+)
+
+// DeepCopy returns an independent deep copy of the given object.
+//
+// All pointers, maps, slices and interface values are copied recursively,
+// so changing the copy never affects the original object.
+// The pointers shared between several places of the original object are shared in the copy as well,
+// which also keeps any circular structures.
+// The unexported fields cannot be set by reflection, so they are copied as is, i.e. shallowly:
+// the copy shares their pointers, maps and slices with the original object,
+// e.g. the list of the forbidden fields of a Header, which is never modified in place.
+func DeepCopy[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := deepCopier{copies: make(map[pointerKey]reflect.Value)}
+	return c.copy(reflect.ValueOf(v)).Interface().(*T)
+}
+
+type pointerKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type deepCopier struct {
+	copies map[pointerKey]reflect.Value
+}
+
+func (c *deepCopier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := pointerKey{ptr: v.Pointer(), typ: v.Type()}
+		if p, found := c.copies[key]; found {
+			return p
+		}
+		p := reflect.New(v.Type().Elem())
+		// register the copy before copying the value to handle the circular structures
+		c.copies[key] = p
+		p.Elem().Set(c.copy(v.Elem()))
+		return p
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		i := reflect.New(v.Type()).Elem()
+		i.Set(c.copy(v.Elem()))
+		return i
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			s.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return s
+	case reflect.Array:
+		a := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			a.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return a
+	case reflect.Struct:
+		s := reflect.New(v.Type()).Elem()
+		// the unexported fields cannot be copied deeply, so they are kept as is
+		s.Set(v)
+		for i := range v.NumField() {
+			if f := s.Field(i); f.CanSet() {
+				f.Set(c.copy(v.Field(i)))
+			}
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// Clone returns a deep copy of the object.
+func (o *OpenAPI) Clone() *OpenAPI {
+	return DeepCopy(o)
+}
+
+// Clone returns a deep copy of the object.
+func (o *Components) Clone() *Components {
+	return DeepCopy(o)
+}
+
+// Clone returns a deep copy of the object.
+func (o *Schema) Clone() *Schema {
+	return DeepCopy(o)
+}
+
+// Clone returns a deep copy of the object, including the extensions.
+func (o *Extendable[T]) Clone() *Extendable[T] {
+	return DeepCopy(o)
+}
+
+// Clone returns a deep copy of the object, either the Ref or the Spec.
+func (o *RefOrSpec[T]) Clone() *RefOrSpec[T] {
+	return DeepCopy(o)
+}
+
+// Clone returns a deep copy of the object.
+func (o *SingleOrArray[T]) Clone() *SingleOrArray[T] {
+	return DeepCopy(o)
+}
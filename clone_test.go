@@ -0,0 +1,69 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestOpenAPI_Clone(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(linkSpec), &spec))
+	spec.AddExt("build", map[string]any{"commit": "abc"})
+	spec.Spec.Components = openapi.NewComponents()
+	spec.Spec.Components.Spec.
+		Add("ID", openapi.NewSchemaBuilder().Type(openapi.StringType, openapi.NullType).Build()).
+		Add("User", openapi.NewSchemaBuilder().Ref("#/components/schemas/ID").Build())
+	original, err := json.Marshal(&spec)
+	require.NoError(t, err)
+
+	clone := spec.Clone()
+	cloneData, err := json.Marshal(clone)
+	require.NoError(t, err)
+	require.JSONEq(t, string(original), string(cloneData))
+
+	clone.Extensions["x-build"].(map[string]any)["commit"] = "def"
+	clone.Spec.Info.Spec.Title = "Changed"
+	clone.Spec.Paths.Spec.Paths["/users/{id}"].Spec.Spec.Parameters[0].Spec.Spec.Name = "uid"
+	clone.Spec.Paths.Spec.Paths["/users/{id}"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"].Spec.Spec.Links["address"].Spec.Spec.Parameters["userId"] = "$request.path.uid"
+	delete(clone.Spec.Paths.Spec.Paths, "/users/{userid}/address")
+	clone.Spec.Components.Spec.Schemas["ID"].Spec.Type.Add(openapi.IntegerType)
+	(*clone.Spec.Components.Spec.Schemas["ID"].Spec.Type)[0] = openapi.BooleanType
+	clone.Spec.Components.Spec.Schemas["User"].Ref.Ref = "#/components/schemas/UID"
+
+	actual, err := json.Marshal(&spec)
+	require.NoError(t, err)
+	require.JSONEq(t, string(original), string(actual))
+}
+
+func TestDeepCopy_SharedPointers(t *testing.T) {
+	shared := openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+	schema := openapi.NewSchemaBuilder().
+		AddProperty("a", shared).
+		AddProperty("b", shared).
+		Build()
+
+	clone := schema.Clone()
+	require.Truef(t, clone.Spec.Properties["a"] == clone.Spec.Properties["b"], "shared pointer is not shared in the clone")
+	require.Truef(t, clone.Spec.Properties["a"] != shared, "shared pointer is not copied")
+
+	var nilSchema *openapi.Schema
+	require.Nil(t, nilSchema.Clone())
+}
+
+func TestDeepCopy_UnexportedFields(t *testing.T) {
+	var header *openapi.RefOrSpec[openapi.Extendable[openapi.Header]]
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "X-Rate-Limit", "schema": {"type": "integer"}}`), &header))
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddComponent("RateLimit", header).
+		Build()
+
+	// the forbidden fields of the original header are kept by the copy
+	validator, err := openapi.NewValidator(openapi.DeepCopy(spec), openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.ErrorContains(t, validator.ValidateSpec(), "/components/headers/RateLimit/name: not allowed for header")
+}
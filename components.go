@@ -1,9 +1,46 @@
 package openapi
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
+	"sync"
 )
 
+var (
+	ErrComponentNotFound = errors.New("component not found")
+	ErrComponentExists   = errors.New("component already exists")
+	// ErrUnsupportedComponentKind is returned for a ComponentKind, which is not a field of Components.
+	ErrUnsupportedComponentKind = errors.New("unsupported component kind")
+	// ErrComponentWrongType is returned by AddKind if the object is not of the type of the kind.
+	ErrComponentWrongType = errors.New("component has wrong type")
+	// ErrInvalidComponentName is returned if the name of a component does not match the allowed pattern.
+	ErrInvalidComponentName = errors.New("invalid component name")
+)
+
+// ComponentKind is the type of reusable objects in the Components, the value is the name of the field in the Components object.
+type ComponentKind string
+
+const (
+	ComponentSchemas         ComponentKind = "schemas"
+	ComponentResponses       ComponentKind = "responses"
+	ComponentParameters      ComponentKind = "parameters"
+	ComponentExamples        ComponentKind = "examples"
+	ComponentRequestBodies   ComponentKind = "requestBodies"
+	ComponentHeaders         ComponentKind = "headers"
+	ComponentSecuritySchemes ComponentKind = "securitySchemes"
+	ComponentLinks           ComponentKind = "links"
+	ComponentCallbacks       ComponentKind = "callbacks"
+	ComponentPaths           ComponentKind = "paths"
+)
+
+// Ref returns the local reference to the component of the kind with the given name, e.g. `#/components/schemas/Pet`.
+func (k ComponentKind) Ref(name string) string {
+	return joinLoc("#", "components", string(k), name)
+}
+
 // Components holds a set of reusable objects for different aspects of the OAS.
 // All objects defined within the components object will have no effect on the API unless they are explicitly referenced
 // from properties outside the components object.
@@ -103,141 +140,283 @@ type Components struct {
 }
 
 // Add adds the given object to the appropriate list based on a type and returns the current object (self|this).
+// The objects of unsupported types are ignored, use AddKind to get an error instead.
 func (o *Components) Add(name string, v any) *Components {
-	if v == nil {
-		return o
+	if spec, ok := v.(refOrSpec); ok {
+		if kind, ok := componentKindOf(spec); ok {
+			_ = o.AddKind(kind, name, v)
+		}
+	}
+	return o
+}
+
+// AddKind adds the given object to the components of the given kind.
+// It fails if the kind is unknown or the object is not of the type of the kind,
+// e.g. `*RefOrSpec[Schema]` for ComponentSchemas or `*RefOrSpec[Extendable[Parameter]]` for ComponentParameters.
+func (o *Components) AddKind(kind ComponentKind, name string, v any) error {
+	m, err := o.componentsMap(kind)
+	if err != nil {
+		return err
 	}
-	switch spec := v.(type) {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() || value.Type() != m.Type().Elem() {
+		return fmt.Errorf("%s: %w: expected component of type %s, but got %T", kind.Ref(name), ErrComponentWrongType, m.Type().Elem(), v)
+	}
+	if m.IsNil() {
+		m.Set(reflect.MakeMapWithSize(m.Type(), 1))
+	}
+	m.SetMapIndex(reflect.ValueOf(name), value)
+	return nil
+}
+
+// GetComponent returns the object of the given type and name and true if it is found in the components,
+// otherwise nil and false.
+// The type is one of the types supported by the Components.Add method, e.g.:
+//
+//	schema, found := GetComponent[Schema](components, "Pet")
+//	param, found := GetComponent[Extendable[Parameter]](components, "limit")
+func GetComponent[T any](c *Components, name string) (*RefOrSpec[T], bool) {
+	if c == nil {
+		return nil, false
+	}
+	var v any
+	switch any((*RefOrSpec[T])(nil)).(type) {
 	case *RefOrSpec[Schema]:
-		if o.Schemas == nil {
-			o.Schemas = make(map[string]*RefOrSpec[Schema], 1)
-		}
-		o.Schemas[name] = spec
+		v = c.Schemas[name]
 	case *RefOrSpec[Extendable[Response]]:
-		if o.Responses == nil {
-			o.Responses = make(map[string]*RefOrSpec[Extendable[Response]], 1)
-		}
-		o.Responses[name] = spec
+		v = c.Responses[name]
 	case *RefOrSpec[Extendable[Parameter]]:
-		if o.Parameters == nil {
-			o.Parameters = make(map[string]*RefOrSpec[Extendable[Parameter]], 1)
-		}
-		o.Parameters[name] = spec
+		v = c.Parameters[name]
 	case *RefOrSpec[Extendable[Example]]:
-		if o.Examples == nil {
-			o.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
-		}
-		o.Examples[name] = spec
+		v = c.Examples[name]
 	case *RefOrSpec[Extendable[RequestBody]]:
-		if o.RequestBodies == nil {
-			o.RequestBodies = make(map[string]*RefOrSpec[Extendable[RequestBody]], 1)
-		}
-		o.RequestBodies[name] = spec
+		v = c.RequestBodies[name]
 	case *RefOrSpec[Extendable[Header]]:
-		if o.Headers == nil {
-			o.Headers = make(map[string]*RefOrSpec[Extendable[Header]], 1)
-		}
-		o.Headers[name] = spec
+		v = c.Headers[name]
 	case *RefOrSpec[Extendable[SecurityScheme]]:
-		if o.SecuritySchemes == nil {
-			o.SecuritySchemes = make(map[string]*RefOrSpec[Extendable[SecurityScheme]], 1)
-		}
-		o.SecuritySchemes[name] = spec
+		v = c.SecuritySchemes[name]
 	case *RefOrSpec[Extendable[Link]]:
-		if o.Links == nil {
-			o.Links = make(map[string]*RefOrSpec[Extendable[Link]], 1)
-		}
-		o.Links[name] = spec
+		v = c.Links[name]
 	case *RefOrSpec[Extendable[Callback]]:
-		if o.Callbacks == nil {
-			o.Callbacks = make(map[string]*RefOrSpec[Extendable[Callback]], 1)
-		}
-		o.Callbacks[name] = spec
+		v = c.Callbacks[name]
 	case *RefOrSpec[Extendable[PathItem]]:
-		if o.Paths == nil {
-			o.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]], 1)
-		}
-		o.Paths[name] = spec
+		v = c.Paths[name]
 	default:
-		// ignore to avoid panic
+		return nil, false
 	}
-	return o
+	spec, ok := v.(*RefOrSpec[T])
+	if !ok || spec == nil {
+		return nil, false
+	}
+	return spec, true
 }
 
-var namePattern = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+// GetSchema returns the schema with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetSchema(name string) (*RefOrSpec[Schema], bool) {
+	return GetComponent[Schema](o, name)
+}
 
-func (o *Components) validateSpec(location string, validator *Validator) []*validationError {
-	var errs []*validationError
-	for k, v := range o.Schemas {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "schemas", k), "invalid name %q, must match %q", k, namePattern.String()))
-		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "schemas", k), validator)...)
-	}
+// GetResponse returns the response with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetResponse(name string) (*RefOrSpec[Extendable[Response]], bool) {
+	return GetComponent[Extendable[Response]](o, name)
+}
 
-	for k, v := range o.Responses {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "responses", k), "invalid name %q, must match %q", k, namePattern.String()))
+// GetParameter returns the parameter with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetParameter(name string) (*RefOrSpec[Extendable[Parameter]], bool) {
+	return GetComponent[Extendable[Parameter]](o, name)
+}
+
+// GetExample returns the example with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetExample(name string) (*RefOrSpec[Extendable[Example]], bool) {
+	return GetComponent[Extendable[Example]](o, name)
+}
+
+// GetRequestBody returns the request body with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetRequestBody(name string) (*RefOrSpec[Extendable[RequestBody]], bool) {
+	return GetComponent[Extendable[RequestBody]](o, name)
+}
+
+// GetHeader returns the header with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetHeader(name string) (*RefOrSpec[Extendable[Header]], bool) {
+	return GetComponent[Extendable[Header]](o, name)
+}
+
+// GetSecurityScheme returns the security scheme with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetSecurityScheme(name string) (*RefOrSpec[Extendable[SecurityScheme]], bool) {
+	return GetComponent[Extendable[SecurityScheme]](o, name)
+}
+
+// GetLink returns the link with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetLink(name string) (*RefOrSpec[Extendable[Link]], bool) {
+	return GetComponent[Extendable[Link]](o, name)
+}
+
+// GetCallback returns the callback with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetCallback(name string) (*RefOrSpec[Extendable[Callback]], bool) {
+	return GetComponent[Extendable[Callback]](o, name)
+}
+
+// GetPathItem returns the path item with the given name and true if it is found, otherwise nil and false.
+func (o *Components) GetPathItem(name string) (*RefOrSpec[Extendable[PathItem]], bool) {
+	return GetComponent[Extendable[PathItem]](o, name)
+}
+
+// ResolvedSchemas returns an iterator over the schemas of the components sorted by name,
+// the references are resolved using the given components or the current ones if c is nil.
+// The schemas, which cannot be resolved, are skipped, see UnresolvedSchemas method.
+//
+// The iterator is compatible with the range-over-func loops of Go 1.23:
+//
+//	for name, schema := range components.ResolvedSchemas(nil) {
+//		...
+//	}
+func (o *Components) ResolvedSchemas(c *Extendable[Components]) func(yield func(name string, s *Schema) bool) {
+	c = o.resolver(c)
+	return func(yield func(name string, s *Schema) bool) {
+		for _, name := range sortedKeys(o.Schemas) {
+			schema, err := o.Schemas[name].GetSpec(c)
+			if err != nil {
+				continue
+			}
+			if !yield(name, schema) {
+				return
+			}
 		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "responses", k), validator)...)
 	}
+}
 
-	for k, v := range o.Parameters {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "parameters", k), "invalid name %q, must match %q", k, namePattern.String()))
+// UnresolvedSchemas returns the errors for the schemas skipped by ResolvedSchemas method sorted by name.
+func (o *Components) UnresolvedSchemas(c *Extendable[Components]) []error {
+	c = o.resolver(c)
+	var errs []error
+	for _, name := range sortedKeys(o.Schemas) {
+		if _, err := o.Schemas[name].GetSpec(c); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ComponentSchemas.Ref(name), err))
 		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "parameters", k), validator)...)
 	}
+	return errs
+}
 
-	for k, v := range o.Examples {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "examples", k), "invalid name %q, must match %q", k, namePattern.String()))
-		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "examples", k), validator)...)
+// resolver returns the given components or the current ones, if nil, to resolve the references.
+func (o *Components) resolver(c *Extendable[Components]) *Extendable[Components] {
+	if c != nil {
+		return c
 	}
+	return &Extendable[Components]{Spec: o}
+}
 
-	for k, v := range o.RequestBodies {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "requestBodies", k), "invalid name %q, must match %q", k, namePattern.String()))
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+
+// componentsMap returns the map of the components of the given kind.
+func (o *Components) componentsMap(kind ComponentKind) (reflect.Value, error) {
+	v := reflect.ValueOf(o).Elem()
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if name == string(kind) {
+			return v.Field(i), nil
 		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "requestBodies", k), validator)...)
 	}
+	return reflect.Value{}, fmt.Errorf("%w %q", ErrUnsupportedComponentKind, kind)
+}
 
-	for k, v := range o.Headers {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "headers", k), "invalid name %q, must match %q", k, namePattern.String()))
-		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "headers", k), validator)...)
+// Remove deletes the component of the given kind and name and returns the current object (self|this).
+// The references to the removed component are not updated.
+func (o *Components) Remove(name string, kind ComponentKind) *Components {
+	if m, err := o.componentsMap(kind); err == nil && !m.IsNil() {
+		m.SetMapIndex(reflect.ValueOf(name), reflect.Value{})
 	}
+	return o
+}
 
-	for k, v := range o.SecuritySchemes {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "securitySchemes", k), "invalid name %q, must match %q", k, namePattern.String()))
-		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "securitySchemes", k), validator)...)
+// Rename changes the name of the component of the given kind and updates all the references to it inside the components.
+// It fails if the component does not exist, the new name is already in use or does not match the allowed pattern.
+//
+// Use OpenAPI.RenameComponent to update the references in the whole document.
+func (o *Components) Rename(kind ComponentKind, oldName, newName string) error {
+	if err := o.rename(kind, oldName, newName); err != nil {
+		return err
 	}
+	rewriteRefs(o, kind, oldName, newName)
+	return nil
+}
 
-	for k, v := range o.Links {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "links", k), "invalid name %q, must match %q", k, namePattern.String()))
-		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "links", k), validator)...)
+func (o *Components) rename(kind ComponentKind, oldName, newName string) error {
+	m, err := o.componentsMap(kind)
+	if err != nil {
+		return err
+	}
+	if !namePattern.MatchString(newName) {
+		return fmt.Errorf("%w %q, must match %q", ErrInvalidComponentName, newName, namePattern.String())
+	}
+	v := m.MapIndex(reflect.ValueOf(oldName))
+	if !v.IsValid() {
+		return fmt.Errorf("%s: %w", kind.Ref(oldName), ErrComponentNotFound)
 	}
+	if oldName == newName {
+		return nil
+	}
+	if m.MapIndex(reflect.ValueOf(newName)).IsValid() {
+		return fmt.Errorf("%s: %w", kind.Ref(newName), ErrComponentExists)
+	}
+	m.SetMapIndex(reflect.ValueOf(newName), v)
+	m.SetMapIndex(reflect.ValueOf(oldName), reflect.Value{})
+	return nil
+}
 
-	for k, v := range o.Callbacks {
+func (o *Components) validateSpec(location string, validator *Validator) []*validationError {
+	var errs []*validationError
+	errs = append(errs, validateComponents(joinLoc(location, "schemas"), o.Schemas, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "responses"), o.Responses, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "parameters"), o.Parameters, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "examples"), o.Examples, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "requestBodies"), o.RequestBodies, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "headers"), o.Headers, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "securitySchemes"), o.SecuritySchemes, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "links"), o.Links, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "callbacks"), o.Callbacks, validator)...)
+	errs = append(errs, validateComponents(joinLoc(location, "paths"), o.Paths, validator)...)
+	return errs
+}
+
+// validateComponents validates the names and the objects of the given components map.
+// The objects are validated concurrently if the Concurrency option is used.
+func validateComponents[T validatable](location string, m map[string]T, validator *Validator) []*validationError {
+	validate := func(k string, v T) []*validationError {
+		var errs []*validationError
 		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "callbacks", k), "invalid name %q, must match %q", k, namePattern.String()))
+			errs = append(errs, newValidationError(joinLoc(location, k), "invalid name %q, must match %q", k, namePattern.String()))
 		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "callbacks", k), validator)...)
+		return append(errs, v.validateSpec(joinLoc(location, k), validator)...)
 	}
 
-	for k, v := range o.Paths {
-		if !namePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, "paths", k), "invalid name %q, must match %q", k, namePattern.String()))
+	var errs []*validationError
+	if validator.opts.concurrency < 2 || len(m) < 2 {
+		for k, v := range m {
+			errs = append(errs, validate(k, v)...)
 		}
-		errs = append(errs, v.validateSpec(joinLoc(location, "paths", k), validator)...)
+		return errs
 	}
 
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	workers := make(chan struct{}, validator.opts.concurrency)
+	for k, v := range m {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			e := validate(k, v)
+			mu.Lock()
+			errs = append(errs, e...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
 	return errs
 }
 
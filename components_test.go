@@ -1,6 +1,9 @@
 package openapi_test
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/sv-tools/openapi"
@@ -209,3 +212,254 @@ func TestComponents_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestComponents_Get(t *testing.T) {
+	c := openapi.NewComponents().Spec
+	schema := openapi.NewSchemaBuilder().Title("test").Build()
+	param := openapi.NewParameterBuilder().Name("test").Build()
+	link := openapi.NewLinkBuilder().OperationID("test").Build()
+	c.Add("test", schema).Add("test", param).Add("test", link)
+
+	t.Run("found", func(t *testing.T) {
+		s, found := c.GetSchema("test")
+		require.Truef(t, found, "schema not found")
+		require.Equal(t, schema, s)
+
+		p, found := c.GetParameter("test")
+		require.Truef(t, found, "parameter not found")
+		require.Equal(t, param, p)
+
+		l, found := openapi.GetComponent[openapi.Extendable[openapi.Link]](c, "test")
+		require.Truef(t, found, "link not found")
+		require.Equal(t, link, l)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, found := c.GetSchema("unknown")
+		require.Truef(t, !found, "unknown schema found")
+
+		_, found = c.GetResponse("test")
+		require.Truef(t, !found, "response found")
+
+		_, found = c.GetHeader("test")
+		require.Truef(t, !found, "header found")
+
+		_, found = openapi.GetComponent[openapi.Parameter](c, "test")
+		require.Truef(t, !found, "not extendable parameter found")
+
+		var nilComponents *openapi.Components
+		_, found = nilComponents.GetSchema("test")
+		require.Truef(t, !found, "schema found in nil components")
+	})
+}
+
+const renameSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Rename Example", "version": "1.0.0"},
+  "security": [{"apiKey": []}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "security": [{"apiKey": [], "oauth": ["read"]}],
+        "responses": {
+          "200": {
+            "description": "list of pets",
+            "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "category": {"$ref": "#/components/schemas/Category"}
+        },
+        "discriminator": {"propertyName": "kind", "mapping": {"cat": "Category", "dog": "#/components/schemas/Category"}}
+      },
+      "Category": {"type": "object", "properties": {"name": {"type": "string"}}},
+      "Tag": {"type": "string"}
+    },
+    "securitySchemes": {
+      "apiKey": {"type": "apiKey", "name": "X-API-Key", "in": "header"},
+      "oauth": {"type": "oauth2", "flows": {"implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {"read": "read pets"}}}}
+    }
+  }
+}`
+
+func TestComponents_AddKind(t *testing.T) {
+	c := openapi.NewComponents().Spec
+	require.NoError(t, c.AddKind(openapi.ComponentSchemas, "Pet", openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()))
+	schema, found := c.GetSchema("Pet")
+	require.Equal(t, true, found)
+	require.NotNil(t, schema)
+
+	for _, tt := range []struct {
+		name     string
+		kind     openapi.ComponentKind
+		v        any
+		err      string
+		sentinel error
+	}{
+		{
+			name:     "wrong type",
+			kind:     openapi.ComponentParameters,
+			v:        openapi.NewSchemaBuilder().Build(),
+			sentinel: openapi.ErrComponentWrongType,
+			err:      "#/components/parameters/Limit: component has wrong type: expected component of type *openapi.RefOrSpec[github.com/sv-tools/openapi.Extendable[github.com/sv-tools/openapi.Parameter]], but got *openapi.RefOrSpec[github.com/sv-tools/openapi.Schema]",
+		},
+		{name: "unsupported type", kind: openapi.ComponentSchemas, v: "string", err: "but got string", sentinel: openapi.ErrComponentWrongType},
+		{name: "nil", kind: openapi.ComponentSchemas, v: nil, err: "but got <nil>", sentinel: openapi.ErrComponentWrongType},
+		{name: "unknown kind", kind: "models", v: openapi.NewSchemaBuilder().Build(), err: `unsupported component kind "models"`, sentinel: openapi.ErrUnsupportedComponentKind},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.AddKind(tt.kind, "Limit", tt.v)
+			require.ErrorContains(t, err, tt.err)
+			require.Equal(t, true, errors.Is(err, tt.sentinel))
+			require.Empty(t, c.Parameters)
+		})
+	}
+}
+
+func TestComponents_ResolvedSchemas(t *testing.T) {
+	// the schemas from the example of the Components object with the references
+	const data = `{
+  "schemas": {
+    "GeneralError": {
+      "type": "object",
+      "properties": {"code": {"type": "integer", "format": "int32"}, "message": {"type": "string"}}
+    },
+    "Category": {
+      "type": "object",
+      "properties": {"id": {"type": "integer", "format": "int64"}, "name": {"type": "string"}}
+    },
+    "Tag": {
+      "type": "object",
+      "properties": {"id": {"type": "integer", "format": "int64"}, "name": {"type": "string"}}
+    },
+    "Error": {"$ref": "#/components/schemas/GeneralError"},
+    "Owner": {"$ref": "#/components/schemas/User"}
+  }
+}`
+	var c *openapi.Extendable[openapi.Components]
+	require.NoError(t, json.Unmarshal([]byte(data), &c))
+
+	var names []string
+	c.Spec.ResolvedSchemas(c)(func(name string, s *openapi.Schema) bool {
+		names = append(names, name)
+		require.NotNil(t, s)
+		require.Len(t, s.Properties, 2)
+		return true
+	})
+	require.Equal(t, []string{"Category", "Error", "GeneralError", "Tag"}, names)
+
+	errs := c.Spec.UnresolvedSchemas(nil)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], `#/components/schemas/Owner: spec not found: component "#/components/schemas/User" not found`)
+
+	t.Run("stop", func(t *testing.T) {
+		var count int
+		c.Spec.ResolvedSchemas(nil)(func(string, *openapi.Schema) bool {
+			count++
+			return false
+		})
+		require.Equal(t, 1, count)
+	})
+}
+
+func TestOpenAPI_RenameComponent(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		kind     openapi.ComponentKind
+		oldName  string
+		newName  string
+		err      string
+		expected []string
+	}{
+		{
+			name:    "schema",
+			kind:    openapi.ComponentSchemas,
+			oldName: "Category",
+			newName: "Group",
+			expected: []string{
+				`"category":{"$ref":"#/components/schemas/Group"}`,
+				`"mapping":{"cat":"Group","dog":"#/components/schemas/Group"}`,
+				`"Group":{"properties":{"name"`,
+				`"items":{"$ref":"#/components/schemas/Pet"}`,
+			},
+		},
+		{
+			name:    "referenced from paths",
+			kind:    openapi.ComponentSchemas,
+			oldName: "Pet",
+			newName: "Animal",
+			expected: []string{
+				`"items":{"$ref":"#/components/schemas/Animal"}`,
+				`"Animal":{"discriminator"`,
+			},
+		},
+		{
+			name:    "security scheme",
+			kind:    openapi.ComponentSecuritySchemes,
+			oldName: "apiKey",
+			newName: "key",
+			expected: []string{
+				`"security":[{"key":[]}]`,
+				`"security":[{"key":[],"oauth":["read"]}]`,
+				`"securitySchemes":{"key":{`,
+			},
+		},
+		{name: "collision", kind: openapi.ComponentSchemas, oldName: "Category", newName: "Tag", err: "component already exists"},
+		{name: "invalid name", kind: openapi.ComponentSchemas, oldName: "Category", newName: "Pet Category", err: "invalid component name"},
+		{name: "not found", kind: openapi.ComponentSchemas, oldName: "Owner", newName: "User", err: "component not found"},
+		{name: "unsupported kind", kind: "foo", oldName: "Category", newName: "Group", err: "unsupported component kind"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, json.Unmarshal([]byte(renameSpec), &spec))
+
+			err := spec.Spec.RenameComponent(tt.kind, tt.oldName, tt.newName)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := json.Marshal(&spec)
+			require.NoError(t, err)
+			for _, e := range tt.expected {
+				require.Truef(t, strings.Contains(string(data), e), "%s not found in %s", e, data)
+			}
+			require.Truef(t, !strings.Contains(string(data), tt.kind.Ref(tt.oldName)), "old ref found in %s", data)
+		})
+	}
+}
+
+func TestComponents_Rename(t *testing.T) {
+	c := openapi.NewComponents().Spec
+	c.Add("Category", openapi.NewSchemaBuilder().Type(openapi.StringType).Build())
+	c.Add("Pet", openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+		AddProperty("category", openapi.NewSchemaBuilder().Ref("#/components/schemas/Category").Build()).
+		AddProperty("other", openapi.NewSchemaBuilder().Ref("#/components/schemas/CategoryOther").Build()).
+		AddProperty("name", openapi.NewSchemaBuilder().Ref("#/components/schemas/Category/properties/name").Build()).
+		Build())
+
+	require.NoError(t, c.Rename(openapi.ComponentSchemas, "Category", "Group"))
+	_, found := c.GetSchema("Category")
+	require.Truef(t, !found, "old schema found")
+	_, found = c.GetSchema("Group")
+	require.Truef(t, found, "new schema not found")
+	pet, _ := c.GetSchema("Pet")
+	require.Equal(t, "#/components/schemas/Group", pet.Spec.Properties["category"].Ref.Ref)
+	require.Equal(t, "#/components/schemas/CategoryOther", pet.Spec.Properties["other"].Ref.Ref)
+	require.Equal(t, "#/components/schemas/Group/properties/name", pet.Spec.Properties["name"].Ref.Ref)
+
+	c.Remove("Group", openapi.ComponentSchemas)
+	_, found = c.GetSchema("Group")
+	require.Truef(t, !found, "removed schema found")
+	// unknown names and kinds are ignored
+	c.Remove("Group", openapi.ComponentSchemas).Remove("Pet", "foo")
+	require.Len(t, c.Schemas, 1)
+}
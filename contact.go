@@ -20,9 +20,9 @@ type Contact struct {
 	Email string `json:"email,omitempty"`
 }
 
-func (o *Contact) validateSpec(location string, _ *Validator) []*validationError {
+func (o *Contact) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
-	if err := checkURL(o.URL); err != nil {
+	if err := validator.checkURL(o.URL); err != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "url"), err))
 	}
 	if err := checkEmail(o.Email); err != nil {
@@ -0,0 +1,51 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestContact_Email(t *testing.T) {
+	for _, tt := range []struct {
+		email string
+		err   string
+	}{
+		{email: "support@example.com"},
+		{email: "first.last+tag@sub.example.co.uk"},
+		{email: "o'brien@example.com"},
+		{email: "user@localhost"},
+		{email: "Support <support@example.com>", err: "invalid email: 'Support <support@example.com>' must be a bare address"},
+		{email: "<support@example.com>", err: "invalid email: '<support@example.com>' must be a bare address"},
+		{email: "support@example.com (Support)", err: "invalid email: 'support@example.com (Support)' must be a bare address"},
+		{email: "support", err: "invalid email"},
+		{email: "support@", err: "invalid email"},
+		{email: "@example.com", err: "invalid email"},
+		{email: "support@@example.com", err: "invalid email"},
+	} {
+		t.Run(tt.email, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().
+					Title("test").
+					Version("1.0.0").
+					Contact(openapi.NewContactBuilder().Email(tt.email).Build()).
+					Build()).
+				AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+				AddComponent("Email", openapi.NewSchemaBuilder().Type(openapi.StringType).Format(openapi.EmailFormat).Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.ValidateFormats())
+			require.NoError(t, err)
+			specErr := validator.ValidateSpec()
+			dataErr := validator.ValidateData("#/components/schemas/Email", tt.email)
+			if tt.err != "" {
+				require.ErrorContains(t, specErr, "/info/contact/email: "+tt.err)
+				require.ErrorContains(t, dataErr, tt.err)
+			} else {
+				require.NoError(t, specErr)
+				require.NoError(t, dataErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Decode reads the document in JSON format from the given reader.
+//
+// Unlike json.Unmarshal, the input is not buffered as a whole: the document is read token by token and
+// the paths and the components are decoded one by one, so only a single path item or component is held
+// in memory as raw JSON at a time. The result is the same as json.Unmarshal into Extendable[OpenAPI] produces.
+//
+// Example:
+//
+//	f, err := os.Open("openapi.json")
+//	...
+//	doc, err := openapi.Decode(bufio.NewReader(f))
+func Decode(r io.Reader) (*Extendable[OpenAPI], error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	var (
+		paths      *Extendable[Paths]
+		components *Extendable[Components]
+		// the rest of the fields are small enough to be decoded as usual
+		raw = make(map[string]json.RawMessage)
+	)
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "paths":
+			paths = NewPaths()
+			err = decodeObject(dec, paths.Extensions, &paths, func(name string) error {
+				var item *RefOrSpec[Extendable[PathItem]]
+				if err := dec.Decode(&item); err != nil {
+					return fmt.Errorf("%s: %w", joinLoc("/paths", name), err)
+				}
+				paths.Spec.Add(name, item)
+				return nil
+			})
+		case "components":
+			components = NewComponents()
+			err = decodeObject(dec, components.Extensions, &components, func(kind string) error {
+				return decodeComponents(dec, components.Spec, ComponentKind(kind))
+			})
+		default:
+			var v json.RawMessage
+			if err = dec.Decode(&v); err == nil {
+				raw[key] = v
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var doc Extendable[OpenAPI]
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	doc.Spec.Paths = paths
+	doc.Spec.Components = components
+	return &doc, nil
+}
+
+// decodeComponents decodes the components of the given kind one by one, the unknown kinds are skipped.
+func decodeComponents(dec *json.Decoder, c *Components, kind ComponentKind) error {
+	m, err := c.componentsMap(kind)
+	if err != nil {
+		// ignore the unknown fields like json.Unmarshal does
+		var v json.RawMessage
+		return dec.Decode(&v)
+	}
+	m.Set(reflect.MakeMap(m.Type()))
+	return decodeObject(dec, nil, m.Addr().Interface(), func(name string) error {
+		v := reflect.New(m.Type().Elem())
+		if err := dec.Decode(v.Interface()); err != nil {
+			return fmt.Errorf("%s: %w", joinLoc("/components", string(kind), name), err)
+		}
+		m.SetMapIndex(reflect.ValueOf(name), v.Elem())
+		return nil
+	})
+}
+
+// decodeObject reads an object and calls the given function for each key to decode the value,
+// the extensions are stored to the given map if it is not nil.
+// The pointer to the result is set to nil if the value is `null`.
+func decodeObject(dec *json.Decoder, extensions map[string]any, result any, decodeValue func(key string) error) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		v := reflect.ValueOf(result).Elem()
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if d, ok := t.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("expected '{', but got %v", t)
+	}
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return err
+		}
+		if extensions != nil && strings.HasPrefix(key, ExtensionPrefix) {
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			extensions[key] = v
+			continue
+		}
+		if err := decodeValue(key); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+func decodeKey(dec *json.Decoder) (string, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := t.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, but got %v", t)
+	}
+	return key, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected '%s', but got %v", delim, t)
+	}
+	return nil
+}
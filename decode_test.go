@@ -0,0 +1,95 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("testdata", func(t *testing.T) {
+		files, err := os.ReadDir("testdata")
+		require.NoError(t, err)
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			data, err := os.ReadFile(path.Join("testdata", f.Name()))
+			require.NoError(t, err)
+			var expected *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, json.Unmarshal(data, &expected))
+			doc, err := openapi.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+			require.Equal(t, expected, doc)
+		}
+	})
+
+	t.Run("extensions and nulls", func(t *testing.T) {
+		const data = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {"/pets": {"get": {"responses": {"200": {"description": "ok"}}}}, "x-paths": true},
+  "components": {"schemas": null, "parameters": {"limit": {"name": "limit", "in": "query"}}, "unknown": {}, "x-components": 1},
+  "webhooks": null,
+  "x-root": {"a": "b"}
+}`
+		var expected *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, json.Unmarshal([]byte(data), &expected))
+		doc, err := openapi.Decode(strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, expected, doc)
+		require.Equal(t, true, doc.Spec.Paths.Extensions["x-paths"])
+		require.Equal(t, map[string]any{"a": "b"}, doc.Extensions["x-root"])
+	})
+
+	t.Run("null paths", func(t *testing.T) {
+		doc, err := openapi.Decode(strings.NewReader(`{"openapi": "3.1.1", "paths": null}`))
+		require.NoError(t, err)
+		require.Nil(t, doc.Spec.Paths)
+	})
+
+	for _, tt := range []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "not an object", data: `[]`, err: "expected '{', but got ["},
+		{name: "invalid path item", data: `{"paths": {"/pets": {"get": 42}}}`, err: "/paths/~1pets: "},
+		{name: "invalid component", data: `{"components": {"schemas": {"Pet": {"type": 42}}}}`, err: "/components/schemas/Pet: "},
+		{name: "truncated", data: `{"paths": {"/pets": {}`, err: "unexpected end of JSON input"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := openapi.Decode(strings.NewReader(tt.data))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	data, err := json.Marshal(bigSpec(5000))
+	require.NoError(b, err)
+
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var doc *openapi.Extendable[openapi.OpenAPI]
+			if err := json.Unmarshal(data, &doc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := openapi.Decode(bytes.NewReader(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
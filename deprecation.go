@@ -0,0 +1,78 @@
+package openapi
+
+import "reflect"
+
+// DeprecatedExtension is the extension to mark the objects without `deprecated` field as deprecated,
+// e.g. a path item or a tag:
+//
+//	name: pets
+//	x-deprecated: true
+const DeprecatedExtension = ExtensionPrefix + "deprecated"
+
+// IsDeprecated returns true if the operation is deprecated itself, or its path item, or any of its tags.
+// The path item and the tags, declared in the root tags of the given document, are deprecated by DeprecatedExtension.
+// The path item and the document can be nil to check the operation only.
+func IsDeprecated(op *Extendable[Operation], pathItem *Extendable[PathItem], doc *OpenAPI) bool {
+	if op == nil || op.Spec == nil {
+		return false
+	}
+	if op.Spec.Deprecated {
+		return true
+	}
+	if pathItem != nil && isDeprecatedExt(pathItem.Extensions) {
+		return true
+	}
+	if doc == nil || len(op.Spec.Tags) == 0 {
+		return false
+	}
+	for _, tag := range doc.Tags {
+		if tag != nil && tag.Spec != nil && isDeprecatedExt(tag.Extensions) {
+			for _, name := range op.Spec.Tags {
+				if name == tag.Spec.Name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isDeprecatedExt(extensions map[string]any) bool {
+	v, _ := extensions[DeprecatedExtension].(bool)
+	return v
+}
+
+// validateDeprecatedUsages reports a warning for each deprecated parameter or schema referenced by the operation,
+// which is not deprecated itself. Only the direct references of the operation are checked, and not the nested ones
+// of the referenced components.
+func validateDeprecatedUsages(location string, op *Extendable[Operation], pathItem *Extendable[PathItem], validator *Validator) []*validationError {
+	if IsDeprecated(op, pathItem, validator.spec.Spec) {
+		return nil
+	}
+	var errs []*validationError
+	w := walker{visited: make(map[pointerKey]bool)}
+	w.visit = func(location string, node any) error {
+		var (
+			ref        *Ref
+			deprecated bool
+		)
+		switch v := node.(type) {
+		case *RefOrSpec[Extendable[Parameter]]:
+			if v.Ref != nil {
+				param, err := v.GetSpecCached(validator.cache)
+				ref, deprecated = v.Ref, err == nil && param.Spec.Deprecated
+			}
+		case *RefOrSpec[Schema]:
+			if v.Ref != nil {
+				schema, err := v.GetSpecCached(validator.cache)
+				ref, deprecated = v.Ref, err == nil && schema.Deprecated
+			}
+		}
+		if deprecated {
+			errs = append(errs, newValidationWarning(location, "%w component '%s' is used by the operation, which is not deprecated", ErrDeprecated, ref.Ref))
+		}
+		return nil
+	}
+	_ = w.walk(reflect.ValueOf(op), location)
+	return errs
+}
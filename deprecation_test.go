@@ -0,0 +1,90 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestIsDeprecated(t *testing.T) {
+	doc := openapi.NewOpenAPIBuilder().
+		AddTags(
+			openapi.NewTagBuilder().Name("pets").Build(),
+			openapi.NewTagBuilder().Name("legacy").AddExt(openapi.DeprecatedExtension, true).Build(),
+		).
+		Build().Spec
+	op := func(deprecated bool, tags ...string) *openapi.Extendable[openapi.Operation] {
+		return openapi.NewOperationBuilder().Deprecated(deprecated).Tags(tags...).Build()
+	}
+	pathItem := openapi.NewPathItemBuilder().Build().Spec
+	deprecatedPathItem := openapi.NewPathItemBuilder().AddExt(openapi.DeprecatedExtension, true).Build().Spec
+
+	require.Equal(t, false, openapi.IsDeprecated(op(false), nil, nil))
+	require.Equal(t, true, openapi.IsDeprecated(op(true), nil, nil))
+	require.Equal(t, false, openapi.IsDeprecated(op(false, "pets"), pathItem, doc))
+	require.Equal(t, true, openapi.IsDeprecated(op(false), deprecatedPathItem, doc))
+	require.Equal(t, true, openapi.IsDeprecated(op(false, "pets", "legacy"), pathItem, doc))
+	require.Equal(t, false, openapi.IsDeprecated(op(false, "legacy"), pathItem, nil))
+}
+
+func TestValidator_DeprecatedUsages(t *testing.T) {
+	newSpec := func(deprecated bool) *openapi.Extendable[openapi.OpenAPI] {
+		data := `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "deprecated": ` + strconv.FormatBool(deprecated) + `,
+        "parameters": [{"$ref": "#/components/parameters/Limit"}],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {
+              "application/json": {
+                "schema": {"oneOf": [{"$ref": "#/components/schemas/Pet"}, {"$ref": "#/components/schemas/OldPet"}]}
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object"},
+      "OldPet": {"type": "object", "deprecated": true}
+    },
+    "parameters": {
+      "Limit": {"name": "limit", "in": "query", "deprecated": true, "schema": {"type": "integer"}}
+    }
+  }
+}`
+		var doc openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, json.Unmarshal([]byte(data), &doc))
+		return &doc
+	}
+
+	t.Run("not deprecated", func(t *testing.T) {
+		validator, err := openapi.NewValidator(newSpec(false))
+		require.NoError(t, err)
+		errs, warnings := validator.Validate()
+		require.Empty(t, errs)
+		require.Len(t, warnings, 2)
+		require.ErrorContains(t, warnings[0], "/paths/~1pets/get/parameters/0: deprecated component '#/components/parameters/Limit' is used by the operation, which is not deprecated")
+		require.ErrorContains(t, warnings[1], "/paths/~1pets/get/responses/200/content/application~1json/schema/oneOf/1: deprecated component '#/components/schemas/OldPet' is used by the operation, which is not deprecated")
+		require.Equal(t, true, errors.Is(warnings[0], openapi.ErrDeprecated))
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		validator, err := openapi.NewValidator(newSpec(true))
+		require.NoError(t, err)
+		errs, warnings := validator.Validate()
+		require.Empty(t, errs)
+		require.Empty(t, warnings)
+	})
+}
@@ -0,0 +1,521 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeKind is the kind of the change between two versions of a document.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change describes a single difference between two versions of a document.
+type Change struct {
+	// The location of the changed object in the form of JSON Pointer, e.g. `/paths/~1pets/get`.
+	Location string     `json:"location"`
+	Kind     ChangeKind `json:"kind"`
+	// A human-readable description of the change.
+	Message string `json:"message"`
+	// Breaking is true if the existing clients of the API can be broken by the change.
+	Breaking bool `json:"breaking"`
+}
+
+// String implements fmt.Stringer interface.
+func (c Change) String() string {
+	s := c.Location + ": " + c.Message
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+// SpecDiff is a list of the changes between two versions of a document, ordered by location.
+type SpecDiff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns the breaking changes only.
+func (d *SpecDiff) Breaking() []Change {
+	var changes []Change
+	for _, c := range d.Changes {
+		if c.Breaking {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// HasBreaking checks if there is at least one breaking change.
+func (d *SpecDiff) HasBreaking() bool {
+	return slices.ContainsFunc(d.Changes, func(c Change) bool { return c.Breaking })
+}
+
+// Walk calls the given function for each change in order.
+// If the function returns ErrStopWalk, then the iteration stops and Walk returns nil;
+// any other error stops the iteration and is returned.
+func (d *SpecDiff) Walk(fn func(Change) error) error {
+	for _, c := range d.Changes {
+		if err := fn(c); err != nil {
+			if errors.Is(err, ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// String renders the changes, one per line.
+func (d *SpecDiff) String() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// JSON returns the changes in JSON format.
+func (d *SpecDiff) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// Diff compares two versions of a document and reports the added, removed and changed paths, operations,
+// parameters, request bodies, responses and schema properties.
+//
+// Each change is marked as breaking or not from the point of view of the existing clients
+// using the built-in rules of BreakingChanges,
+// e.g. removing a response or adding a required parameter is breaking, but adding an optional parameter is not.
+// The schemas of the requests and the responses are compared in opposite directions:
+// a new required property breaks the requests, but a removed property breaks the responses.
+//
+// The references are resolved using the components of the corresponding document before comparing,
+// so an inline schema and the referenced one are equal.
+func Diff(oldSpec, newSpec *OpenAPI) (*SpecDiff, error) {
+	if oldSpec == nil || newSpec == nil {
+		return nil, fmt.Errorf("diff: %w", ErrRequired)
+	}
+	d := differ{
+		oldComponents: oldSpec.Components,
+		newComponents: newSpec.Components,
+		visited:       make(map[[2]*Schema]bool),
+		rules:         sortedRules(builtinBreakingRules()),
+	}
+	if err := d.paths(oldSpec.Paths, newSpec.Paths); err != nil {
+		return nil, err
+	}
+	// a change is breaking if the same change is reported by the built-in rules of BreakingChanges
+	breaking := make(map[[2]string]bool, len(d.breaking))
+	for _, c := range d.breaking {
+		breaking[[2]string{c.Location, c.Message}] = true
+	}
+	for i, c := range d.changes {
+		d.changes[i].Breaking = breaking[[2]string{c.Location, c.Message}]
+	}
+	return &SpecDiff{Changes: d.changes}, nil
+}
+
+type differ struct {
+	oldComponents *Extendable[Components]
+	newComponents *Extendable[Components]
+	changes       []Change
+	// the pairs of schemas being compared in the current recursion, to stop on the recursive schemas
+	visited map[[2]*Schema]bool
+	// the rules are called for each compared pair of objects, see BreakingChanges
+	rules    []BreakingRule
+	breaking []Change
+}
+
+// check calls the rules for the given pair of objects.
+func (d *differ) check(location string, oldValue, newValue any, request bool) {
+	if len(d.rules) == 0 {
+		return
+	}
+	c := &RuleContext{Location: location, Old: oldValue, New: newValue, Request: request}
+	for _, rule := range d.rules {
+		d.breaking = append(d.breaking, rule(c)...)
+	}
+}
+
+func (d *differ) add(location string, kind ChangeKind, format string, args ...any) {
+	d.changes = append(d.changes, Change{
+		Location: location,
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// unionKeys returns the sorted list of the keys of both maps.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, found := a[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// specOf returns the spec of the given object or nil if the object is not set.
+func specOf[T any](o *Extendable[T]) *T {
+	if o == nil {
+		return nil
+	}
+	return o.Spec
+}
+
+// resolve returns the spec of the given object, found is false if the object is not set.
+func resolve[T any](o *RefOrSpec[T], c *Extendable[Components], location string) (spec *T, found bool, err error) {
+	if o == nil {
+		return nil, false, nil
+	}
+	spec, err = o.GetSpec(c)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", location, err)
+	}
+	return spec, true, nil
+}
+
+// resolvePathItem returns the path item combined with the fields declared next to `$ref`,
+// found is false if the item is not set.
+func resolvePathItem(o *RefOrSpec[Extendable[PathItem]], c *Extendable[Components], location string) (item *Extendable[PathItem], found bool, err error) {
+	if o == nil {
+		return nil, false, nil
+	}
+	item, err = ResolvePathItem(o, c)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", location, err)
+	}
+	return item, true, nil
+}
+
+func (d *differ) paths(oldPaths, newPaths *Extendable[Paths]) error {
+	var om, nm map[string]*RefOrSpec[Extendable[PathItem]]
+	if oldPaths != nil {
+		om = oldPaths.Spec.Paths
+	}
+	if newPaths != nil {
+		nm = newPaths.Spec.Paths
+	}
+	for _, path := range unionKeys(om, nm) {
+		location := joinLoc("", "paths", path)
+		oldItem, oldFound, err := resolvePathItem(om[path], d.oldComponents, location)
+		if err != nil {
+			return err
+		}
+		newItem, newFound, err := resolvePathItem(nm[path], d.newComponents, location)
+		if err != nil {
+			return err
+		}
+		d.check(location, specOf(oldItem), specOf(newItem), false)
+		switch {
+		case !oldFound:
+			d.add(location, ChangeAdded, "path added")
+		case !newFound:
+			d.add(location, ChangeRemoved, "path removed")
+		default:
+			if err := d.pathItem(location, oldItem.Spec, newItem.Spec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *differ) pathItem(location string, oldItem, newItem *PathItem) error {
+	oldMethods, oldOperations := oldItem.operations()
+	newMethods, newOperations := newItem.operations()
+	for _, method := range pathItemMethods {
+		method = strings.ToLower(method)
+		loc := joinLoc(location, method)
+		i, j := slices.Index(oldMethods, method), slices.Index(newMethods, method)
+		var oldOp, newOp *Operation
+		if i >= 0 {
+			oldOp = oldOperations[i].Spec
+		}
+		if j >= 0 {
+			newOp = newOperations[j].Spec
+		}
+		if i >= 0 || j >= 0 {
+			d.check(loc, oldOp, newOp, false)
+		}
+		switch {
+		case i < 0 && j < 0:
+		case i < 0:
+			d.add(loc, ChangeAdded, "operation added")
+		case j < 0:
+			d.add(loc, ChangeRemoved, "operation removed")
+		default:
+			oldParams, err := d.parameters(loc, oldItem.Parameters, oldOp.Parameters, d.oldComponents)
+			if err != nil {
+				return err
+			}
+			newParams, err := d.parameters(loc, newItem.Parameters, newOp.Parameters, d.newComponents)
+			if err != nil {
+				return err
+			}
+			if err := d.operation(loc, oldOp, newOp, oldParams, newParams); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parameters returns the effective parameters of an operation by the `in` and `name` pair,
+// the operation level parameters override the path level ones, the header names are case-insensitive.
+func (d *differ) parameters(location string, pathParams, opParams []*RefOrSpec[Extendable[Parameter]], c *Extendable[Components]) (map[string]*Parameter, error) {
+	params := make(map[string]*Parameter, len(pathParams)+len(opParams))
+	for i, p := range slices.Concat(pathParams, opParams) {
+		spec, found, err := resolve(p, c, joinLoc(location, "parameters", i))
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			params[spec.Spec.key()] = spec.Spec
+		}
+	}
+	return params, nil
+}
+
+func (d *differ) operation(location string, oldOp, newOp *Operation, oldParams, newParams map[string]*Parameter) error {
+	if !oldOp.Deprecated && newOp.Deprecated {
+		d.add(joinLoc(location, "deprecated"), ChangeModified, "operation deprecated")
+	}
+
+	for _, key := range unionKeys(oldParams, newParams) {
+		oldParam, newParam := oldParams[key], newParams[key]
+		p := newParam
+		if p == nil {
+			p = oldParam
+		}
+		loc := joinLoc(location, "parameters", p.In, p.Name)
+		d.check(loc, oldParam, newParam, true)
+		switch {
+		case oldParam == nil:
+			if newParam.Required {
+				d.add(loc, ChangeAdded, "required parameter added")
+			} else {
+				d.add(loc, ChangeAdded, "optional parameter added")
+			}
+		case newParam == nil:
+			d.add(loc, ChangeRemoved, "parameter removed")
+		default:
+			if !oldParam.Required && newParam.Required {
+				d.add(joinLoc(loc, "required"), ChangeModified, "parameter became required")
+			} else if oldParam.Required && !newParam.Required {
+				d.add(joinLoc(loc, "required"), ChangeModified, "parameter became optional")
+			}
+			if err := d.schema(joinLoc(loc, "schema"), oldParam.Schema, newParam.Schema, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := d.requestBody(joinLoc(location, "requestBody"), oldOp.RequestBody, newOp.RequestBody); err != nil {
+		return err
+	}
+	return d.responses(joinLoc(location, "responses"), oldOp.Responses, newOp.Responses)
+}
+
+func (d *differ) requestBody(location string, oldBody, newBody *RefOrSpec[Extendable[RequestBody]]) error {
+	oldSpec, oldFound, err := resolve(oldBody, d.oldComponents, location)
+	if err != nil {
+		return err
+	}
+	newSpec, newFound, err := resolve(newBody, d.newComponents, location)
+	if err != nil {
+		return err
+	}
+	if !oldFound && !newFound {
+		return nil
+	}
+	d.check(location, specOf(oldSpec), specOf(newSpec), true)
+	switch {
+	case !oldFound:
+		if newSpec.Spec.Required {
+			d.add(location, ChangeAdded, "required request body added")
+		} else {
+			d.add(location, ChangeAdded, "optional request body added")
+		}
+		return nil
+	case !newFound:
+		d.add(location, ChangeRemoved, "request body removed")
+		return nil
+	}
+	if !oldSpec.Spec.Required && newSpec.Spec.Required {
+		d.add(joinLoc(location, "required"), ChangeModified, "request body became required")
+	} else if oldSpec.Spec.Required && !newSpec.Spec.Required {
+		d.add(joinLoc(location, "required"), ChangeModified, "request body became optional")
+	}
+	return d.content(joinLoc(location, "content"), oldSpec.Spec.Content, newSpec.Spec.Content, true)
+}
+
+func (d *differ) responses(location string, oldResponses, newResponses *Extendable[Responses]) error {
+	om := make(map[string]*RefOrSpec[Extendable[Response]])
+	nm := make(map[string]*RefOrSpec[Extendable[Response]])
+	for _, v := range []struct {
+		responses *Extendable[Responses]
+		m         map[string]*RefOrSpec[Extendable[Response]]
+	}{
+		{oldResponses, om},
+		{newResponses, nm},
+	} {
+		if v.responses == nil {
+			continue
+		}
+		for code, r := range v.responses.Spec.Response {
+			v.m[code] = r
+		}
+		if v.responses.Spec.Default != nil {
+			v.m["default"] = v.responses.Spec.Default
+		}
+	}
+
+	for _, code := range unionKeys(om, nm) {
+		loc := joinLoc(location, code)
+		oldResponse, oldFound, err := resolve(om[code], d.oldComponents, loc)
+		if err != nil {
+			return err
+		}
+		newResponse, newFound, err := resolve(nm[code], d.newComponents, loc)
+		if err != nil {
+			return err
+		}
+		d.check(loc, specOf(oldResponse), specOf(newResponse), false)
+		switch {
+		case !oldFound:
+			d.add(loc, ChangeAdded, "response added")
+		case !newFound:
+			d.add(loc, ChangeRemoved, "response removed")
+		default:
+			if err := d.content(joinLoc(loc, "content"), oldResponse.Spec.Content, newResponse.Spec.Content, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *differ) content(location string, oldContent, newContent map[string]*Extendable[MediaType], request bool) error {
+	for _, mediaType := range unionKeys(oldContent, newContent) {
+		loc := joinLoc(location, mediaType)
+		oldMedia, newMedia := oldContent[mediaType], newContent[mediaType]
+		d.check(loc, specOf(oldMedia), specOf(newMedia), request)
+		switch {
+		case oldMedia == nil:
+			d.add(loc, ChangeAdded, "media type added")
+		case newMedia == nil:
+			d.add(loc, ChangeRemoved, "media type removed")
+		default:
+			if err := d.schema(joinLoc(loc, "schema"), oldMedia.Spec.Schema, newMedia.Spec.Schema, request); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schema compares the types, the properties and the items of two schemas.
+// The request flag defines the direction of the data to decide if a change is breaking.
+func (d *differ) schema(location string, oldRef, newRef *RefOrSpec[Schema], request bool) error {
+	oldSchema, oldFound, err := resolve(oldRef, d.oldComponents, location)
+	if err != nil {
+		return err
+	}
+	newSchema, newFound, err := resolve(newRef, d.newComponents, location)
+	if err != nil {
+		return err
+	}
+	if !oldFound || !newFound {
+		return nil
+	}
+	// the pair is being compared up the stack, so the schema is recursive;
+	// the same pair reached by another path, e.g. a shared component, is compared again at that location
+	key := [2]*Schema{oldSchema, newSchema}
+	if d.visited[key] {
+		return nil
+	}
+	d.visited[key] = true
+	defer delete(d.visited, key)
+	d.check(location, oldSchema, newSchema, request)
+
+	if narrowed, widened := compareTypes(oldSchema.Type, newSchema.Type); narrowed || widened {
+		// the requests are broken by narrowing, the responses by widening
+		d.add(joinLoc(location, "type"), ChangeModified,
+			"type changed from %s to %s", typesString(oldSchema.Type), typesString(newSchema.Type))
+	}
+
+	for _, name := range unionKeys(oldSchema.Properties, newSchema.Properties) {
+		loc := joinLoc(location, "properties", name)
+		oldProp, newProp := oldSchema.Properties[name], newSchema.Properties[name]
+		switch {
+		case oldProp == nil:
+			if slices.Contains(newSchema.Required, name) {
+				d.add(loc, ChangeAdded, "required property added")
+			} else {
+				d.add(loc, ChangeAdded, "optional property added")
+			}
+		case newProp == nil:
+			d.add(loc, ChangeRemoved, "property removed")
+		default:
+			wasRequired, isRequired := slices.Contains(oldSchema.Required, name), slices.Contains(newSchema.Required, name)
+			if !wasRequired && isRequired {
+				d.add(joinLoc(loc, "required"), ChangeModified, "property became required")
+			} else if wasRequired && !isRequired {
+				d.add(joinLoc(loc, "required"), ChangeModified, "property became optional")
+			}
+			if err := d.schema(loc, oldProp, newProp, request); err != nil {
+				return err
+			}
+		}
+	}
+
+	if oldSchema.Items != nil && newSchema.Items != nil {
+		return d.schema(joinLoc(location, "items"), oldSchema.Items.Schema, newSchema.Items.Schema, request)
+	}
+	return nil
+}
+
+// compareTypes checks if the set of the allowed types is narrowed or widened, the not set type allows any type.
+// An integer is a number as well, so changing a number to an integer is narrowing.
+func compareTypes(oldTypes, newTypes *SingleOrArray[string]) (narrowed, widened bool) {
+	if oldTypes.Len() == 0 && newTypes.Len() == 0 {
+		return false, false
+	}
+	if oldTypes.Len() == 0 {
+		return true, false
+	}
+	if newTypes.Len() == 0 {
+		return false, true
+	}
+	contains := func(types *SingleOrArray[string], t string) bool {
+		return SingleOrArrayContains(types, t) || t == IntegerType && SingleOrArrayContains(types, NumberType)
+	}
+	oldTypes.Each(func(t string) {
+		narrowed = narrowed || !contains(newTypes, t)
+	})
+	newTypes.Each(func(t string) {
+		widened = widened || !contains(oldTypes, t)
+	})
+	return narrowed, widened
+}
+
+func typesString(types *SingleOrArray[string]) string {
+	if types.Len() == 0 {
+		return "any"
+	}
+	return strings.Join(*types, "|")
+}
@@ -0,0 +1,191 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const diffOldSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "pets",
+            "content": {
+              "application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}
+            }
+          },
+          "404": {"description": "not found"}
+        }
+      },
+      "post": {
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+        },
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/pets/{id}": {
+      "delete": {"responses": {"204": {"description": "deleted"}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"type": "string"},
+          "tag": {"type": "string"},
+          "age": {"type": "integer"}
+        }
+      }
+    }
+  }
+}`
+
+const diffNewSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Pets", "version": "2.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer"}},
+          {"name": "offset", "in": "query", "schema": {"type": "integer"}},
+          {"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "pets",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "required": ["name"],
+                    "properties": {
+                      "name": {"type": "string"},
+                      "age": {"type": "number"}
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "required": ["name", "owner"],
+                "properties": {
+                  "name": {"type": "string"},
+                  "tag": {"type": "string"},
+                  "age": {"type": "integer"},
+                  "owner": {"type": "string"}
+                }
+              }
+            }
+          }
+        },
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/owners": {
+      "get": {"responses": {"200": {"description": "owners"}}}
+    }
+  }
+}`
+
+func TestDiff(t *testing.T) {
+	var oldSpec, newSpec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(diffOldSpec), &oldSpec))
+	require.NoError(t, json.Unmarshal([]byte(diffNewSpec), &newSpec))
+
+	diff, err := openapi.Diff(oldSpec.Spec, newSpec.Spec)
+	require.NoError(t, err)
+	require.Equal(t, []openapi.Change{
+		{Location: "/paths/~1owners", Kind: openapi.ChangeAdded, Message: "path added"},
+		{Location: "/paths/~1pets/get/parameters/header/X-Tenant", Kind: openapi.ChangeAdded, Message: "required parameter added", Breaking: true},
+		{Location: "/paths/~1pets/get/parameters/query/offset", Kind: openapi.ChangeAdded, Message: "optional parameter added"},
+		{Location: "/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/age/type", Kind: openapi.ChangeModified, Message: "type changed from integer to number", Breaking: true},
+		{Location: "/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/tag", Kind: openapi.ChangeRemoved, Message: "property removed", Breaking: true},
+		{Location: "/paths/~1pets/get/responses/404", Kind: openapi.ChangeRemoved, Message: "response removed", Breaking: true},
+		{Location: "/paths/~1pets/post/requestBody/content/application~1json/schema/properties/owner", Kind: openapi.ChangeAdded, Message: "required property added", Breaking: true},
+		{Location: "/paths/~1pets~1{id}", Kind: openapi.ChangeRemoved, Message: "path removed", Breaking: true},
+	}, diff.Changes)
+	require.Equal(t, true, diff.HasBreaking())
+	require.Len(t, diff.Breaking(), 6)
+	require.Equal(t, "/paths/~1pets~1{id}: path removed (breaking)", diff.Changes[7].String())
+
+	var visited int
+	require.NoError(t, diff.Walk(func(c openapi.Change) error {
+		visited++
+		if c.Breaking {
+			return openapi.ErrStopWalk
+		}
+		return nil
+	}))
+	require.Equal(t, 2, visited)
+
+	data, err := diff.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"location": "/paths/~1owners", "kind": "added", "message": "path added", "breaking": false}`, mustMarshal(t, diff.Changes[0]))
+	require.NotEmpty(t, data)
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	var oldSpec, newSpec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(diffOldSpec), &oldSpec))
+	require.NoError(t, json.Unmarshal([]byte(diffOldSpec), &newSpec))
+
+	// the inline schema is equal to the referenced one
+	pet := newSpec.Spec.Components.Spec.Schemas["Pet"]
+	newSpec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Post.Spec.RequestBody.Spec.Spec.Content["application/json"].Spec.Schema = pet.Clone()
+
+	diff, err := openapi.Diff(oldSpec.Spec, newSpec.Spec)
+	require.NoError(t, err)
+	require.Empty(t, diff.Changes)
+	require.Equal(t, false, diff.HasBreaking())
+	require.Equal(t, "", diff.String())
+}
+
+func TestDiff_SharedSchema(t *testing.T) {
+	var oldSpec, newSpec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(diffOldSpec), &oldSpec))
+	require.NoError(t, json.Unmarshal([]byte(diffOldSpec), &newSpec))
+
+	// Pet is used by the request body and the response, and it is recursive, so `parent` is not compared again
+	for _, spec := range []*openapi.Extendable[openapi.OpenAPI]{&oldSpec, &newSpec} {
+		spec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties["parent"] = openapi.NewSchemaBuilder().Ref("#/components/schemas/Pet").Build()
+	}
+	delete(newSpec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties, "tag")
+
+	diff, err := openapi.Diff(oldSpec.Spec, newSpec.Spec)
+	require.NoError(t, err)
+	require.Equal(t, []openapi.Change{
+		{Location: "/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/tag", Kind: openapi.ChangeRemoved, Message: "property removed", Breaking: true},
+		{Location: "/paths/~1pets/post/requestBody/content/application~1json/schema/properties/tag", Kind: openapi.ChangeRemoved, Message: "property removed"},
+	}, diff.Changes)
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -1,5 +1,10 @@
 package openapi
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Discriminator is used when request bodies or response payloads may be one of a number of different schemas,
 // a discriminator object can be used to aid in serialization, deserialization, and validation.
 // The discriminator is a specific object in a schema which is used to inform the consumer of the document of
@@ -41,6 +46,36 @@ func (o *Discriminator) validateSpec(location string, validator *Validator) []*v
 	return errs
 }
 
+// Resolve returns the reference to the schema for the given payload.
+//
+// The value of the PropertyName property of the payload is looked up in the Mapping,
+// the mapped value can be a reference or a schema name.
+// If the value is not mapped, then it is used as a name of the schema in the components.
+// An error is returned if the property is missing or the schema cannot be found.
+func (o *Discriminator) Resolve(payload map[string]any, c *Extendable[Components]) (*RefOrSpec[Schema], error) {
+	raw, found := payload[o.PropertyName]
+	if !found {
+		return nil, fmt.Errorf("discriminator property %q: %w", o.PropertyName, ErrRequired)
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("discriminator property %q must be a string, but got %T", o.PropertyName, raw)
+	}
+	target := value
+	if v, found := o.Mapping[value]; found {
+		target = v
+	}
+	if !strings.Contains(target, "/") {
+		// the schema name
+		target = ComponentSchemas.Ref(target)
+	}
+	ref := NewRefOrSpec[Schema](target)
+	if _, err := ref.GetSpec(c); err != nil {
+		return nil, fmt.Errorf("discriminator value %q is not mapped: %w", value, err)
+	}
+	return ref, nil
+}
+
 type DiscriminatorBuilder struct {
 	spec *Discriminator
 }
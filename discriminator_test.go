@@ -0,0 +1,50 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestDiscriminator_Resolve(t *testing.T) {
+	components := openapi.NewComponents()
+	for _, name := range []string{"Cat", "Dog", "Lizard"} {
+		components.Spec.Add(name, openapi.NewSchemaBuilder().
+			Type(openapi.ObjectType).
+			AddProperty("petType", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+			Build())
+	}
+	discriminator := openapi.NewDiscriminatorBuilder().
+		PropertyName("petType").
+		AddMapping("dog", "#/components/schemas/Dog").
+		AddMapping("lizard", "Lizard").
+		Build()
+
+	for _, tt := range []struct {
+		name    string
+		payload map[string]any
+		ref     string
+		err     string
+	}{
+		{name: "implicit", payload: map[string]any{"petType": "Cat"}, ref: "#/components/schemas/Cat"},
+		{name: "mapped ref", payload: map[string]any{"petType": "dog"}, ref: "#/components/schemas/Dog"},
+		{name: "mapped name", payload: map[string]any{"petType": "lizard"}, ref: "#/components/schemas/Lizard"},
+		{name: "missing property", payload: map[string]any{"name": "Tom"}, err: `discriminator property "petType": required`},
+		{name: "not a string", payload: map[string]any{"petType": 1}, err: `discriminator property "petType" must be a string, but got int`},
+		{name: "unmapped", payload: map[string]any{"petType": "Monster"}, err: `discriminator value "Monster" is not mapped: spec not found`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := discriminator.Resolve(tt.payload, components)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.ref, ref.Ref.Ref)
+			spec, err := ref.GetSpec(components)
+			require.NoError(t, err)
+			require.Equal(t, components.Spec.Schemas[tt.ref[len("#/components/schemas/"):]].Spec, spec)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Version303 is the version of the documents created by Downgrade function.
+const Version303 = "3.0.3"
+
+// Warning describes a lossy change made during the conversion of a document.
+type Warning struct {
+	// The location of the changed object in the form of JSON Pointer, e.g. `/components/schemas/Pet`.
+	Location string
+	Message  string
+}
+
+// String implements fmt.Stringer interface.
+func (w Warning) String() string {
+	return w.Location + ": " + w.Message
+}
+
+// unsupportedSchemaFields30 is the list of the Schema keywords, which are not supported by OpenAPI 3.0.
+var unsupportedSchemaFields30 = []string{
+	"$schema", "$id", "$defs", "$dynamicRef", "$dynamicAnchor", "$vocabulary", "$comment",
+	"if", "then", "else", "dependentRequired", "dependentSchemas", "prefixItems", "contains", "minContains", "maxContains",
+	"unevaluatedItems", "unevaluatedProperties", "patternProperties", "propertyNames",
+	"contentSchema", "contentMediaType", "contentEncoding",
+}
+
+// Downgrade converts the given OpenAPI 3.1 document to OpenAPI 3.0.3.
+//
+// The schemas are converted in the following way:
+//   - the nullable unions, like `type: [string, "null"]`, are rewritten into the single type and `nullable: true`,
+//     the unions of several types are rewritten into `anyOf`;
+//   - `const` is replaced with the single-value `enum`;
+//   - the first item of `examples` is moved to `example`;
+//   - the numeric `exclusiveMinimum` and `exclusiveMaximum` are converted to `minimum` and `maximum`
+//     with the boolean `exclusiveMinimum` and `exclusiveMaximum`.
+//
+// The objects, which are not supported by OpenAPI 3.0, are dropped with a warning:
+// `webhooks`, `jsonSchemaDialect`, `info.summary`, `license.identifier`, `components.paths`,
+// `mutualTLS` security schemes, `summary` and `description` of the references and the JSON Schema keywords
+// like `$defs`, `if`, `prefixItems` or `unevaluatedProperties`.
+// The warnings are returned for all lossy changes.
+//
+// The given document is not modified.
+func Downgrade(doc *OpenAPI) (*OpenAPI, []Warning, error) {
+	if !isVersion31(doc.OpenAPI) {
+		return nil, nil, NewUnsupportedVersionError(doc.OpenAPI)
+	}
+	d := downgrader{doc: doc.Clone()}
+	if err := d.downgrade(); err != nil {
+		return nil, nil, err
+	}
+	slices.SortStableFunc(d.warnings, func(a, b Warning) int {
+		return compareLocations(a.Location, b.Location)
+	})
+	return d.doc, d.warnings, nil
+}
+
+type downgrader struct {
+	doc      *OpenAPI
+	warnings []Warning
+}
+
+func (d *downgrader) warn(location string, format string, args ...any) {
+	d.warnings = append(d.warnings, Warning{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *downgrader) downgrade() error {
+	doc := d.doc
+	doc.OpenAPI = Version303
+	if doc.JsonSchemaDialect != "" {
+		if doc.JsonSchemaDialect != "https://spec.openapis.org/oas/3.1/dialect/base" {
+			d.warn("/jsonSchemaDialect", "the JSON Schema dialect %q is not supported by OpenAPI 3.0", doc.JsonSchemaDialect)
+		}
+		doc.JsonSchemaDialect = ""
+	}
+	if len(doc.WebHooks) > 0 {
+		d.warn("/webhooks", "the webhooks are not supported by OpenAPI 3.0")
+		doc.WebHooks = nil
+	}
+	if doc.Paths == nil {
+		// the paths are required in OpenAPI 3.0
+		doc.Paths = NewPaths()
+		doc.Paths.Spec.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]])
+	}
+	if doc.Info != nil {
+		if doc.Info.Spec.Summary != "" {
+			d.warn("/info/summary", "the summary of the info is not supported by OpenAPI 3.0")
+			doc.Info.Spec.Summary = ""
+		}
+		if l := doc.Info.Spec.License; l != nil && l.Spec.Identifier != "" {
+			d.warn("/info/license/identifier", "the SPDX license identifier %q is not supported by OpenAPI 3.0", l.Spec.Identifier)
+			l.Spec.Identifier = ""
+		}
+	}
+	if doc.Components != nil {
+		if len(doc.Components.Spec.Paths) > 0 {
+			d.warn("/components/paths", "the path items components are not supported by OpenAPI 3.0")
+			doc.Components.Spec.Paths = nil
+		}
+		for name, scheme := range doc.Components.Spec.SecuritySchemes {
+			if scheme.Spec != nil && scheme.Spec.Spec.Type == TypeMutualTLS {
+				d.warn(joinLoc("", "components", "securitySchemes", name), "the %q security scheme is not supported by OpenAPI 3.0", TypeMutualTLS)
+				delete(doc.Components.Spec.SecuritySchemes, name)
+			}
+		}
+	}
+
+	return Walk(doc, func(location string, node any) error {
+		switch n := node.(type) {
+		case *Ref:
+			if n.Summary != "" || n.Description != "" {
+				d.warn(location, "the summary and the description of the references are not supported by OpenAPI 3.0")
+				n.Summary = ""
+				n.Description = ""
+			}
+		case *Schema:
+			d.schema(location, n)
+		}
+		return nil
+	})
+}
+
+func (d *downgrader) schema(location string, s *Schema) {
+	fields := reflect.ValueOf(s).Elem()
+	for i := range fields.NumField() {
+		name, _, _ := strings.Cut(fields.Type().Field(i).Tag.Get("json"), ",")
+		if slices.Contains(unsupportedSchemaFields30, name) && !fields.Field(i).IsZero() {
+			d.warn(joinLoc(location, name), "the keyword is not supported by OpenAPI 3.0")
+			fields.Field(i).SetZero()
+		}
+	}
+
+	if s.Type != nil {
+		types := slices.DeleteFunc(slices.Clone(*s.Type), func(t string) bool { return t == NullType })
+		if len(types) < s.Type.Len() {
+			s.AddExt("nullable", true)
+		}
+		switch {
+		case len(types) == 0:
+			// the null type is expressed by `nullable` only
+			d.warn(joinLoc(location, "type"), "the %q type is not supported by OpenAPI 3.0", NullType)
+			s.Type = nil
+		case len(types) == 1:
+			s.Type = NewSingleOrArray(types...)
+		case len(s.AnyOf) == 0:
+			s.Type = nil
+			for _, t := range types {
+				s.AnyOf = append(s.AnyOf, NewSchemaBuilder().Type(t).Build())
+			}
+		default:
+			d.warn(joinLoc(location, "type"), "multiple types %v are not supported by OpenAPI 3.0, only the first one is used", types)
+			s.Type = NewSingleOrArray(types[0])
+		}
+	}
+
+	if s.Const != nil {
+		if len(s.Enum) > 0 && !enumContains(s.Enum, s.Const) {
+			d.warn(joinLoc(location, "enum"), "the enum is replaced with the const value %v", s.Const)
+		}
+		s.Enum = []any{s.Const}
+		s.Const = nil
+	}
+
+	if len(s.Examples) > 0 {
+		if len(s.Examples) > 1 || s.Example != nil {
+			d.warn(joinLoc(location, "examples"), "only one example is supported by OpenAPI 3.0")
+		}
+		if s.Example == nil {
+			s.Example = s.Examples[0]
+		}
+		s.Examples = nil
+	}
+
+	if s.ExclusiveMinimum != nil {
+		if s.Minimum == nil || *s.Minimum <= *s.ExclusiveMinimum {
+			s.Minimum = s.ExclusiveMinimum
+			s.AddExt("exclusiveMinimum", true)
+		}
+		s.ExclusiveMinimum = nil
+	}
+	if s.ExclusiveMaximum != nil {
+		if s.Maximum == nil || *s.Maximum >= *s.ExclusiveMaximum {
+			s.Maximum = s.ExclusiveMaximum
+			s.AddExt("exclusiveMaximum", true)
+		}
+		s.ExclusiveMaximum = nil
+	}
+}
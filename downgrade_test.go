@@ -0,0 +1,81 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestDowngrade(t *testing.T) {
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "openapi": "3.1.1",
+	  "jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+	  "info": {"title": "Pets", "summary": "Pets API", "version": "1.0.0", "license": {"name": "MIT", "identifier": "MIT"}},
+	  "webhooks": {"newPet": {"post": {"responses": {"200": {"description": "ok"}}}}},
+	  "components": {
+	    "schemas": {
+	      "Pet": {
+	        "type": "object",
+	        "properties": {
+	          "name": {"type": ["string", "null"], "examples": ["Tom", "Jerry"]},
+	          "kind": {"type": "string", "const": "cat"},
+	          "age": {"type": "integer", "exclusiveMinimum": 0},
+	          "id": {"type": ["string", "integer"]},
+	          "nothing": {"type": "null"},
+	          "tags": {"type": "array", "prefixItems": [{"type": "string"}]}
+	        }
+	      }
+	    }
+	  }
+	}`), &doc))
+
+	downgraded, warnings, err := openapi.Downgrade(doc.Spec)
+	require.NoError(t, err)
+	data, err := json.Marshal(downgraded)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+	  "openapi": "3.0.3",
+	  "info": {"title": "Pets", "version": "1.0.0", "license": {"name": "MIT"}},
+	  "paths": {},
+	  "components": {
+	    "schemas": {
+	      "Pet": {
+	        "type": "object",
+	        "properties": {
+	          "name": {"type": "string", "nullable": true, "example": "Tom"},
+	          "kind": {"type": "string", "enum": ["cat"]},
+	          "age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+	          "id": {"anyOf": [{"type": "string"}, {"type": "integer"}]},
+	          "nothing": {"nullable": true},
+	          "tags": {"type": "array"}
+	        }
+	      }
+	    }
+	  }
+	}`, string(data))
+
+	var messages []string
+	for _, w := range warnings {
+		messages = append(messages, w.String())
+	}
+	require.Equal(t, []string{
+		"/components/schemas/Pet/properties/name/examples: only one example is supported by OpenAPI 3.0",
+		`/components/schemas/Pet/properties/nothing/type: the "null" type is not supported by OpenAPI 3.0`,
+		"/components/schemas/Pet/properties/tags/prefixItems: the keyword is not supported by OpenAPI 3.0",
+		`/info/license/identifier: the SPDX license identifier "MIT" is not supported by OpenAPI 3.0`,
+		"/info/summary: the summary of the info is not supported by OpenAPI 3.0",
+		"/webhooks: the webhooks are not supported by OpenAPI 3.0",
+	}, messages)
+
+	// the original document is not modified
+	require.Equal(t, "3.1.1", doc.Spec.OpenAPI)
+	require.Equal(t, "cat", doc.Spec.Components.Spec.Schemas["Pet"].Spec.Properties["kind"].Spec.Const)
+}
+
+func TestDowngrade_UnsupportedVersion(t *testing.T) {
+	_, _, err := openapi.Downgrade(&openapi.OpenAPI{OpenAPI: "3.0.3"})
+	require.ErrorContains(t, err, "unsupported version: 3.0.3")
+}
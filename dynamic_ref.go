@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// schemaResource holds the dynamic keywords of the schemas of a single schema resource.
+type schemaResource struct {
+	// anchors are the locations of the schemas by the names of `$dynamicAnchor`, in order of declaration
+	anchors map[string][]string
+	// schemas are the schemas by their locations
+	schemas map[string]*Schema
+	// refs are the values of `$dynamicRef` by the locations of the schemas
+	refs map[string]string
+}
+
+// collectSchemaResources collects the `$dynamicAnchor` and `$dynamicRef` keywords of all the schemas of the document
+// by the schema resources, identified by the locations of the schemas with `$id`. The document itself is a resource
+// with the empty location, so the anchors of the components share the same scope.
+func collectSchemaResources(doc *OpenAPI) map[string]*schemaResource {
+	resources := make(map[string]*schemaResource)
+	// the stack of the locations of the enclosing resources, the depth-first traversal keeps it consistent
+	stack := []string{""}
+	_ = Walk(doc, func(location string, node any) error {
+		schema, ok := node.(*Schema)
+		if !ok {
+			return nil
+		}
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if location == top || strings.HasPrefix(location, top+"/") {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		if schema.ID != "" {
+			stack = append(stack, location)
+		}
+		if schema.DynamicAnchor == "" && schema.DynamicRef == "" {
+			return nil
+		}
+		key := stack[len(stack)-1]
+		r, found := resources[key]
+		if !found {
+			r = &schemaResource{
+				anchors: make(map[string][]string),
+				schemas: make(map[string]*Schema),
+				refs:    make(map[string]string),
+			}
+			resources[key] = r
+		}
+		r.schemas[location] = schema
+		if schema.DynamicAnchor != "" {
+			r.anchors[schema.DynamicAnchor] = append(r.anchors[schema.DynamicAnchor], location)
+		}
+		if schema.DynamicRef != "" {
+			r.refs[location] = schema.DynamicRef
+		}
+		return nil
+	})
+	return resources
+}
+
+// validateDynamicRefs checks that every `$dynamicRef` of the document refers to a `$dynamicAnchor`
+// declared in the same schema resource, and that the anchors are unique within the resource.
+// The references by JSON Pointer, e.g. `#/$defs/node`, are checked by the regular validation of the data.
+func validateDynamicRefs(doc *OpenAPI) []*validationError {
+	var errs []*validationError
+	resources := collectSchemaResources(doc)
+	for _, key := range sortedKeys(resources) {
+		r := resources[key]
+		for _, name := range sortedKeys(r.anchors) {
+			locations := r.anchors[name]
+			for _, loc := range locations[1:] {
+				errs = append(errs, newValidationError(joinLoc(loc, "$dynamicAnchor"), "'%s' is not unique, also used at %s", name, locations[0]))
+			}
+		}
+		for _, loc := range sortedKeys(r.refs) {
+			ref := r.refs[loc]
+			switch name, isFragment := strings.CutPrefix(ref, "#"); {
+			case !isFragment:
+				errs = append(errs, newValidationError(joinLoc(loc, "$dynamicRef"), "loading outside of the document is not implemented for the ref %q", ref))
+			case strings.HasPrefix(name, "/"):
+				// a JSON Pointer behaves like `$ref`
+			case len(r.anchors[name]) == 0:
+				errs = append(errs, newValidationError(joinLoc(loc, "$dynamicRef"), "no '$dynamicAnchor' named '%s' in scope", name))
+			}
+		}
+	}
+	return errs
+}
+
+// ResolveDynamicRef returns the schema declaring the `$dynamicAnchor`, which the given `$dynamicRef` refers to,
+// e.g. `#node`. The anchors are looked up in the scope of the document, so the anchors of the schemas with `$id`
+// and of their subschemas are not used, because they belong to other schema resources.
+//
+// Like `$ref`, a dynamic reference is resolved to the anchor of the same schema resource, the extending of the
+// recursive schemas by the dynamic scope is applied by the validation of the data.
+func ResolveDynamicRef(doc *OpenAPI, ref string) (*Schema, error) {
+	name, ok := strings.CutPrefix(ref, "#")
+	if !ok || name == "" || strings.HasPrefix(name, "/") {
+		return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("unsupported dynamic ref %q, expected an anchor, like '#node'", ref), nil)
+	}
+	r := collectSchemaResources(doc)[""]
+	if r == nil || len(r.anchors[name]) == 0 {
+		return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("$dynamicAnchor %q not found", name), []string{ref})
+	}
+	return r.schemas[r.anchors[name][0]], nil
+}
@@ -0,0 +1,90 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestDynamicRef(t *testing.T) {
+	data := `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Tree": {
+        "$dynamicAnchor": "node",
+        "type": "object",
+        "required": ["value"],
+        "properties": {
+          "value": {"type": "integer"},
+          "children": {"type": "array", "items": {"$dynamicRef": "#node"}}
+        }
+      },
+      "List": {
+        "$id": "https://example.com/list",
+        "$dynamicAnchor": "node",
+        "type": "array",
+        "items": {"$dynamicRef": "#node"}
+      }
+    }
+  }
+}`
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+
+	t.Run("validate", func(t *testing.T) {
+		validator, err := openapi.NewValidator(&doc, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+
+		tree := map[string]any{
+			"value": 1,
+			"children": []any{
+				map[string]any{"value": 2},
+				map[string]any{"value": 3, "children": []any{map[string]any{"value": 4}}},
+			},
+		}
+		require.NoError(t, validator.ValidateData("#/components/schemas/Tree", tree))
+		tree["children"].([]any)[1].(map[string]any)["children"] = []any{map[string]any{"name": "leaf"}}
+		require.Error(t, validator.ValidateData("#/components/schemas/Tree", tree))
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		schema, err := openapi.ResolveDynamicRef(doc.Spec, "#node")
+		require.NoError(t, err)
+		require.Equal(t, doc.Spec.Components.Spec.Schemas["Tree"].Spec, schema)
+
+		_, err = openapi.ResolveDynamicRef(doc.Spec, "#leaf")
+		require.ErrorContains(t, err, `$dynamicAnchor "leaf" not found`)
+		require.Equal(t, true, errors.Is(err, openapi.ErrRefTargetNotFound))
+		_, err = openapi.ResolveDynamicRef(doc.Spec, "#/components/schemas/Tree")
+		require.ErrorContains(t, err, "unsupported dynamic ref")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		spec := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddComponent("Tree", openapi.NewSchemaBuilder().DynamicAnchor("node").Type(openapi.ObjectType).
+				AddProperty("children", openapi.NewSchemaBuilder().Type(openapi.ArrayType).
+					Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().DynamicRef("#leaf").Build())).Build()).
+				Build()).
+			AddComponent("Node", openapi.NewSchemaBuilder().DynamicAnchor("node").Build()).
+			AddComponent("Remote", openapi.NewSchemaBuilder().DynamicRef("https://example.com/tree#node").Build()).
+			AddComponent("Scoped", openapi.NewSchemaBuilder().ID("https://example.com/scoped").
+				AddProperty("child", openapi.NewSchemaBuilder().DynamicRef("#node").Build()).Build()).
+			Build()
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		errs, _ := validator.Validate()
+		require.Len(t, errs, 4)
+		require.ErrorContains(t, errs[0], `/components/schemas/Remote/$dynamicRef: loading outside of the document is not implemented for the ref "https://example.com/tree#node"`)
+		require.ErrorContains(t, errs[1], "/components/schemas/Scoped/properties/child/$dynamicRef: no '$dynamicAnchor' named 'node' in scope")
+		require.ErrorContains(t, errs[2], "/components/schemas/Tree/$dynamicAnchor: 'node' is not unique, also used at /components/schemas/Node")
+		require.ErrorContains(t, errs[3], "/components/schemas/Tree/properties/children/items/$dynamicRef: no '$dynamicAnchor' named 'leaf' in scope")
+	})
+}
@@ -1,5 +1,13 @@
 package openapi
 
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"slices"
+	"strings"
+)
+
 // Encoding is definition that applied to a single schema property.
 //
 // https://spec.openapis.org/oas/v3.1.1#encoding-object
@@ -78,6 +86,9 @@ func (o *Encoding) validateSpec(location string, validator *Validator) []*valida
 	var errs []*validationError
 	if len(o.Headers) > 0 {
 		for k, v := range o.Headers {
+			if strings.EqualFold(k, "Content-Type") {
+				errs = append(errs, newValidationError(joinLoc(location, "headers", k), "not allowed, use `contentType` field instead"))
+			}
 			errs = append(errs, v.validateSpec(joinLoc(location, "headers", k), validator)...)
 		}
 	}
@@ -90,6 +101,77 @@ func (o *Encoding) validateSpec(location string, validator *Validator) []*valida
 	return errs
 }
 
+// ValidatePartHeaders validates the headers of a multipart part against the headers declared
+// in the Encoding object located at the given location.
+//
+// The location should be in form of JSON Pointer,
+// e.g. `/paths/~1upload/post/requestBody/content/multipart~1form-data/encoding/profileImage`.
+// The Content-Type header is ignored, because it is controlled by the `contentType` field.
+func (v *Validator) ValidatePartHeaders(location string, header textproto.MIMEHeader) error {
+	obj, err := ResolvePointer(v.spec.Spec, location)
+	if err != nil {
+		return err
+	}
+	encoding, ok := obj.(*Extendable[Encoding])
+	if !ok {
+		return fmt.Errorf("expected %T at %q, but got %T", encoding, location, obj)
+	}
+	return errors.Join(v.validateHeaders(joinLoc(location, "headers"), encoding.Spec.Headers, header)...)
+}
+
+// validateHeaders validates the values of the given header against the declared headers, sorted by name.
+// The values of each header are joined by comma and deserialized using `simple` style, so the arrays and objects are supported.
+// The Content-Type header is ignored.
+func (v *Validator) validateHeaders(location string, headers map[string]*RefOrSpec[Extendable[Header]], header textproto.MIMEHeader) []error {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs []error
+	for _, name := range names {
+		ref := headers[name]
+		if strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		loc := joinLoc(location, name)
+		h, err := ref.GetSpecCached(v.cache)
+		if err != nil {
+			errs = append(errs, newValidationError(loc, err))
+			continue
+		}
+		values := header.Values(name)
+		if len(values) == 0 {
+			if h.Spec.Required {
+				errs = append(errs, newValidationError(loc, ErrRequired))
+			}
+			continue
+		}
+		if h.Spec.Schema == nil {
+			continue
+		}
+		// the header is deserialized as a parameter with simple style, like the header parameters of the requests
+		p := &Parameter{Name: name, In: InHeader, Style: StyleSimple, Explode: &h.Spec.Explode, Schema: h.Spec.Schema}
+		value, err := p.DeserializeValue(strings.Join(values, ","))
+		if err != nil {
+			errs = append(errs, newValidationError(loc, err))
+			continue
+		}
+		schemaLoc := joinLoc(ref.getLocationOrRef(loc), "schema")
+		// the referenced schemas are not used by the deserialization, so the primitive values are kept as strings
+		// and have to be validated as JSON values to support non-string schemas, e.g. integer
+		err = v.ValidateData(schemaLoc, value)
+		if s, ok := value.(string); ok && err != nil {
+			err = v.ValidateDataAsJSON(schemaLoc, s)
+		}
+		if err != nil {
+			errs = append(errs, newValidationError(loc, err))
+		}
+	}
+	return errs
+}
+
 type EncodingBuilder struct {
 	spec *Extendable[Encoding]
 }
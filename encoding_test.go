@@ -0,0 +1,179 @@
+package openapi_test
+
+import (
+	"net/textproto"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func newMultipartSpec(encoding *openapi.Extendable[openapi.Encoding]) *openapi.Extendable[openapi.OpenAPI] {
+	return openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Multipart").Version("1.0.0").Build()).
+		AddPath("/upload", openapi.NewPathItemBuilder().
+			Post(openapi.NewOperationBuilder().
+				RequestBody(openapi.NewRequestBodyBuilder().
+					AddContent("multipart/form-data", openapi.NewMediaTypeBuilder().
+						Schema(openapi.NewSchemaBuilder().
+							Type(openapi.ObjectType).
+							AddProperty("profileImage", openapi.NewSchemaBuilder().Build()).
+							Build()).
+						AddEncoding("profileImage", encoding).
+						Build()).
+					Build()).
+				Build()).
+			Build()).
+		AddComponent("RateLimit", openapi.NewHeaderBuilder().
+			Required(true).
+			Schema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+			Build()).
+		Build()
+}
+
+func TestEncoding_validateSpec_ContentType(t *testing.T) {
+	spec := newMultipartSpec(openapi.NewEncodingBuilder().
+		ContentType("image/png").
+		Header("content-type", openapi.NewHeaderBuilder().Build()).
+		Build())
+	v, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.ErrorContains(t, v.ValidateSpec(), "encoding/profileImage/headers/content-type: not allowed, use `contentType` field instead")
+}
+
+func TestValidator_ValidatePartHeaders(t *testing.T) {
+	const location = "/paths/~1upload/post/requestBody/content/multipart~1form-data/encoding/profileImage"
+	spec := newMultipartSpec(openapi.NewEncodingBuilder().
+		ContentType("image/png").
+		Header("X-Rate-Limit", openapi.NewRefOrExtSpec[openapi.Header]("#/components/headers/RateLimit")).
+		Header("X-Image-Name", openapi.NewHeaderBuilder().
+			Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).MaxLength(5).Build()).
+			Build()).
+		Build())
+	v, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, v.ValidateSpec())
+
+	for _, tt := range []struct {
+		name   string
+		header textproto.MIMEHeader
+		err    string
+	}{
+		{
+			name:   "valid",
+			header: textproto.MIMEHeader{"X-Rate-Limit": {"10"}, "X-Image-Name": {"12345"}},
+		},
+		{
+			name:   "content type is ignored",
+			header: textproto.MIMEHeader{"X-Rate-Limit": {"10"}, "Content-Type": {"text/plain"}},
+		},
+		{
+			name:   "missing required",
+			header: textproto.MIMEHeader{"X-Image-Name": {"cat"}},
+			err:    "headers/X-Rate-Limit: required",
+		},
+		{
+			name:   "wrong type",
+			header: textproto.MIMEHeader{"X-Rate-Limit": {"ten"}},
+			err:    "headers/X-Rate-Limit: parameter \"X-Rate-Limit\": invalid value: unable to convert 'ten' to [integer]",
+		},
+		{
+			name:   "too long",
+			header: textproto.MIMEHeader{"X-Rate-Limit": {"10"}, "X-Image-Name": {"123456"}},
+			err:    "headers/X-Image-Name",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidatePartHeaders(location, tt.header)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMediaType_validateSpec_Encoding(t *testing.T) {
+	newSpec := func(mediaType, name string, encoding *openapi.Extendable[openapi.Encoding]) *openapi.Extendable[openapi.OpenAPI] {
+		return openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("Encoding").Version("1.0.0").Build()).
+			AddPath("/upload", openapi.NewPathItemBuilder().
+				Post(openapi.NewOperationBuilder().
+					RequestBody(openapi.NewRequestBodyBuilder().
+						AddContent(mediaType, openapi.NewMediaTypeBuilder().
+							Schema(openapi.NewSchemaBuilder().
+								Type(openapi.ObjectType).
+								AddProperty("profileImage", openapi.NewSchemaBuilder().Build()).
+								AllOf(openapi.NewSchemaBuilder().AddProperty("id", openapi.NewSchemaBuilder().Build()).Build()).
+								Build()).
+							AddEncoding(name, encoding).
+							Build()).
+						Build()).
+					Build()).
+				Build()).
+			Build()
+	}
+
+	for _, tt := range []struct {
+		name      string
+		mediaType string
+		property  string
+		encoding  *openapi.Extendable[openapi.Encoding]
+		err       string
+		warning   string
+	}{
+		{
+			name:      "valid",
+			mediaType: "multipart/form-data",
+			property:  "profileImage",
+			encoding:  openapi.NewEncodingBuilder().ContentType("image/png, image/*").Build(),
+		},
+		{
+			name:      "allOf property",
+			mediaType: "application/x-www-form-urlencoded",
+			property:  "id",
+			encoding:  openapi.NewEncodingBuilder().Style(openapi.StyleForm).Explode(true).Build(),
+		},
+		{
+			name:      "unknown property",
+			mediaType: "multipart/form-data",
+			property:  "avatar",
+			encoding:  openapi.NewEncodingBuilder().ContentType("image/png").Build(),
+			err:       "encoding/avatar: 'avatar' is not a property of the schema",
+		},
+		{
+			name:      "invalid content type",
+			mediaType: "multipart/form-data",
+			property:  "profileImage",
+			encoding:  openapi.NewEncodingBuilder().ContentType("image/png, png").Build(),
+			err:       "encoding/profileImage/contentType: invalid media type 'png': type/subtype expected",
+		},
+		{
+			name:      "style for json",
+			mediaType: "application/json",
+			property:  "profileImage",
+			encoding:  openapi.NewEncodingBuilder().Style(openapi.StyleForm).Build(),
+			warning:   "encoding/profileImage: style, explode and allowReserved are ignored for 'application/json'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := openapi.NewValidator(newSpec(tt.mediaType, tt.property, tt.encoding))
+			require.NoError(t, err)
+			errs, warnings := v.Validate()
+			if tt.err != "" {
+				require.Len(t, errs, 1)
+				require.ErrorContains(t, errs[0], tt.err)
+			} else {
+				require.Empty(t, errs)
+			}
+			if tt.warning != "" {
+				require.Len(t, warnings, 1)
+				require.ErrorContains(t, warnings[0], tt.warning)
+			} else {
+				require.Empty(t, warnings)
+			}
+		})
+	}
+}
@@ -47,12 +47,25 @@ type Example struct {
 	ExternalValue string `json:"externalValue,omitempty"`
 }
 
-func (o *Example) validateSpec(location string, _ *Validator) []*validationError {
+// validateExamples checks that the mutually exclusive `example` and `examples` fields of Parameter, Header
+// or Media Type objects are not set together and validates the examples.
+func validateExamples(location string, example any, examples map[string]*RefOrSpec[Extendable[Example]], validator *Validator) []*validationError {
+	var errs []*validationError
+	if example != nil && len(examples) > 0 {
+		errs = append(errs, newValidationError(joinLoc(location, "example&examples"), ErrMutuallyExclusive))
+	}
+	for k, v := range examples {
+		errs = append(errs, v.validateSpec(joinLoc(location, "examples", k), validator)...)
+	}
+	return errs
+}
+
+func (o *Example) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	if o.Value != nil && o.ExternalValue != "" {
 		errs = append(errs, newValidationError(joinLoc(location, "value&externalValue"), ErrMutuallyExclusive))
 	}
-	if err := checkURL(o.ExternalValue); err != nil {
+	if err := validator.checkURL(o.ExternalValue); err != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "externalValue"), err))
 	}
 	// no validation of Value field, because it needs a schema and
@@ -65,6 +65,49 @@ func (o *Extendable[T]) GetExt(name string) any {
 	return o.Extensions[name]
 }
 
+// ErrExtensionNotFound is returned by UnmarshalExt method if there is no extension with the given name.
+var ErrExtensionNotFound = errors.New("extension not found")
+
+// UnmarshalExt decodes the extension value into v by JSON encoding and decoding the stored value,
+// so v can be a pointer to any type, which is compatible with the JSON representation of the value.
+// The `x-` prefix will be added automatically to given name.
+// ErrExtensionNotFound is returned if there is no such extension.
+func (o *Extendable[T]) UnmarshalExt(name string, v any) error {
+	if !strings.HasPrefix(name, ExtensionPrefix) {
+		name = ExtensionPrefix + name
+	}
+	value, ok := o.Extensions[name]
+	if !ok {
+		return fmt.Errorf("%s: %w", name, ErrExtensionNotFound)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%T.Extensions.%s: %w", o.Spec, name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%T.Extensions.%s: %w", o.Spec, name, err)
+	}
+	return nil
+}
+
+// GetExt returns the extension value by name decoded into the type V, see UnmarshalExt method.
+// The `x-` prefix will be added automatically to given name.
+// The boolean result is false if there is no such extension.
+//
+// Example:
+//
+//	limit, ok, err := openapi.GetExt[RateLimit](doc, "rate-limit")
+func GetExt[V, T any](o *Extendable[T], name string) (V, bool, error) {
+	var v V
+	if err := o.UnmarshalExt(name, &v); err != nil {
+		if errors.Is(err, ErrExtensionNotFound) {
+			return v, false, nil
+		}
+		return v, true, err
+	}
+	return v, true, nil
+}
+
 // MarshalJSON implements json.Marshaler interface.
 func (o *Extendable[T]) MarshalJSON() ([]byte, error) {
 	var raw map[string]json.RawMessage
@@ -106,9 +149,14 @@ func (o *Extendable[T]) UnmarshalJSON(data []byte) error {
 			delete(raw, name)
 		}
 	}
-	fields, err := json.Marshal(&raw)
-	if err != nil {
-		return fmt.Errorf("%T(raw): %w", o.Spec, err)
+	// the structs ignore the extensions as unknown fields, so the data can be decoded as is,
+	// but the custom decoders, e.g. of the maps like Paths, must not get the extensions
+	fields := data
+	if _, ok := any(o.Spec).(json.Unmarshaler); ok && len(o.Extensions) > 0 {
+		var err error
+		if fields, err = json.Marshal(&raw); err != nil {
+			return fmt.Errorf("%T(raw): %w", o.Spec, err)
+		}
 	}
 	if err := json.Unmarshal(fields, &o.Spec); err != nil {
 		return fmt.Errorf("%T: %w", o.Spec, err)
@@ -136,6 +184,9 @@ func NewUnsupportedSpecTypeError(spec any) error {
 }
 
 func (o *Extendable[T]) validateSpec(location string, validator *Validator) []*validationError {
+	if validator.stopped() {
+		return nil
+	}
 	var errs []*validationError
 	if o.Spec != nil {
 		if spec, ok := any(o.Spec).(validatable); ok {
@@ -144,7 +195,7 @@ func (o *Extendable[T]) validateSpec(location string, validator *Validator) []*v
 			errs = append(errs, newValidationError(location, NewUnsupportedSpecTypeError(o.Spec)))
 		}
 	}
-	if validator.opts.allowExtensionNameWithoutPrefix {
+	if validator.checkFailFast(errs) || validator.opts.allowExtensionNameWithoutPrefix {
 		return errs
 	}
 
@@ -153,5 +204,6 @@ func (o *Extendable[T]) validateSpec(location string, validator *Validator) []*v
 			errs = append(errs, newValidationError(joinLoc(location, name), ErrExtensionNameMustStartWithPrefix))
 		}
 	}
+	validator.checkFailFast(errs)
 	return errs
 }
@@ -2,6 +2,7 @@ package openapi_test
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/sv-tools/openapi/internal/require"
@@ -88,3 +89,51 @@ func TestExtendable_WithExt(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExt(t *testing.T) {
+	type rateLimit struct {
+		Requests int    `json:"requests"`
+		Period   string `json:"period"`
+	}
+
+	var ext *openapi.Extendable[testExtendable]
+	require.NoError(t, json.Unmarshal([]byte(`{"x-rate-limit": {"requests": 100, "period": "1m"}, "x-name": "pets"}`), &ext))
+
+	for _, name := range []string{"rate-limit", "x-rate-limit"} {
+		t.Run(name, func(t *testing.T) {
+			limit, ok, err := openapi.GetExt[rateLimit](ext, name)
+			require.NoError(t, err)
+			require.Equal(t, true, ok)
+			require.Equal(t, rateLimit{Requests: 100, Period: "1m"}, limit)
+		})
+	}
+
+	t.Run("added value", func(t *testing.T) {
+		e := openapi.NewExtendable(&testExtendable{})
+		e.AddExt("rate-limit", map[string]any{"requests": 10})
+		limit, ok, err := openapi.GetExt[*rateLimit](e, "rate-limit")
+		require.NoError(t, err)
+		require.Equal(t, true, ok)
+		require.Equal(t, &rateLimit{Requests: 10}, limit)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		limit, ok, err := openapi.GetExt[rateLimit](ext, "limit")
+		require.NoError(t, err)
+		require.Equal(t, false, ok)
+		require.Equal(t, rateLimit{}, limit)
+		require.Equal(t, true, errors.Is(ext.UnmarshalExt("limit", &limit), openapi.ErrExtensionNotFound))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, ok, err := openapi.GetExt[int](ext, "name")
+		require.Equal(t, true, ok)
+		require.ErrorContains(t, err, "Extensions.x-name: json: cannot unmarshal string into Go value of type int")
+	})
+
+	t.Run("UnmarshalExt", func(t *testing.T) {
+		var name string
+		require.NoError(t, ext.UnmarshalExt("name", &name))
+		require.Equal(t, "pets", name)
+	})
+}
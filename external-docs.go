@@ -18,12 +18,12 @@ type ExternalDocs struct {
 	URL string `json:"url"`
 }
 
-func (o *ExternalDocs) validateSpec(location string, _ *Validator) []*validationError {
+func (o *ExternalDocs) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	if o.URL == "" {
 		errs = append(errs, newValidationError(joinLoc(location, "url"), ErrRequired))
 	}
-	if err := checkURL(o.URL); err != nil {
+	if err := validator.checkURL(o.URL); err != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "url"), err))
 	}
 	return errs
@@ -1,5 +1,7 @@
 package openapi
 
+import "encoding/json"
+
 // Header Object follows the structure of the Parameter Object with the some changes.
 //
 // https://spec.openapis.org/oas/v3.1.1#header-object
@@ -36,10 +38,47 @@ type Header struct {
 	// Specifies that a header is deprecated and SHOULD be transitioned out of usage.
 	// Default value is false.
 	Deprecated bool `json:"deprecated,omitempty"`
+	// Example of the header’s potential value.
+	// The example field is mutually exclusive of the examples field.
+	Example any `json:"example,omitempty"`
+	// Examples of the header’s potential value.
+	// The examples field is mutually exclusive of the example field.
+	Examples map[string]*RefOrSpec[Extendable[Example]] `json:"examples,omitempty"`
+
+	// forbidden holds the fields of Parameter Object, which are set in the source document, but not allowed for headers
+	forbidden []string
+}
+
+// headerForbiddenFields is the list of the fields of Parameter Object, which MUST NOT be used by Header Object.
+var headerForbiddenFields = []string{"allowEmptyValue", "allowReserved", "in", "name"}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// The fields `name`, `in`, `allowReserved` and `allowEmptyValue` are not stored, but reported by the validation.
+func (o *Header) UnmarshalJSON(data []byte) error {
+	type alias Header
+	var v alias
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	v.forbidden = nil
+	for _, name := range headerForbiddenFields {
+		if _, found := raw[name]; found {
+			v.forbidden = append(v.forbidden, name)
+		}
+	}
+	*o = Header(v)
+	return nil
 }
 
 func (o *Header) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
+	for _, name := range o.forbidden {
+		errs = append(errs, newValidationError(joinLoc(location, name), "not allowed for header"))
+	}
 	if o.Schema != nil && o.Content != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "schema&content"), ErrMutuallyExclusive))
 	}
@@ -48,13 +87,12 @@ func (o *Header) validateSpec(location string, validator *Validator) []*validati
 		if l != 1 {
 			errs = append(errs, newValidationError(joinLoc(location, "content"), "must be only one item, but got '%d'", l))
 		}
-		for k, v := range o.Content {
-			errs = append(errs, v.validateSpec(joinLoc(location, "content", k), validator)...)
-		}
+		errs = append(errs, validateContent(joinLoc(location, "content"), o.Content, validator)...)
 	}
 	if o.Schema != nil {
 		errs = append(errs, o.Schema.validateSpec(joinLoc(location, "schema"), validator)...)
 	}
+	errs = append(errs, validateExamples(location, o.Example, o.Examples, validator)...)
 
 	switch o.Style {
 	case "", StyleSimple:
@@ -131,3 +169,21 @@ func (b *HeaderBuilder) Deprecated(v bool) *HeaderBuilder {
 	b.spec.Spec.Spec.Deprecated = v
 	return b
 }
+
+func (b *HeaderBuilder) Example(v any) *HeaderBuilder {
+	b.spec.Spec.Spec.Example = v
+	return b
+}
+
+func (b *HeaderBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *HeaderBuilder {
+	b.spec.Spec.Spec.Examples = v
+	return b
+}
+
+func (b *HeaderBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *HeaderBuilder {
+	if b.spec.Spec.Spec.Examples == nil {
+		b.spec.Spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+	}
+	b.spec.Spec.Spec.Examples[name] = value
+	return b
+}
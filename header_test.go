@@ -0,0 +1,65 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestHeader_validateSpec(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		header string
+		errs   []string
+	}{
+		{
+			name:   "valid",
+			header: `{"description": "The number of allowed requests", "style": "simple", "schema": {"type": "integer"}}`,
+		},
+		{
+			name:   "in query",
+			header: `{"name": "X-Rate-Limit", "in": "query", "schema": {"type": "integer"}}`,
+			errs: []string{
+				"/components/headers/RateLimit/in: not allowed for header",
+				"/components/headers/RateLimit/name: not allowed for header",
+			},
+		},
+		{
+			name:   "parameter fields",
+			header: `{"allowReserved": true, "allowEmptyValue": false, "schema": {"type": "string"}}`,
+			errs: []string{
+				"/components/headers/RateLimit/allowEmptyValue: not allowed for header",
+				"/components/headers/RateLimit/allowReserved: not allowed for header",
+			},
+		},
+		{
+			name:   "example and examples",
+			header: `{"schema": {"type": "integer"}, "example": 100, "examples": {"limit": {"value": 100}}}`,
+			errs:   []string{"/components/headers/RateLimit/example&examples: mutually exclusive"},
+		},
+		{
+			name:   "form style",
+			header: `{"style": "form", "schema": {"type": "string"}}`,
+			errs:   []string{"/components/headers/RateLimit/style: invalid value, expected one of [simple], but got 'form'"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var header *openapi.RefOrSpec[openapi.Extendable[openapi.Header]]
+			require.NoError(t, json.Unmarshal([]byte(tt.header), &header))
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("RateLimit", header).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+		})
+	}
+}
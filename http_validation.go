@@ -0,0 +1,328 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ValidateRequest validates the given HTTP request against the operation,
+// which must be defined in the paths of the validated document.
+//
+// The path, query, header and cookie parameters of the operation and of its path item are deserialized
+// according to their `style` and `explode` fields and validated against their schemas;
+// the values of the path parameters are extracted from the request path using the path template.
+// The body is validated against the schema of the request body content, which is selected by the Content-Type header,
+// only the JSON media types are decoded and validated; for `multipart/form-data` the headers of each part
+// are validated against the encoding declared for the part, the other media types are checked for presence.
+// The body of the request is restored after reading, so it can be read again by the handler.
+func (v *Validator) ValidateRequest(op *Operation, r *http.Request) []error {
+	location, template, item, ok := v.findOperation(op)
+	if !ok {
+		return []error{fmt.Errorf("%w in the paths", ErrOperationNotFound)}
+	}
+
+	var errs []error
+	pathValues, _ := matchPathTemplate(template, r.URL.Path)
+	for _, p := range v.operationParameters(location, template, item, op, &errs) {
+		errs = append(errs, v.validateRequestParameter(p.location, p.param, r, pathValues)...)
+	}
+
+	if op.RequestBody != nil {
+		bodyLoc := strings.TrimPrefix(op.RequestBody.getLocationOrRef(joinLoc(location, "requestBody")), "#")
+		body, err := op.RequestBody.GetSpecCached(v.cache)
+		if err != nil {
+			return append(errs, newValidationError(bodyLoc, err))
+		}
+		errs = append(errs, v.validateRequestBody(bodyLoc, body.Spec, r)...)
+	}
+	return errs
+}
+
+// ValidateResponse validates the given HTTP response of the operation,
+// which must be defined in the paths of the validated document.
+//
+// The response is selected by the status code, the explicit code takes precedence over the range definition
+// and the default response, see Responses.Get method.
+// The declared headers are deserialized using `simple` style and validated against their schemas and the body is validated against the schema
+// of the response content, which is selected by the Content-Type header; only the JSON media types are decoded and validated.
+// An empty body is not validated.
+func (v *Validator) ValidateResponse(op *Operation, statusCode int, header http.Header, body []byte) []error {
+	location, _, _, ok := v.findOperation(op)
+	if !ok {
+		return []error{fmt.Errorf("%w in the paths", ErrOperationNotFound)}
+	}
+	var (
+		key string
+		ref *RefOrSpec[Extendable[Response]]
+	)
+	if op.Responses != nil {
+		key, ref = op.Responses.Spec.lookup(statusCode)
+	}
+	if ref == nil {
+		return []error{newValidationError(joinLoc(location, "responses"), "status code %d is not documented", statusCode)}
+	}
+	location = strings.TrimPrefix(ref.getLocationOrRef(joinLoc(location, "responses", key)), "#")
+	response, err := ref.GetSpecCached(v.cache)
+	if err != nil {
+		return []error{newValidationError(location, err)}
+	}
+
+	errs := v.validateHeaders(joinLoc(location, "headers"), response.Spec.Headers, textproto.MIMEHeader(header))
+	if len(body) > 0 && len(response.Spec.Content) > 0 {
+		errs = append(errs, v.validateBody(location, response.Spec.Content, header.Get("Content-Type"), body)...)
+	}
+	return errs
+}
+
+// findOperation returns the location of the given operation in the paths together with its path template and path item.
+// The operations declared next to `$ref` of a path item are found too, see ResolvePathItem.
+func (v *Validator) findOperation(op *Operation) (location, template string, item *RefOrSpec[Extendable[PathItem]], ok bool) {
+	if op == nil || v.spec.Spec.Paths == nil {
+		return "", "", nil, false
+	}
+	for _, path := range v.spec.Spec.Paths.Spec.Keys() {
+		ref := v.spec.Spec.Paths.Spec.Paths[path]
+		resolved, err := ResolvePathItem(ref, v.spec.Spec.Components)
+		if err != nil {
+			continue
+		}
+		methods, operations := resolved.Spec.operations()
+		for i, o := range operations {
+			if o.Spec != op {
+				continue
+			}
+			location = joinLoc("/paths", path)
+			if ref.IsRef() && (ref.Spec == nil || ref.Spec.Spec == nil || *ref.Spec.Spec.operationField(strings.ToUpper(methods[i])) != o) {
+				// the operation is declared in the referenced path item
+				location = strings.TrimPrefix(ref.Ref.Ref, "#")
+			}
+			return joinLoc(location, methods[i]), path, ref, true
+		}
+	}
+	return "", "", nil, false
+}
+
+type locatedParameter struct {
+	location string
+	param    *Parameter
+}
+
+// operationParameters returns the parameters of the operation and the ones of the path item,
+// which are not overridden by the operation; the resolving errors are added to errs.
+// The parameters declared next to `$ref` of the path item override the referenced ones.
+func (v *Validator) operationParameters(location, path string, item *RefOrSpec[Extendable[PathItem]], op *Operation, errs *[]error) []locatedParameter {
+	var params []locatedParameter
+	collect := func(location string, refs []*RefOrSpec[Extendable[Parameter]]) {
+		for i, ref := range refs {
+			loc := joinLoc(location, "parameters", i)
+			p, err := ref.GetSpecCached(v.cache)
+			if err != nil {
+				*errs = append(*errs, newValidationError(loc, err))
+				continue
+			}
+			if slices.ContainsFunc(params, func(o locatedParameter) bool {
+				return o.param.key() == p.Spec.key()
+			}) {
+				continue
+			}
+			params = append(params, locatedParameter{
+				location: strings.TrimPrefix(ref.getLocationOrRef(loc), "#"),
+				param:    p.Spec,
+			})
+		}
+	}
+	collect(location, op.Parameters)
+	if item.Spec != nil && item.Spec.Spec != nil {
+		collect(joinLoc("/paths", path), item.Spec.Spec.Parameters)
+	}
+	if item.IsRef() {
+		if referenced, err := item.GetSpecCached(v.cache); err == nil {
+			collect(strings.TrimPrefix(item.Ref.Ref, "#"), referenced.Spec.Parameters)
+		}
+	}
+	return params
+}
+
+func (v *Validator) validateRequestParameter(location string, p *Parameter, r *http.Request, pathValues map[string]string) []error {
+	var (
+		raw   string
+		found bool
+	)
+	switch p.In {
+	case InPath:
+		raw, found = pathValues[p.Name]
+		if !found {
+			raw = r.PathValue(p.Name)
+			found = raw != ""
+		}
+	case InQuery:
+		query := r.URL.Query()
+		raw, found = query.Get(p.Name), query.Has(p.Name)
+		if found && raw == "" && !p.AllowEmptyValue {
+			return []error{newValidationError(location, "empty value is not allowed")}
+		}
+	case InHeader:
+		values := r.Header.Values(p.Name)
+		raw, found = strings.Join(values, ","), len(values) > 0
+	case InCookie:
+		if c, err := r.Cookie(p.Name); err == nil {
+			raw, found = c.Value, true
+		}
+	}
+
+	if len(p.Content) > 0 {
+		// the value is serialized according to the media type, so only JSON is supported
+		if !found {
+			return requiredParameterError(location, p)
+		}
+		for k, mt := range p.Content {
+			if mt == nil || mt.Spec.Schema == nil {
+				continue
+			}
+			schemaLoc := mt.Spec.Schema.getLocationOrRef(joinLoc(location, "content", k, "schema"))
+			if err := v.ValidateDataAsJSON(schemaLoc, raw); err != nil {
+				return []error{newValidationError(location, err)}
+			}
+		}
+		return nil
+	}
+
+	var (
+		value any
+		err   error
+	)
+	switch p.In {
+	case InQuery:
+		value, err = p.DeserializeValues(r.URL.Query())
+		found = value != nil
+	case InCookie:
+		if found {
+			value, err = p.DeserializeValue(strings.Join(r.Header.Values("Cookie"), "; "))
+		}
+	default:
+		if found {
+			value, err = p.DeserializeValue(raw)
+		}
+	}
+	if err != nil {
+		return []error{newValidationError(location, err)}
+	}
+	if !found {
+		return requiredParameterError(location, p)
+	}
+	if p.Schema == nil {
+		return nil
+	}
+	schemaLoc := p.Schema.getLocationOrRef(joinLoc(location, "schema"))
+	// the referenced schemas are not used by the deserialization, so the primitive values are kept as strings
+	// and have to be validated as JSON values to support non-string schemas, e.g. integer
+	err = v.ValidateData(schemaLoc, value)
+	if s, ok := value.(string); ok && err != nil {
+		err = v.ValidateDataAsJSON(schemaLoc, s)
+	}
+	if err != nil {
+		return []error{newValidationError(location, err)}
+	}
+	return nil
+}
+
+func requiredParameterError(location string, p *Parameter) []error {
+	if p.Required {
+		return []error{newValidationError(location, ErrRequired)}
+	}
+	return nil
+}
+
+func (v *Validator) validateRequestBody(location string, body *RequestBody, r *http.Request) []error {
+	var data []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		data, err = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return []error{newValidationError(location, fmt.Errorf("reading body failed: %w", err))}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	if len(data) == 0 {
+		if body.Required {
+			return []error{newValidationError(location, ErrRequired)}
+		}
+		return nil
+	}
+
+	return v.validateBody(location, body.Content, r.Header.Get("Content-Type"), data)
+}
+
+// validateBody validates the body of the request or response against the content, which is selected by the given Content-Type.
+func (v *Validator) validateBody(location string, content map[string]*Extendable[MediaType], contentType string, data []byte) []error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return []error{newValidationError(joinLoc(location, "content"), "invalid Content-Type '%s': %w", contentType, err)}
+	}
+	key, mt, ok := selectContent(content, mediaType)
+	if !ok {
+		keys := make([]string, 0, len(content))
+		for k := range content {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		return []error{newValidationError(joinLoc(location, "content"), "Content-Type '%s' is not one of %v", mediaType, keys)}
+	}
+	if mediaType == "multipart/form-data" {
+		return v.validateParts(joinLoc(location, "content", key), mt, params["boundary"], data)
+	}
+	if mt.Schema == nil || !isJSONMediaType(mediaType) {
+		return nil
+	}
+	value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return []error{newValidationError(location, fmt.Errorf("decoding body failed: %w", err))}
+	}
+	schemaLoc := mt.Schema.getLocationOrRef(joinLoc(location, "content", key, "schema"))
+	if err := v.ValidateData(schemaLoc, value); err != nil {
+		return []error{newValidationError(location, err)}
+	}
+	return nil
+}
+
+// validateParts parses the multipart body and validates the headers of each part,
+// which has an encoding declared in the media type, using ValidatePartHeaders.
+func (v *Validator) validateParts(location string, mt *MediaType, boundary string, data []byte) []error {
+	if boundary == "" {
+		return []error{newValidationError(location, "missing multipart boundary")}
+	}
+	reader := multipart.NewReader(bytes.NewReader(data), boundary)
+	var errs []error
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return errs
+		}
+		if err != nil {
+			return append(errs, newValidationError(location, fmt.Errorf("reading multipart body failed: %w", err)))
+		}
+		name := part.FormName()
+		if _, ok := mt.Encoding[name]; ok {
+			if err := v.ValidatePartHeaders(joinLoc(location, "encoding", name), part.Header); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		_ = part.Close()
+	}
+}
+
+// isJSONMediaType returns true for `application/json` and the media types with `+json` suffix, e.g. `application/problem+json`.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
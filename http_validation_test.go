@@ -0,0 +1,415 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const httpValidationSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "operationId": "addPet",
+        "parameters": [
+          {"name": "X-Request-ID", "in": "header", "required": true, "schema": {"type": "string", "format": "uuid"}},
+          {"name": "dryRun", "in": "query", "schema": {"type": "boolean"}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+        },
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/upload": {
+      "post": {
+        "operationId": "upload",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "multipart/form-data": {
+              "schema": {"type": "object", "properties": {"file": {"type": "string"}, "note": {"type": "string"}}},
+              "encoding": {
+                "file": {
+                  "contentType": "text/plain",
+                  "headers": {"X-Checksum": {"required": true, "schema": {"type": "integer"}}}
+                }
+              }
+            }
+          }
+        },
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/pets/{id}": {
+      "parameters": [{"$ref": "#/components/parameters/ID"}],
+      "get": {
+        "operationId": "getPet",
+        "parameters": [
+          {"name": "fields", "in": "query", "explode": false, "schema": {"type": "array", "items": {"type": "string"}, "maxItems": 2}},
+          {"name": "tags", "in": "cookie", "explode": false, "schema": {"type": "array", "items": {"type": "string"}, "maxItems": 2}},
+          {"name": "ids", "in": "query", "schema": {"type": "array", "items": {"type": "integer"}, "maxItems": 1}}
+        ],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "headers": {
+              "X-Rate-Limit": {"$ref": "#/components/headers/RateLimit"},
+              "X-Rate": {"schema": {"type": "array", "items": {"type": "integer"}, "maxItems": 2}}
+            },
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+          },
+          "4XX": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "headers": {
+      "RateLimit": {"required": true, "schema": {"type": "integer"}}
+    },
+    "responses": {
+      "Error": {
+        "description": "error",
+        "content": {"application/problem+json": {"schema": {"type": "object", "required": ["title"]}}}
+      }
+    },
+    "parameters": {
+      "ID": {"name": "id", "in": "path", "required": true, "schema": {"$ref": "#/components/schemas/ID"}}
+    },
+    "schemas": {
+      "ID": {"type": "integer", "minimum": 1},
+      "Pet": {
+        "type": "object",
+        "required": ["id", "name"],
+        "properties": {"id": {"$ref": "#/components/schemas/ID"}, "name": {"type": "string"}},
+        "additionalProperties": false
+      }
+    }
+  }
+}`
+
+func newHTTPValidator(tb testing.TB) (*openapi.Validator, *openapi.Extendable[openapi.OpenAPI]) {
+	tb.Helper()
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(tb, json.Unmarshal([]byte(httpValidationSpec), &spec))
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(tb, err)
+	return validator, spec
+}
+
+func TestValidator_ValidateRequest(t *testing.T) {
+	validator, spec := newHTTPValidator(t)
+
+	for _, tt := range []struct {
+		name        string
+		operationID string
+		method      string
+		target      string
+		contentType string
+		body        string
+		errs        []string
+	}{
+		{
+			name:        "valid post",
+			operationID: "addPet",
+			method:      http.MethodPost,
+			target:      "/pets?dryRun=true",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+		},
+		{
+			name:        "missing required field",
+			operationID: "addPet",
+			method:      http.MethodPost,
+			target:      "/pets",
+			contentType: "application/json",
+			body:        `{"id": 1}`,
+			errs:        []string{"/paths/~1pets/post/requestBody: jsonschema validation failed", "missing property 'name'"},
+		},
+		{
+			name:        "wrong content type",
+			operationID: "addPet",
+			method:      http.MethodPost,
+			target:      "/pets",
+			contentType: "text/plain",
+			body:        `id=1`,
+			errs:        []string{"/paths/~1pets/post/requestBody/content: Content-Type 'text/plain' is not one of [application/json]"},
+		},
+		{
+			name:        "missing body",
+			operationID: "addPet",
+			method:      http.MethodPost,
+			target:      "/pets",
+			errs:        []string{"/paths/~1pets/post/requestBody: required"},
+		},
+		{
+			name:        "wrong query parameter",
+			operationID: "addPet",
+			method:      http.MethodPost,
+			target:      "/pets?dryRun=yes",
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+			errs:        []string{"/paths/~1pets/post/parameters/1: "},
+		},
+		{
+			name:        "valid get",
+			operationID: "getPet",
+			method:      http.MethodGet,
+			target:      "/pets/42?fields=id,name",
+		},
+		{
+			name:        "invalid path parameter",
+			operationID: "getPet",
+			method:      http.MethodGet,
+			target:      "/pets/0",
+			errs:        []string{"/components/parameters/ID: jsonschema validation failed", "minimum: got 0, want 1"},
+		},
+		{
+			name:        "invalid array query parameter",
+			operationID: "getPet",
+			method:      http.MethodGet,
+			target:      "/pets/42?fields=id,name,tag",
+			errs:        []string{"/paths/~1pets~1{id}/get/parameters/0: jsonschema validation failed", "maxItems: got 3, want 2"},
+		},
+		{
+			name:        "exploded by default query parameter",
+			operationID: "getPet",
+			method:      http.MethodGet,
+			target:      "/pets/42?ids=1",
+		},
+		{
+			name:        "invalid exploded by default query parameter",
+			operationID: "getPet",
+			method:      http.MethodGet,
+			target:      "/pets/42?ids=1&ids=2",
+			errs:        []string{"/paths/~1pets~1{id}/get/parameters/2: jsonschema validation failed", "maxItems: got 2, want 1"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, op, ok := spec.Spec.FindOperation(tt.operationID)
+			require.Equal(t, true, ok)
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.method == http.MethodPost {
+				r.Header.Set("X-Request-ID", "4ec5b9b1-3a5c-4d7e-8a8f-0d9c2a1e5f10")
+			}
+			errs := validator.ValidateRequest(op, r)
+			if len(tt.errs) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			for _, e := range tt.errs {
+				require.ErrorContains(t, errs[0], e)
+			}
+		})
+	}
+
+	t.Run("missing header", func(t *testing.T) {
+		_, _, op, _ := spec.Spec.FindOperation("addPet")
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"id": 1, "name": "Fluffy"}`))
+		r.Header.Set("Content-Type", "application/json")
+		errs := validator.ValidateRequest(op, r)
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "/paths/~1pets/post/parameters/0: required")
+	})
+
+	t.Run("body is restored", func(t *testing.T) {
+		_, _, op, _ := spec.Spec.FindOperation("addPet")
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"id": 1, "name": "Fluffy"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-Request-ID", "4ec5b9b1-3a5c-4d7e-8a8f-0d9c2a1e5f10")
+		require.Empty(t, validator.ValidateRequest(op, r))
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"id": 1, "name": "Fluffy"}`, string(data))
+	})
+
+	t.Run("cookie parameter", func(t *testing.T) {
+		_, _, op, _ := spec.Spec.FindOperation("getPet")
+		r := httptest.NewRequest(http.MethodGet, "/pets/42", nil)
+		r.Header.Add("Cookie", "session=1; tags=a,b")
+		require.Empty(t, validator.ValidateRequest(op, r))
+
+		r = httptest.NewRequest(http.MethodGet, "/pets/42", nil)
+		r.Header.Add("Cookie", "session=1")
+		r.Header.Add("Cookie", "tags=a,b,c")
+		errs := validator.ValidateRequest(op, r)
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "/paths/~1pets~1{id}/get/parameters/1: jsonschema validation failed")
+	})
+
+	t.Run("multipart part headers", func(t *testing.T) {
+		_, _, op, _ := spec.Spec.FindOperation("upload")
+		newRequest := func(checksum string) *http.Request {
+			var buf bytes.Buffer
+			w := multipart.NewWriter(&buf)
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="file"; filename="pet.txt"`)
+			header.Set("Content-Type", "text/plain")
+			if checksum != "" {
+				header.Set("X-Checksum", checksum)
+			}
+			part, err := w.CreatePart(header)
+			require.NoError(t, err)
+			_, err = part.Write([]byte("Fluffy"))
+			require.NoError(t, err)
+			require.NoError(t, w.WriteField("note", "no headers declared"))
+			require.NoError(t, w.Close())
+			r := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+			r.Header.Set("Content-Type", w.FormDataContentType())
+			return r
+		}
+
+		require.Empty(t, validator.ValidateRequest(op, newRequest("42")))
+
+		errs := validator.ValidateRequest(op, newRequest(""))
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "/paths/~1upload/post/requestBody/content/multipart~1form-data/encoding/file/headers/X-Checksum: required")
+
+		errs = validator.ValidateRequest(op, newRequest("abc"))
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "/paths/~1upload/post/requestBody/content/multipart~1form-data/encoding/file/headers/X-Checksum: ")
+
+		r := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("--xyz\r\nbroken header\r\n\r\nFluffy\r\n--xyz--\r\n"))
+		r.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+		errs = validator.ValidateRequest(op, r)
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "reading multipart body failed")
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		errs := validator.ValidateRequest(openapi.NewOperationBuilder().Build().Spec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "operation not found in the paths")
+		require.Equal(t, true, errors.Is(errs[0], openapi.ErrOperationNotFound))
+	})
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	validator, spec := newHTTPValidator(t)
+	_, _, op, ok := spec.Spec.FindOperation("getPet")
+	require.Equal(t, true, ok)
+
+	for _, tt := range []struct {
+		name        string
+		statusCode  int
+		rateLimit   string
+		rate        string
+		contentType string
+		body        string
+		errs        []string
+	}{
+		{
+			name:        "valid",
+			statusCode:  http.StatusOK,
+			rateLimit:   "100",
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+		},
+		{
+			name:        "undocumented field",
+			statusCode:  http.StatusOK,
+			rateLimit:   "100",
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy", "tag": "cat"}`,
+			errs:        []string{"/paths/~1pets~1{id}/get/responses/200: jsonschema validation failed", "additional properties 'tag' not allowed"},
+		},
+		{
+			name:        "missing header",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+			errs:        []string{"/paths/~1pets~1{id}/get/responses/200/headers/X-Rate-Limit: required"},
+		},
+		{
+			name:        "invalid header",
+			statusCode:  http.StatusOK,
+			rateLimit:   "many",
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+			errs:        []string{"/paths/~1pets~1{id}/get/responses/200/headers/X-Rate-Limit: "},
+		},
+		{
+			name:        "array header",
+			statusCode:  http.StatusOK,
+			rateLimit:   "100",
+			rate:        "1,2",
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+		},
+		{
+			name:        "invalid array header",
+			statusCode:  http.StatusOK,
+			rateLimit:   "100",
+			rate:        "1,2,3",
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+			errs:        []string{"/paths/~1pets~1{id}/get/responses/200/headers/X-Rate: jsonschema validation failed", "maxItems: got 3, want 2"},
+		},
+		{
+			name:        "referenced range response",
+			statusCode:  http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"title": "not found"}`,
+		},
+		{
+			name:        "invalid referenced range response",
+			statusCode:  http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{}`,
+			errs:        []string{"/components/responses/Error: jsonschema validation failed", "missing property 'title'"},
+		},
+		{
+			name:        "wrong content type",
+			statusCode:  http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"title": "not found"}`,
+			errs:        []string{"/components/responses/Error/content: Content-Type 'application/json' is not one of [application/problem+json]"},
+		},
+		{
+			name:       "undocumented status code",
+			statusCode: http.StatusInternalServerError,
+			errs:       []string{"/paths/~1pets~1{id}/get/responses: status code 500 is not documented"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.rateLimit != "" {
+				header.Set("X-Rate-Limit", tt.rateLimit)
+			}
+			if tt.rate != "" {
+				header.Set("X-Rate", tt.rate)
+			}
+			if tt.contentType != "" {
+				header.Set("Content-Type", tt.contentType)
+			}
+			errs := validator.ValidateResponse(op, tt.statusCode, header, []byte(tt.body))
+			if len(tt.errs) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			for _, e := range tt.errs {
+				require.ErrorContains(t, errs[0], e)
+			}
+		})
+	}
+}
@@ -54,7 +54,7 @@ func (o *Info) validateSpec(location string, validator *Validator) []*validation
 	if o.License != nil {
 		errs = append(errs, o.License.validateSpec(joinLoc(location, "license"), validator)...)
 	}
-	if err := checkURL(o.TermsOfService); err != nil {
+	if err := validator.checkURL(o.TermsOfService); err != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "termsOfService"), err))
 	}
 	return errs
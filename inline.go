@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Inline creates a copy of the given document with all references replaced by the deep copies of the referenced objects,
+// so the result contains no `$ref` fields.
+// The components are kept in the result and inlined as well.
+//
+// It fails if the document contains the circular references, e.g. a recursive schema,
+// because inlining cannot terminate in this case; the error contains the chain of the references.
+func Inline(doc *OpenAPI) (*OpenAPI, error) {
+	inlined := doc.Clone()
+	in := inliner{components: inlined.Components}
+	if err := in.walk(reflect.ValueOf(inlined), "", nil, make(visitedObjects)); err != nil {
+		return nil, err
+	}
+	return inlined, nil
+}
+
+type inliner struct {
+	components *Extendable[Components]
+}
+
+// walk inlines the references in the given object,
+// the chain and visited are the references being inlined on the current path.
+func (in *inliner) walk(v reflect.Value, location string, chain []string, visited visitedObjects) error {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			return in.walk(v.Elem(), location, chain, visited)
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		o, ok := v.Interface().(refOrSpec)
+		if !ok || o.getRef() == nil {
+			return in.walk(v.Elem(), location, chain, visited)
+		}
+		// follow the chain of references one by one to detect the cycles
+		var added []string
+		defer func() {
+			for _, ref := range added {
+				delete(visited, ref)
+			}
+		}()
+		for cur := o; cur != nil && cur.getRef() != nil; cur = in.component(cur.getRef().Ref) {
+			ref := cur.getRef().Ref
+			chain = append(chain, ref)
+			if visited[ref] {
+				return fmt.Errorf("%s: unable to inline circular reference: %s", location, strings.Join(chain, " -> "))
+			}
+			visited[ref] = true
+			added = append(added, ref)
+		}
+		spec, err := o.inline(in.components)
+		if err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		return in.walk(reflect.ValueOf(spec), location, chain, visited)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			loc := location
+			if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+				loc = joinLoc(location, name)
+			}
+			if err := in.walk(v.Field(i), loc, chain, visited); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// sort the keys to report the same error for the same document
+		keys := v.MapKeys()
+		if v.Type().Key().Kind() == reflect.String {
+			slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		}
+		for _, k := range keys {
+			if err := in.walk(v.MapIndex(k), joinLoc(location, k.Interface()), chain, visited); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := in.walk(v.Index(i), joinLoc(location, i), chain, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// component returns the component referenced by the given local reference or nil.
+func (in *inliner) component(ref string) refOrSpec {
+	kind, name, found := strings.Cut(strings.TrimPrefix(ref, "#/components/"), "/")
+	if !found || in.components == nil || !strings.HasPrefix(ref, "#/components/") {
+		return nil
+	}
+	m, err := in.components.Spec.componentsMap(ComponentKind(kind))
+	if err != nil {
+		return nil
+	}
+	v := m.MapIndex(reflect.ValueOf(name))
+	if !v.IsValid() || v.IsNil() {
+		return nil
+	}
+	o, _ := v.Interface().(refOrSpec)
+	return o
+}
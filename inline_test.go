@@ -0,0 +1,69 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestInline(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(linkSpec), &spec))
+	spec.Spec.Components = openapi.NewComponents()
+	spec.Spec.Components.Spec.
+		Add("User", openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+			AddProperty("id", openapi.NewSchemaBuilder().Ref("#/components/schemas/ID").Build()).
+			Build()).
+		Add("ID", openapi.NewSchemaBuilder().Type(openapi.StringType).Format(openapi.UUIDFormat).Build()).
+		Add("UserID", openapi.NewParameterBuilder().Name("id").In(openapi.InPath).Required(true).
+			Schema(openapi.NewSchemaBuilder().Ref("#/components/schemas/ID").Build()).
+			Build())
+	getUser := spec.Spec.Paths.Spec.Paths["/users/{id}"].Spec.Spec
+	getUser.Parameters = []*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+		openapi.NewRefOrExtSpec[openapi.Parameter]("#/components/parameters/UserID"),
+	}
+	getUser.Get.Spec.Responses.Spec.Response["200"].Spec.Spec.Content["application/json"].Spec.Schema =
+		openapi.NewSchemaBuilder().Ref("#/components/schemas/User").Build()
+
+	inlined, err := openapi.Inline(spec.Spec)
+	require.NoError(t, err)
+	data, err := json.Marshal(inlined)
+	require.NoError(t, err)
+	require.Truef(t, !strings.Contains(string(data), "$ref"), "$ref found in %s", data)
+
+	// round trip
+	var actual openapi.OpenAPI
+	require.NoError(t, json.Unmarshal(data, &actual))
+	actualData, err := json.Marshal(&actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(actualData))
+
+	param := inlined.Paths.Spec.Paths["/users/{id}"].Spec.Spec.Parameters[0]
+	require.Nil(t, param.Ref)
+	require.Equal(t, "id", param.Spec.Spec.Name)
+	require.Equal(t, openapi.UUIDFormat, param.Spec.Spec.Schema.Spec.Format)
+	// the inlined objects are copies
+	param.Spec.Spec.Schema.Spec.Format = ""
+	require.Equal(t, openapi.UUIDFormat, inlined.Components.Spec.Schemas["ID"].Spec.Format)
+
+	// the original document is not changed
+	require.Equal(t, "#/components/parameters/UserID", getUser.Parameters[0].Ref.Ref)
+}
+
+func TestInline_Cycle(t *testing.T) {
+	doc := openapi.NewOpenAPIBuilder().
+		AddComponent("Node", openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+			AddProperty("children", openapi.NewSchemaBuilder().Type(openapi.ArrayType).
+				Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Ref("#/components/schemas/Tree").Build())).
+				Build()).
+			Build()).
+		AddComponent("Tree", openapi.NewSchemaBuilder().Ref("#/components/schemas/Root").Build()).
+		AddComponent("Root", openapi.NewSchemaBuilder().Ref("#/components/schemas/Node").Build()).
+		Build()
+
+	_, err := openapi.Inline(doc.Spec)
+	require.ErrorContains(t, err, "unable to inline circular reference: #/components/schemas/Tree -> #/components/schemas/Root -> #/components/schemas/Node -> #/components/schemas/Tree")
+}
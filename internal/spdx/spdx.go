@@ -0,0 +1,197 @@
+// Package spdx validates SPDX license expressions.
+//
+// The lists of the license and the exception identifiers are taken from
+// the spdx-license-ids (3.0.21) and the spdx-exceptions (2.5.0) packages.
+//
+// https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/
+package spdx
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	//go:embed licenses.txt
+	licensesList string
+	//go:embed deprecated_licenses.txt
+	deprecatedLicensesList string
+	//go:embed exceptions.txt
+	exceptionsList string
+	//go:embed deprecated_exceptions.txt
+	deprecatedExceptionsList string
+
+	licenses             = newSet(licensesList)
+	deprecatedLicenses   = newSet(deprecatedLicensesList)
+	exceptions           = newSet(exceptionsList)
+	deprecatedExceptions = newSet(deprecatedExceptionsList)
+)
+
+var (
+	ErrInvalidExpression = errors.New("invalid SPDX license expression")
+	ErrUnknownIdentifier = errors.New("unknown SPDX identifier")
+)
+
+// the identifiers are case-insensitive
+func newSet(list string) map[string]bool {
+	m := make(map[string]bool)
+	for _, v := range strings.Fields(list) {
+		m[strings.ToLower(v)] = true
+	}
+	return m
+}
+
+var (
+	idString   = regexp.MustCompile(`^[A-Za-z0-9.\-]+$`)
+	licenseRef = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.\-]+:)?LicenseRef-[A-Za-z0-9.\-]+$`)
+)
+
+// Validate checks the syntax of the given license expression, e.g. `MIT`, `Apache-2.0 OR MIT`,
+// `GPL-2.0-or-later WITH Classpath-exception-2.0` or `LicenseRef-Custom`.
+//
+// If strict is true, then all the license and exception identifiers must be in the SPDX lists.
+// The deprecated identifiers are returned separately, because they are still valid.
+func Validate(expression string, strict bool) (deprecated []string, err error) {
+	p := parser{tokens: tokenize(expression), strict: strict}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidExpression)
+	}
+	if err := p.orExpression(); err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpression, p.tokens[p.pos])
+	}
+	return p.deprecated, nil
+}
+
+func tokenize(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	return strings.Fields(expression)
+}
+
+// parser is a recursive descent parser of the grammar:
+//
+//	or-expression   = and-expression *("OR" and-expression)
+//	and-expression  = with-expression *("AND" with-expression)
+//	with-expression = "(" or-expression ")" / license ["WITH" exception]
+type parser struct {
+	tokens     []string
+	pos        int
+	strict     bool
+	deprecated []string
+}
+
+func (p *parser) next() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// isOperator checks the operator, which must be either in upper or in lower case.
+func (p *parser) isOperator(op string) bool {
+	t := p.next()
+	return t == op || t == strings.ToLower(op)
+}
+
+func (p *parser) orExpression() error {
+	if err := p.andExpression(); err != nil {
+		return err
+	}
+	for p.isOperator("OR") {
+		p.pos++
+		if err := p.andExpression(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) andExpression() error {
+	if err := p.withExpression(); err != nil {
+		return err
+	}
+	for p.isOperator("AND") {
+		p.pos++
+		if err := p.withExpression(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) withExpression() error {
+	t := p.next()
+	switch {
+	case t == "":
+		return fmt.Errorf("%w: unexpected end", ErrInvalidExpression)
+	case t == "(":
+		p.pos++
+		if err := p.orExpression(); err != nil {
+			return err
+		}
+		if p.next() != ")" {
+			return fmt.Errorf("%w: missing ')'", ErrInvalidExpression)
+		}
+		p.pos++
+		return nil
+	}
+	p.pos++
+	if err := p.license(t); err != nil {
+		return err
+	}
+	if p.isOperator("WITH") {
+		p.pos++
+		return p.exception(p.next())
+	}
+	return nil
+}
+
+func (p *parser) isKeyword(t string) bool {
+	switch strings.ToUpper(t) {
+	case "AND", "OR", "WITH", "(", ")":
+		return true
+	}
+	return false
+}
+
+func (p *parser) license(t string) error {
+	if p.isKeyword(t) {
+		return fmt.Errorf("%w: unexpected %q", ErrInvalidExpression, t)
+	}
+	if licenseRef.MatchString(t) {
+		return nil
+	}
+	id := strings.TrimSuffix(t, "+")
+	if !idString.MatchString(id) {
+		return fmt.Errorf("%w: invalid identifier %q", ErrInvalidExpression, t)
+	}
+	switch key := strings.ToLower(id); {
+	case deprecatedLicenses[strings.ToLower(t)] || deprecatedLicenses[key]:
+		p.deprecated = append(p.deprecated, t)
+	case !licenses[key] && p.strict:
+		return fmt.Errorf("%w: %q", ErrUnknownIdentifier, t)
+	}
+	return nil
+}
+
+func (p *parser) exception(t string) error {
+	if t == "" || p.isKeyword(t) {
+		return fmt.Errorf("%w: missing exception after WITH", ErrInvalidExpression)
+	}
+	p.pos++
+	if !idString.MatchString(t) {
+		return fmt.Errorf("%w: invalid exception %q", ErrInvalidExpression, t)
+	}
+	switch key := strings.ToLower(t); {
+	case deprecatedExceptions[key]:
+		p.deprecated = append(p.deprecated, t)
+	case !exceptions[key] && p.strict:
+		return fmt.Errorf("%w: %q", ErrUnknownIdentifier, t)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// KeyOrder holds the order of the keys of all objects of a source JSON document,
+// e.g. the order of the paths, the response codes or the properties of the schemas.
+//
+// The Go maps, like Paths.Paths or Schema.Properties, do not preserve the order of the keys,
+// so use KeyOrder together with MarshalWithKeyOrder function to write the document in the order of the source:
+//
+//	var doc openapi.OpenAPI
+//	if err := json.Unmarshal(data, &doc); err != nil {
+//		return err
+//	}
+//	order, err := openapi.NewKeyOrder(data)
+//	if err != nil {
+//		return err
+//	}
+//	// modify the doc
+//	data, err = openapi.MarshalWithKeyOrder(&doc, order)
+type KeyOrder struct {
+	// keys is the list of the keys by the location of the object in the form of JSON Pointer
+	keys map[string][]string
+}
+
+// NewKeyOrder parses the given JSON document and records the order of the keys of all its objects.
+func NewKeyOrder(data []byte) (*KeyOrder, error) {
+	o := &KeyOrder{keys: make(map[string][]string)}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := o.parse(decoder, ""); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Keys returns the keys of the object at the given location in the order of the source document,
+// e.g. `Keys("/paths")` or `Keys("/components/schemas/Pet/properties")`.
+func (o *KeyOrder) Keys(location string) []string {
+	return o.keys[location]
+}
+
+func (o *KeyOrder) parse(decoder *json.Decoder, location string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		var keys []string
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := token.(string)
+			if !ok {
+				return fmt.Errorf("%s: unexpected token %v", location, token)
+			}
+			keys = append(keys, key)
+			if err := o.parse(decoder, joinLoc(location, key)); err != nil {
+				return err
+			}
+		}
+		o.keys[location] = keys
+		_, err = decoder.Token()
+		return err
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := o.parse(decoder, joinLoc(location, i)); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+	}
+	return nil
+}
+
+// comparator sorts the keys in the order of the source document,
+// the new keys go after the known ones in the canonical order, see MarshalCanonical.
+func (o *KeyOrder) comparator(keys []string) func(a, b string) int {
+	parts := make([]any, len(keys))
+	for i, k := range keys {
+		parts[i] = k
+	}
+	positions := make(map[string]int)
+	for i, k := range o.keys[joinLoc("", parts...)] {
+		positions[k] = i
+	}
+	canonical := canonicalComparator(keys)
+	return func(a, b string) int {
+		pa, aok := positions[a]
+		pb, bok := positions[b]
+		switch {
+		case aok && bok:
+			return pa - pb
+		case aok:
+			return -1
+		case bok:
+			return 1
+		default:
+			return canonical(a, b)
+		}
+	}
+}
+
+// MarshalWithKeyOrder returns the JSON encoding of the given document with the keys of the objects
+// in the order of the source document, the new keys, which are absent in the source, go after the known ones
+// in the canonical order, see MarshalCanonical.
+//
+// The output is indented with two spaces.
+func MarshalWithKeyOrder(doc *OpenAPI, order *KeyOrder) ([]byte, error) {
+	if order == nil {
+		return MarshalCanonical(doc)
+	}
+	return marshalOrdered(doc, order.comparator)
+}
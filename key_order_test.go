@@ -0,0 +1,79 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const keyOrderSpec = `{
+  "openapi": "3.1.1",
+  "info": {"version": "1.0.0", "title": "Key Order"},
+  "paths": {
+    "/pets/{id}": {
+      "get": {
+        "responses": {
+          "404": {"description": "not found"},
+          "200": {
+            "description": "the pet",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+          }
+        }
+      }
+    },
+    "/pets": {
+      "get": {
+        "responses": {"default": {"description": "the pets"}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "id": {"type": "integer"},
+          "age": {"type": "integer"}
+        }
+      }
+    }
+  }
+}`
+
+func TestMarshalWithKeyOrder(t *testing.T) {
+	var doc openapi.OpenAPI
+	require.NoError(t, json.Unmarshal([]byte(keyOrderSpec), &doc))
+	order, err := openapi.NewKeyOrder([]byte(keyOrderSpec))
+	require.NoError(t, err)
+	require.Equal(t, []string{"/pets/{id}", "/pets"}, order.Keys("/paths"))
+	require.Equal(t, []string{"name", "id", "age"}, order.Keys("/components/schemas/Pet/properties"))
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := openapi.MarshalWithKeyOrder(&doc, order)
+		require.NoError(t, err)
+
+		var compact, expected bytes.Buffer
+		require.NoError(t, json.Compact(&compact, []byte(keyOrderSpec)))
+		require.NoError(t, json.Indent(&expected, compact.Bytes(), "", "  "))
+		require.Equal(t, expected.String(), string(data))
+	})
+
+	t.Run("new keys", func(t *testing.T) {
+		updated := doc.Clone()
+		updated.Paths.Spec.Add("/owners", openapi.NewPathItemBuilder().Summary("owners").Build())
+		updated.Paths.Spec.Add("/a", openapi.NewPathItemBuilder().Summary("a").Build())
+		data, err := openapi.MarshalWithKeyOrder(updated, order)
+		require.NoError(t, err)
+		pos := -1
+		for _, key := range []string{`"/pets/{id}": {`, `"/pets": {`, `"/a": {`, `"/owners": {`} {
+			i := strings.Index(string(data), key)
+			require.Truef(t, i > pos, "%s is out of order:\n%s", key, data)
+			pos = i
+		}
+	})
+}
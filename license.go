@@ -1,5 +1,9 @@
 package openapi
 
+import (
+	"github.com/sv-tools/openapi/internal/spdx"
+)
+
 // License information for the exposed API.
 //
 // https://spec.openapis.org/oas/v3.1.1#license-object
@@ -21,7 +25,7 @@ type License struct {
 	URL string `json:"url,omitempty"`
 }
 
-func (o *License) validateSpec(location string, _ *Validator) []*validationError {
+func (o *License) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	if o.Name == "" {
 		errs = append(errs, newValidationError(joinLoc(location, "name"), ErrRequired))
@@ -29,7 +33,16 @@ func (o *License) validateSpec(location string, _ *Validator) []*validationError
 	if o.Identifier != "" && o.URL != "" {
 		errs = append(errs, newValidationError(joinLoc(location, "identifier&url"), ErrMutuallyExclusive))
 	}
-	if err := checkURL(o.URL); err != nil {
+	if o.Identifier != "" {
+		deprecated, err := spdx.Validate(o.Identifier, !validator.opts.allowUnknownLicenseIdentifiers)
+		if err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, "identifier"), err))
+		}
+		for _, id := range deprecated {
+			errs = append(errs, newValidationWarning(joinLoc(location, "identifier"), "%w SPDX identifier %q", ErrDeprecated, id))
+		}
+	}
+	if err := validator.checkURL(o.URL); err != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "url"), err))
 	}
 	return errs
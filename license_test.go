@@ -0,0 +1,111 @@
+package openapi_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestLicense_Identifier(t *testing.T) {
+	for _, tt := range []struct {
+		identifier string
+		opts       []openapi.ValidationOption
+		err        string
+		warning    string
+	}{
+		{identifier: "MIT"},
+		{identifier: "mit"},
+		{identifier: "Apache-2.0 OR MIT"},
+		{identifier: "(MIT AND BSD-3-Clause) or Apache-2.0+"},
+		{identifier: "GPL-2.0-or-later WITH Classpath-exception-2.0"},
+		{identifier: "LicenseRef-Custom"},
+		{identifier: "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2"},
+		{identifier: "GPL-2.0+", warning: `deprecated SPDX identifier "GPL-2.0+"`},
+		{identifier: "Unknown-1.0", err: `unknown SPDX identifier: "Unknown-1.0"`},
+		{identifier: "Unknown-1.0", opts: []openapi.ValidationOption{openapi.AllowUnknownLicenseIdentifiers()}},
+		{identifier: "MIT WITH Unknown-exception", err: `unknown SPDX identifier: "Unknown-exception"`},
+		{identifier: "MIT OR", err: "invalid SPDX license expression: unexpected end"},
+		{identifier: "MIT Apache-2.0", err: `invalid SPDX license expression: unexpected "Apache-2.0"`},
+		{identifier: "(MIT", err: "invalid SPDX license expression: missing ')'"},
+		{identifier: "MIT WITH", err: "invalid SPDX license expression: missing exception after WITH"},
+		{identifier: "MIT And Apache-2.0", err: `invalid SPDX license expression: unexpected "And"`},
+		{identifier: "MIT/X11", opts: []openapi.ValidationOption{openapi.AllowUnknownLicenseIdentifiers()}, err: `invalid SPDX license expression: invalid identifier "MIT/X11"`},
+	} {
+		t.Run(tt.identifier, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().
+					Title("test").
+					Version("1.0.0").
+					License(openapi.NewLicenseBuilder().Name("test").Identifier(tt.identifier).Build()).
+					Build()).
+				AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, tt.opts...)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			if tt.err != "" {
+				require.Len(t, errs, 1)
+				require.ErrorContains(t, errs[0], "/info/license/identifier: "+tt.err)
+			} else {
+				require.Empty(t, errs)
+			}
+			if tt.warning != "" {
+				require.Len(t, warnings, 1)
+				require.ErrorContains(t, warnings[0], "/info/license/identifier: "+tt.warning)
+			} else {
+				require.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestLicense_URL(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		url  string
+		opts []openapi.ValidationOption
+		err  string
+	}{
+		{name: "relative", url: "/licenses/mit"},
+		{name: "relative with https", url: "/licenses/mit", opts: []openapi.ValidationOption{openapi.RequireHTTPS()}, err: "'/licenses/mit' is not an absolute URL"},
+		{name: "relative by default", url: "../LICENSE"},
+		{name: "https", url: "https://opensource.org/license/mit", opts: []openapi.ValidationOption{openapi.RequireHTTPS()}},
+		{name: "http", url: "http://opensource.org/license/mit", opts: []openapi.ValidationOption{openapi.RequireHTTPS()}, err: "'http://opensource.org/license/mit' must use https scheme"},
+		{name: "invalid", url: "http://[::1", err: "invalid URL"},
+		{
+			name: "custom",
+			url:  "http://[::1",
+			opts: []openapi.ValidationOption{openapi.RequireHTTPS(), openapi.WithURLChecker(func(string) error { return nil })},
+		},
+		{
+			name: "custom error",
+			url:  "https://opensource.org/license/mit",
+			opts: []openapi.ValidationOption{openapi.WithURLChecker(func(s string) error { return fmt.Errorf("'%s' is not allowed", s) })},
+			err:  "'https://opensource.org/license/mit' is not allowed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().
+					Title("test").
+					Version("1.0.0").
+					License(openapi.NewLicenseBuilder().Name("test").URL(tt.url).Build()).
+					Build()).
+				AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, tt.opts...)
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			if tt.err != "" {
+				require.Len(t, errs, 1)
+				require.ErrorContains(t, errs[0], "/info/license/url: "+tt.err)
+			} else {
+				require.Empty(t, errs)
+			}
+		})
+	}
+}
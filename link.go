@@ -1,5 +1,11 @@
 package openapi
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Link represents a possible design-time link for a response.
 // The presence of a link does not guarantee the caller’s ability to successfully invoke it,
 // rather it provides a known relationship and traversal mechanism between responses and other operations.
@@ -85,23 +91,95 @@ func (o *Link) validateSpec(location string, validator *Validator) []*validation
 	if o.OperationRef != "" && o.OperationID != "" {
 		errs = append(errs, newValidationError(joinLoc(location, "operationRef&operationId"), ErrMutuallyExclusive))
 	}
-	if o.OperationID != "" {
-		id := joinLoc("operations", o.OperationID)
-		if !validator.visited[id] {
-			validator.linkToOperationID[joinLoc(location, "operationId")] = o.OperationID
+	if o.OperationID != "" && !validator.detached {
+		if _, err := o.resolveOperationID(validator.operations); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, "operationId"), err))
+		}
+	}
+	// only local references can be checked, the loading by url is not supported yet
+	if strings.HasPrefix(o.OperationRef, "#") && !validator.detached {
+		if _, err := o.resolveOperationRef(validator.spec.Spec); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, "operationRef"), err))
+		}
+	}
+	for k, v := range o.Parameters {
+		// the value can be a constant or a runtime expression
+		if expr, ok := v.(string); ok && strings.HasPrefix(expr, "$") {
+			if _, err := ParseRuntimeExpression(expr); err != nil {
+				errs = append(errs, newValidationError(joinLoc(location, "parameters", k), err))
+			}
 		}
 	}
-	// uncomment when JSONLookup is implemented
-	// if o.OperationRef != "" {
-	//	ref := NewRefOrExtSpec[Operation](o.OperationRef)
-	//	errs = append(errs, ref.validateSpec(joinLoc(location, "operationRef"), validator)...)
-	//}
 	if o.Server != nil {
 		errs = append(errs, o.Server.validateSpec(joinLoc(location, "server"), validator)...)
 	}
 	return errs
 }
 
+// ErrOperationNotFound is returned if the operation cannot be found in the document.
+var ErrOperationNotFound = errors.New("operation not found")
+
+const operationNotFoundPrefix = "operation not found: "
+
+type OperationNotFoundError string
+
+func (e OperationNotFoundError) Error() string {
+	return operationNotFoundPrefix + string(e)
+}
+
+func (e OperationNotFoundError) Is(target error) bool {
+	return target == ErrOperationNotFound || strings.HasPrefix(target.Error(), operationNotFoundPrefix)
+}
+
+func NewOperationNotFoundError(v string) error {
+	return OperationNotFoundError(v)
+}
+
+// ResolveOperation returns the target Operation of the link.
+//
+// The operation is searched by operationId in all path items, webhooks and callbacks of the given document or
+// located by operationRef using JSON Pointer.
+// Only local operationRef values (started with `#`) are supported.
+// An error is returned if both operationId and operationRef are set or if the operation cannot be found.
+func (o *Link) ResolveOperation(doc *OpenAPI) (*Operation, error) {
+	switch {
+	case o.OperationRef != "" && o.OperationID != "":
+		return nil, fmt.Errorf("operationRef&operationId: %w", ErrMutuallyExclusive)
+	case o.OperationID != "":
+		return o.resolveOperationID(doc.operationIndex(true))
+	case o.OperationRef != "":
+		return o.resolveOperationRef(doc)
+	default:
+		return nil, fmt.Errorf("operationRef||operationId: %w", ErrRequired)
+	}
+}
+
+func (o *Link) resolveOperationID(operations map[string][]operationEntry) (*Operation, error) {
+	if entries := operations[o.OperationID]; len(entries) > 0 {
+		return entries[0].operation, nil
+	}
+	return nil, NewOperationNotFoundError(fmt.Sprintf("operationId %q", o.OperationID))
+}
+
+func (o *Link) resolveOperationRef(doc *OpenAPI) (*Operation, error) {
+	if !strings.HasPrefix(o.OperationRef, "#") {
+		// TODO: support loading by url
+		return nil, NewOperationNotFoundError(fmt.Sprintf("loading by url is not implemented for the operationRef %q", o.OperationRef))
+	}
+	v, err := ResolvePointer(doc, o.OperationRef)
+	if err != nil {
+		return nil, NewOperationNotFoundError(fmt.Sprintf("operationRef %q: %s", o.OperationRef, err))
+	}
+	switch op := v.(type) {
+	case *Extendable[Operation]:
+		return op.Spec, nil
+	case *Operation:
+		return op, nil
+	default:
+		return nil, NewOperationNotFoundError(fmt.Sprintf("operationRef %q points to %T", o.OperationRef, v))
+	}
+}
+
 type LinkBuilder struct {
 	spec *RefOrSpec[Extendable[Link]]
 }
@@ -116,6 +194,15 @@ func (b *LinkBuilder) Build() *RefOrSpec[Extendable[Link]] {
 	return b.spec
 }
 
+// BuildValid validates and returns the built object, or the validation errors if the object is invalid.
+// The object is validated standalone, so the references and the examples are not checked.
+func (b *LinkBuilder) BuildValid() (*RefOrSpec[Extendable[Link]], error) {
+	if err := validateDetached("", b.spec); err != nil {
+		return nil, err
+	}
+	return b.spec, nil
+}
+
 func (b *LinkBuilder) Extensions(v map[string]any) *LinkBuilder {
 	b.spec.Spec.Extensions = v
 	return b
@@ -0,0 +1,216 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const linkSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Link Example", "version": "1.0.0"},
+  "paths": {
+    "/users/{id}": {
+      "parameters": [
+        {"name": "id", "in": "path", "required": true, "description": "the user identifier, as userId", "schema": {"type": "string"}}
+      ],
+      "get": {
+        "responses": {
+          "200": {
+            "description": "the user being returned",
+            "content": {
+              "application/json": {
+                "schema": {"type": "object", "properties": {"uuid": {"type": "string", "format": "uuid"}}}
+              }
+            },
+            "links": {
+              "address": {"operationId": "getUserAddress", "parameters": {"userId": "$request.path.id"}}
+            }
+          }
+        }
+      }
+    },
+    "/users/{userid}/address": {
+      "parameters": [
+        {"name": "userid", "in": "path", "required": true, "description": "the user identifier, as userId", "schema": {"type": "string"}}
+      ],
+      "get": {
+        "operationId": "getUserAddress",
+        "responses": {"200": {"description": "the user's address"}}
+      }
+    }
+  }
+}`
+
+func TestLink_ResolveOperation(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(linkSpec), &spec))
+
+	for _, tt := range []struct {
+		name string
+		link *openapi.Link
+		err  string
+	}{
+		{
+			name: "by operationId",
+			link: &openapi.Link{OperationID: "getUserAddress"},
+		},
+		{
+			name: "by operationRef",
+			link: &openapi.Link{OperationRef: "#/paths/~1users~1{userid}~1address/get"},
+		},
+		{
+			name: "by url encoded operationRef",
+			link: &openapi.Link{OperationRef: "#/paths/~1users~1%7Buserid%7D~1address/get"},
+		},
+		{
+			name: "operationId not found",
+			link: &openapi.Link{OperationID: "getUserPhone"},
+			err:  "operation not found: operationId \"getUserPhone\"",
+		},
+		{
+			name: "operationRef not found",
+			link: &openapi.Link{OperationRef: "#/paths/~1users~1{userid}~1address/post"},
+			err:  "operation not found",
+		},
+		{
+			name: "operationRef points to not an operation",
+			link: &openapi.Link{OperationRef: "#/paths/~1users~1{userid}~1address/parameters/0"},
+			err:  "points to",
+		},
+		{
+			name: "external operationRef",
+			link: &openapi.Link{OperationRef: "https://example.com/openapi.json#/paths/~1users/get"},
+			err:  "loading by url is not implemented",
+		},
+		{
+			name: "both",
+			link: &openapi.Link{OperationID: "getUserAddress", OperationRef: "#/paths/~1users~1{userid}~1address/get"},
+			err:  "mutually exclusive",
+		},
+		{
+			name: "none",
+			link: &openapi.Link{},
+			err:  "required",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := tt.link.ResolveOperation(spec.Spec)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, op)
+			require.Equal(t, "getUserAddress", op.OperationID)
+		})
+	}
+}
+
+func TestLink_ResolveOperation_Callbacks(t *testing.T) {
+	const doc = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Callbacks", "version": "1.0.0"},
+  "paths": {
+    "/subscribe": {
+      "post": {
+        "operationId": "subscribe",
+        "callbacks": {
+          "onEvent": {
+            "{$request.body#/url}": {
+              "post": {"operationId": "onEvent", "responses": {"200": {"description": "ok"}}}
+            }
+          }
+        },
+        "responses": {
+          "201": {"description": "subscribed", "links": {"event": {"operationId": "onEvent"}, "pet": {"operationId": "newPet"}}}
+        }
+      }
+    }
+  },
+  "webhooks": {
+    "newPet": {
+      "post": {"operationId": "newPet", "responses": {"200": {"description": "ok"}}}
+    }
+  }
+}`
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(doc), &spec))
+
+	for _, id := range []string{"onEvent", "newPet"} {
+		t.Run(id, func(t *testing.T) {
+			op, err := (&openapi.Link{OperationID: id}).ResolveOperation(spec.Spec)
+			require.NoError(t, err)
+			require.Equal(t, id, op.OperationID)
+
+			_, _, _, ok := spec.Spec.FindOperation(id)
+			require.Equal(t, false, ok)
+		})
+	}
+
+	validator, err := openapi.NewValidator(&spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+}
+
+func TestOpenAPI_FindOperation(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(linkSpec), &spec))
+
+	path, method, op, ok := spec.Spec.FindOperation("getUserAddress")
+	require.Equal(t, true, ok)
+	require.Equal(t, "/users/{userid}/address", path)
+	require.Equal(t, "get", method)
+	require.Equal(t, "getUserAddress", op.OperationID)
+	require.Equal(t, true, spec.Spec.IsOperationIDUnique("getUserAddress"))
+
+	_, _, op, ok = spec.Spec.FindOperation("getUserPhone")
+	require.Equal(t, false, ok)
+	require.Nil(t, op)
+
+	t.Run("duplicate", func(t *testing.T) {
+		doc := spec.Spec.Clone()
+		item, err := doc.Paths.Spec.Paths["/users/{id}"].GetSpec(doc.Components)
+		require.NoError(t, err)
+		item.Spec.Get.Spec.OperationID = "getUserAddress"
+		item.Spec.Post = openapi.NewOperationBuilder().OperationID("getUserAddress").Build()
+
+		path, method, _, ok := doc.FindOperation("getUserAddress")
+		require.Equal(t, true, ok)
+		require.Equal(t, "/users/{id}", path)
+		require.Equal(t, "get", method)
+		require.Equal(t, false, doc.IsOperationIDUnique("getUserAddress"))
+	})
+
+	t.Run("changed after the first call", func(t *testing.T) {
+		doc := spec.Spec.Clone()
+		_, _, _, ok := doc.FindOperation("getUserAddress")
+		require.Equal(t, true, ok)
+
+		item, err := doc.Paths.Spec.Paths["/users/{userid}/address"].GetSpec(doc.Components)
+		require.NoError(t, err)
+		item.Spec.Get.Spec.OperationID = "getAddress"
+
+		_, _, _, ok = doc.FindOperation("getUserAddress")
+		require.Equal(t, false, ok)
+		path, _, _, ok := doc.FindOperation("getAddress")
+		require.Equal(t, true, ok)
+		require.Equal(t, "/users/{userid}/address", path)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, _, ok := spec.Spec.FindOperation("getUserAddress")
+				require.Equal(t, true, ok)
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -1,5 +1,13 @@
 package openapi
 
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
 // MediaType provides schema and examples for the media type identified by its key.
 //
 // https://spec.openapis.org/oas/v3.1.1#media-type-object
@@ -56,14 +64,7 @@ func (o *MediaType) validateSpec(location string, validator *Validator) []*valid
 			errs = append(errs, v.validateSpec(joinLoc(location, "encoding", k), validator)...)
 		}
 	}
-	if o.Example != nil && len(o.Examples) > 0 {
-		errs = append(errs, newValidationError(joinLoc(location, "example&examples"), ErrMutuallyExclusive))
-	}
-	if len(o.Examples) > 0 {
-		for k, v := range o.Examples {
-			errs = append(errs, v.validateSpec(joinLoc(location, "examples", k), validator)...)
-		}
-	}
+	errs = append(errs, validateExamples(location, o.Example, o.Examples, validator)...)
 
 	if validator.opts.doNotValidateExamples {
 		return errs
@@ -79,7 +80,7 @@ func (o *MediaType) validateSpec(location string, validator *Validator) []*valid
 	}
 	if len(o.Examples) > 0 {
 		for k, v := range o.Examples {
-			example, err := v.GetSpec(validator.spec.Spec.Components)
+			example, err := v.GetSpecCached(validator.cache)
 			if err != nil {
 				// do not add the error, because it is already validated earlier
 				continue
@@ -95,6 +96,218 @@ func (o *MediaType) validateSpec(location string, validator *Validator) []*valid
 	return errs
 }
 
+// validateContent validates the content map of a request body, a response, a parameter or a header.
+// The keys must be the media types or the media type ranges, like `text/*`,
+// the ranges overlapping with the other keys are reported as the warnings.
+func validateContent(location string, content map[string]*Extendable[MediaType], validator *Validator) []*validationError {
+	var errs []*validationError
+	mediaTypes := make(map[string]string, len(content))
+	for k, v := range content {
+		mediaType, err := parseMediaTypeRange(k)
+		if err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, k), err))
+		} else {
+			mediaTypes[k] = mediaType
+		}
+		errs = append(errs, v.validateSpec(joinLoc(location, k), validator)...)
+		errs = append(errs, v.Spec.validateEncoding(joinLoc(location, k, "encoding"), mediaType, validator)...)
+	}
+
+	keys := make([]string, 0, len(mediaTypes))
+	for k := range mediaTypes {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		for _, other := range keys {
+			if k != other && mediaTypeRangeMatches(mediaTypes[k], mediaTypes[other]) && (mediaTypes[k] != mediaTypes[other] || k < other) {
+				errs = append(errs, newValidationWarning(joinLoc(location, k), "'%s' overlaps with '%s'", k, other))
+			}
+		}
+	}
+	return errs
+}
+
+// validateEncoding checks that the encoding keys are the properties of the schema, the content types are valid
+// and the serialization fields are used with the form media types only.
+// The media type is empty if the key of the content is invalid.
+func (o *MediaType) validateEncoding(location string, mediaType string, validator *Validator) []*validationError {
+	if len(o.Encoding) == 0 {
+		return nil
+	}
+	var errs []*validationError
+	var properties map[string]bool
+	if o.Schema != nil {
+		if schema, err := o.Schema.GetSpecCached(validator.cache); err == nil {
+			properties = schemaPropertyNames(schema, validator.spec.Spec.Components)
+		}
+	}
+	form := mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+	for k, v := range o.Encoding {
+		if properties != nil && !properties[k] {
+			errs = append(errs, newValidationError(joinLoc(location, k), "'%s' is not a property of the schema", k))
+		}
+		if v == nil || v.Spec == nil {
+			continue
+		}
+		if v.Spec.ContentType != "" {
+			for _, contentType := range strings.Split(v.Spec.ContentType, ",") {
+				if _, err := parseMediaTypeRange(strings.TrimSpace(contentType)); err != nil {
+					errs = append(errs, newValidationError(joinLoc(location, k, "contentType"), err))
+				}
+			}
+		}
+		if mediaType != "" && !form && (v.Spec.Style != "" || v.Spec.Explode || v.Spec.AllowReserved) {
+			errs = append(errs, newValidationWarning(joinLoc(location, k), "style, explode and allowReserved are ignored for '%s'", mediaType))
+		}
+	}
+	return errs
+}
+
+// schemaPropertyNames returns the names of the properties of the schema including the properties of the allOf schemas,
+// or nil if the properties cannot be determined, e.g. they are defined dynamically by anyOf or additionalProperties.
+func schemaPropertyNames(schema *Schema, components *Extendable[Components]) map[string]bool {
+	names := make(map[string]bool)
+	if !collectPropertyNames(schema, components, names, make(map[*Schema]bool)) {
+		return nil
+	}
+	return names
+}
+
+func collectPropertyNames(schema *Schema, components *Extendable[Components], names map[string]bool, visited map[*Schema]bool) bool {
+	if visited[schema] {
+		return true
+	}
+	visited[schema] = true
+	if len(schema.AnyOf) > 0 || len(schema.OneOf) > 0 || schema.AdditionalProperties != nil || len(schema.PatternProperties) > 0 {
+		return false
+	}
+	for k := range schema.Properties {
+		names[k] = true
+	}
+	for _, ref := range schema.AllOf {
+		s, err := ref.GetSpec(components)
+		if err != nil || !collectPropertyNames(s, components, names, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMediaTypeRange returns the lower-cased media type without parameters, e.g. `application/json` or `text/*`.
+func parseMediaTypeRange(v string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid media type '%s': %w", v, err)
+	}
+	typ, subtype, found := strings.Cut(mediaType, "/")
+	if !found || typ == "" || subtype == "" || typ == "*" && subtype != "*" {
+		return "", fmt.Errorf("invalid media type '%s': type/subtype expected", v)
+	}
+	return mediaType, nil
+}
+
+// mediaTypeRangeMatches returns true if the given media type range, e.g. `application/*`, matches the media type.
+func mediaTypeRangeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == mediaType || mediaRange == "*/*" {
+		return true
+	}
+	typ, subtype, _ := strings.Cut(mediaRange, "/")
+	return subtype == "*" && strings.HasPrefix(mediaType, typ+"/")
+}
+
+// acceptRange is a media type range of the Accept header with its quality value.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept parses the Accept header, the invalid media ranges are skipped.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		if _, err := parseMediaTypeRange(mediaType); err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, found := params["q"]; found {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// mediaTypeSpecificity returns 2 for a concrete media type, 1 for `type/*` and 0 for `*/*`.
+func mediaTypeSpecificity(mediaType string) int {
+	switch {
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// selectContent returns the item of the content map, which matches the given Accept header best.
+func selectContent(content map[string]*Extendable[MediaType], accept string) (string, *MediaType, bool) {
+	ranges := parseAccept(accept)
+	if strings.TrimSpace(accept) == "" {
+		ranges = []acceptRange{{mediaType: "*/*", quality: 1}}
+	}
+
+	type candidate struct {
+		key         string
+		quality     float64
+		specificity int
+		keySpecific int
+	}
+	var best *candidate
+	for key, value := range content {
+		if value == nil {
+			continue
+		}
+		mediaType, err := parseMediaTypeRange(key)
+		if err != nil {
+			continue
+		}
+		// the most specific matching range of the Accept header defines the quality of the media type
+		c := candidate{key: key, specificity: -1, keySpecific: mediaTypeSpecificity(mediaType)}
+		for _, r := range ranges {
+			if !mediaTypeRangeMatches(r.mediaType, mediaType) && !mediaTypeRangeMatches(mediaType, r.mediaType) {
+				continue
+			}
+			if s := mediaTypeSpecificity(r.mediaType); s > c.specificity {
+				c.specificity, c.quality = s, r.quality
+			}
+		}
+		if c.specificity < 0 || c.quality == 0 {
+			continue
+		}
+		if best == nil ||
+			c.quality > best.quality ||
+			c.quality == best.quality && (c.specificity > best.specificity ||
+				c.specificity == best.specificity && (c.keySpecific > best.keySpecific ||
+					c.keySpecific == best.keySpecific && c.key < best.key)) {
+			best = &c
+		}
+	}
+	if best == nil {
+		return "", nil, false
+	}
+	return best.key, content[best.key].Spec, true
+}
+
 type MediaTypeBuilder struct {
 	spec *Extendable[MediaType]
 }
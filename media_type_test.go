@@ -0,0 +1,98 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestMediaType_ContentKeys(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		keys     []string
+		errs     []string
+		warnings []string
+	}{
+		{name: "valid", keys: []string{"application/json", "text/plain; charset=utf-8", "image/*"}},
+		{
+			name: "bad key",
+			keys: []string{"application/json", "aplication json"},
+			errs: []string{"/paths/~1pets/get/responses/200/content/aplication json: invalid media type 'aplication json'"},
+		},
+		{
+			name: "no subtype",
+			keys: []string{"json"},
+			errs: []string{"/paths/~1pets/get/responses/200/content/json: invalid media type 'json': type/subtype expected"},
+		},
+		{
+			name: "wildcard type only",
+			keys: []string{"*/json"},
+			errs: []string{"/paths/~1pets/get/responses/200/content/*~1json: invalid media type '*/json': type/subtype expected"},
+		},
+		{
+			name:     "overlapping range",
+			keys:     []string{"application/json", "application/*"},
+			warnings: []string{"/paths/~1pets/get/responses/200/content/application~1*: 'application/*' overlaps with 'application/json'"},
+		},
+		{
+			name: "overlapping all",
+			keys: []string{"text/plain", "*/*"},
+			warnings: []string{
+				"/paths/~1pets/get/responses/200/content/*~1*: '*/*' overlaps with 'text/plain'",
+			},
+		},
+		{
+			name:     "same type with parameters",
+			keys:     []string{"text/plain", "text/plain; charset=utf-8"},
+			warnings: []string{"/paths/~1pets/get/responses/200/content/text~1plain: 'text/plain' overlaps with 'text/plain; charset=utf-8'"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			response := openapi.NewResponseBuilder().Description("test")
+			for _, k := range tt.keys {
+				response.AddContent(k, openapi.NewMediaTypeBuilder().Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build())
+			}
+			operation := openapi.NewOperationBuilder().Build()
+			operation.Spec.Responses = openapi.NewResponsesBuilder().AddResponse("200", response.Build()).Build().Spec
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddPath("/pets", openapi.NewPathItemBuilder().Get(operation).Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+			require.Len(t, warnings, len(tt.warnings))
+			for i, w := range tt.warnings {
+				require.ErrorContains(t, warnings[i], w)
+			}
+		})
+	}
+}
+
+func TestMediaType_ExampleAndExamples(t *testing.T) {
+	mediaType := openapi.NewMediaTypeBuilder().
+		Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+		Example("Fluffy").
+		AddExample("cat", openapi.NewExampleBuilder().Value("Tom").Build()).
+		Build()
+	operation := openapi.NewOperationBuilder().Build()
+	operation.Spec.Responses = openapi.NewResponsesBuilder().
+		AddResponse("200", openapi.NewResponseBuilder().Description("test").AddContent("text/plain", mediaType).Build()).
+		Build().Spec
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().Get(operation).Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "/paths/~1pets/get/responses/200/content/text~1plain/example&examples: mutually exclusive")
+}
@@ -0,0 +1,290 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// ErrPathExists is returned by Merge if the same path or webhook is defined in several documents.
+var ErrPathExists = errors.New("path already exists")
+
+// ErrUnsupportedMergeStrategy is returned by Extendable.Merge for an unknown MergeStrategy.
+var ErrUnsupportedMergeStrategy = errors.New("unsupported merge strategy")
+
+// componentKinds is the list of all kinds of the components.
+var componentKinds = []ComponentKind{
+	ComponentSchemas,
+	ComponentResponses,
+	ComponentParameters,
+	ComponentExamples,
+	ComponentRequestBodies,
+	ComponentHeaders,
+	ComponentSecuritySchemes,
+	ComponentLinks,
+	ComponentCallbacks,
+	ComponentPaths,
+}
+
+type mergeOptions struct {
+	renameConflicts bool
+}
+
+// MergeOption is a type for the options of MergeWithOptions function.
+type MergeOption func(*mergeOptions)
+
+// RenameConflictingComponents is an option to rename the conflicting components instead of failing.
+// A suffix is added to the name of the component, e.g. `Error_1`, and all the references to it are updated.
+func RenameConflictingComponents() MergeOption {
+	return func(o *mergeOptions) {
+		o.renameConflicts = true
+	}
+}
+
+// Merge combines the given documents into a single one, see MergeWithOptions.
+func Merge(specs ...*OpenAPI) (*OpenAPI, error) {
+	return MergeWithOptions(specs)
+}
+
+// MergeWithOptions combines the given documents into a single one.
+//
+// The paths and the webhooks are united, the same path in several documents is an error.
+// The components are merged by kind and name, the identical components are added only once,
+// the conflicting ones, which have the same name but different definitions, cause an error,
+// unless RenameConflictingComponents option is used.
+// The tags (by name), the servers (by url) and the security requirements are concatenated without duplicates.
+// The version, the info and the rest of the fields are taken from the first document, which has them.
+//
+// The given documents are not modified.
+func MergeWithOptions(specs []*OpenAPI, opts ...MergeOption) (*OpenAPI, error) {
+	var options mergeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	merged := &OpenAPI{}
+	for i, spec := range specs {
+		if spec == nil {
+			continue
+		}
+		// copy the document, because the components can be renamed
+		spec = spec.Clone()
+		if err := mergeComponents(merged, spec, &options); err != nil {
+			return nil, fmt.Errorf("spec %d: %w", i, err)
+		}
+		if err := mergeDocument(merged, spec); err != nil {
+			return nil, fmt.Errorf("spec %d: %w", i, err)
+		}
+	}
+	return merged, nil
+}
+
+func mergeComponents(dst, src *OpenAPI, options *mergeOptions) error {
+	if src.Components == nil {
+		return nil
+	}
+	if dst.Components == nil {
+		dst.Components = NewComponents()
+	}
+	if err := renameConflicts(dst, src, options); err != nil {
+		return err
+	}
+	for _, kind := range componentKinds {
+		dm, _ := dst.Components.Spec.componentsMap(kind)
+		sm, _ := src.Components.Spec.componentsMap(kind)
+		if sm.Len() == 0 {
+			continue
+		}
+		if dm.IsNil() {
+			dm.Set(reflect.MakeMap(dm.Type()))
+		}
+		iter := sm.MapRange()
+		for iter.Next() {
+			if !dm.MapIndex(iter.Key()).IsValid() {
+				dm.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+	}
+	for k, v := range src.Components.Extensions {
+		if _, found := dst.Components.Extensions[k]; !found {
+			dst.Components.AddExt(k, v)
+		}
+	}
+	return nil
+}
+
+// renameConflicts renames the components of the source document, which conflict with the components
+// of the destination one, and updates the references to them.
+//
+// The renaming changes the referencing components, so the identical components can become conflicting,
+// e.g. a schema referencing a renamed one; the components are compared again until no conflicts are left,
+// so the conflicts are found regardless of the order of the kinds and the names.
+func renameConflicts(dst, src *OpenAPI, options *mergeOptions) error {
+	for renamed := true; renamed; {
+		renamed = false
+		for _, kind := range componentKinds {
+			dm, _ := dst.Components.Spec.componentsMap(kind)
+			sm, _ := src.Components.Spec.componentsMap(kind)
+			if sm.Len() == 0 || dm.Len() == 0 {
+				continue
+			}
+
+			// sort the names to get the stable suffixes
+			names := make([]string, 0, sm.Len())
+			for _, k := range sm.MapKeys() {
+				names = append(names, k.String())
+			}
+			slices.Sort(names)
+
+			for _, name := range names {
+				existing := dm.MapIndex(reflect.ValueOf(name))
+				if !existing.IsValid() || reflect.DeepEqual(existing.Interface(), sm.MapIndex(reflect.ValueOf(name)).Interface()) {
+					continue
+				}
+				if !options.renameConflicts {
+					return fmt.Errorf("%s: %w", kind.Ref(name), ErrComponentExists)
+				}
+				newName := name
+				for i := 1; dm.MapIndex(reflect.ValueOf(newName)).IsValid() || sm.MapIndex(reflect.ValueOf(newName)).IsValid(); i++ {
+					newName = name + "_" + strconv.Itoa(i)
+				}
+				if err := src.RenameComponent(kind, name, newName); err != nil {
+					return err
+				}
+				renamed = true
+			}
+		}
+	}
+	return nil
+}
+
+func mergeDocument(dst, src *OpenAPI) error {
+	if src.Paths != nil {
+		if dst.Paths == nil {
+			dst.Paths = NewPaths()
+		}
+		for path, item := range src.Paths.Spec.Paths {
+			if _, found := dst.Paths.Spec.Paths[path]; found {
+				return fmt.Errorf("%s: %w", joinLoc("", "paths", path), ErrPathExists)
+			}
+			dst.Paths.Spec.Add(path, item)
+		}
+		for k, v := range src.Paths.Extensions {
+			if _, found := dst.Paths.Extensions[k]; !found {
+				dst.Paths.AddExt(k, v)
+			}
+		}
+	}
+	for name, webhook := range src.WebHooks {
+		if dst.WebHooks == nil {
+			dst.WebHooks = NewWebhooks()
+		}
+		if _, found := dst.WebHooks[name]; found {
+			return fmt.Errorf("%s: %w", joinLoc("", "webhooks", name), ErrPathExists)
+		}
+		dst.WebHooks[name] = webhook
+	}
+
+	for _, tag := range src.Tags {
+		if !slices.ContainsFunc(dst.Tags, func(t *Extendable[Tag]) bool { return t.Spec.Name == tag.Spec.Name }) {
+			dst.Tags = append(dst.Tags, tag)
+		}
+	}
+	for _, server := range src.Servers {
+		if !slices.ContainsFunc(dst.Servers, func(s *Extendable[Server]) bool { return s.Spec.URL == server.Spec.URL }) {
+			dst.Servers = append(dst.Servers, server)
+		}
+	}
+	for _, security := range src.Security {
+		if !slices.ContainsFunc(dst.Security, func(s SecurityRequirement) bool { return reflect.DeepEqual(s, security) }) {
+			dst.Security = append(dst.Security, security)
+		}
+	}
+
+	if dst.OpenAPI == "" {
+		dst.OpenAPI = src.OpenAPI
+	}
+	if dst.Info == nil {
+		dst.Info = src.Info
+	}
+	if dst.JsonSchemaDialect == "" {
+		dst.JsonSchemaDialect = src.JsonSchemaDialect
+	}
+	if dst.ExternalDocs == nil {
+		dst.ExternalDocs = src.ExternalDocs
+	}
+	return nil
+}
+
+// MergeStrategy defines how Extendable.Merge method handles the values, which are set in both objects.
+type MergeStrategy int
+
+const (
+	// MergeOverwrite replaces the values of the current object with the values of the other one.
+	MergeOverwrite MergeStrategy = iota
+	// MergeKeep keeps the values of the current object and adds only the missing ones.
+	MergeKeep
+)
+
+// Merge merges the extensions and the fields of the spec of the other object into the current one.
+//
+// The extensions are merged by name. The fields of the spec are merged if they are not zero in the other object,
+// the maps, e.g. the properties of a schema, are merged by key, the other fields, including the slices,
+// are replaced as a whole. The given strategy is applied if a value is set in both objects.
+// The values of the other object are copied, so it is not modified by the further changes of the current object.
+//
+// Example of applying the defaults to an operation:
+//
+//	err := op.Merge(defaults, openapi.MergeKeep)
+func (o *Extendable[T]) Merge(other *Extendable[T], strategy MergeStrategy) error {
+	if strategy != MergeOverwrite && strategy != MergeKeep {
+		return fmt.Errorf("%w %d", ErrUnsupportedMergeStrategy, strategy)
+	}
+	if other == nil {
+		return nil
+	}
+	for k, v := range other.Extensions {
+		if _, found := o.Extensions[k]; !found || strategy == MergeOverwrite {
+			o.AddExt(k, v)
+		}
+	}
+	if other.Spec == nil {
+		return nil
+	}
+	src := DeepCopy(other.Spec)
+	if o.Spec == nil {
+		o.Spec = src
+		return nil
+	}
+	dst := reflect.ValueOf(o.Spec).Elem()
+	if dst.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: unsupported spec type %T", ErrInvalidValue, o.Spec)
+	}
+	mergeFields(dst, reflect.ValueOf(src).Elem(), strategy)
+	return nil
+}
+
+// mergeFields sets the non-zero exported fields of src to dst according to the strategy, the maps are merged by key.
+func mergeFields(dst, src reflect.Value, strategy MergeStrategy) {
+	for i := range dst.NumField() {
+		if !dst.Type().Field(i).IsExported() {
+			continue
+		}
+		df, sf := dst.Field(i), src.Field(i)
+		switch {
+		case sf.IsZero():
+		case df.IsZero():
+			df.Set(sf)
+		case df.Kind() == reflect.Map:
+			iter := sf.MapRange()
+			for iter.Next() {
+				if strategy == MergeOverwrite || !df.MapIndex(iter.Key()).IsValid() {
+					df.SetMapIndex(iter.Key(), iter.Value())
+				}
+			}
+		case strategy == MergeOverwrite:
+			df.Set(sf)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const mergePetsSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "servers": [{"url": "https://petstore.example.com"}],
+  "tags": [{"name": "pets"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "tags": ["pets"],
+        "responses": {
+          "200": {"description": "pets", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}},
+          "default": {"description": "error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"name": {"type": "string"}}},
+      "Error": {"type": "object", "required": ["code"], "properties": {"code": {"type": "integer"}, "message": {"type": "string"}}}
+    }
+  }
+}`
+
+const mergeStoreSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Store", "version": "1.0.0"},
+  "servers": [{"url": "https://petstore.example.com"}, {"url": "https://store.example.com"}],
+  "tags": [{"name": "pets"}, {"name": "store"}],
+  "paths": {
+    "/orders": {
+      "get": {
+        "tags": ["store"],
+        "responses": {
+          "200": {"description": "orders", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}},
+          "default": {"description": "error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"id": {"type": "integer"}}},
+      "Error": {"type": "object", "required": ["code"], "properties": {"code": {"type": "integer"}, "message": {"type": "string"}}}
+    }
+  }
+}`
+
+func mergeSpec(t *testing.T, data string) *openapi.OpenAPI {
+	t.Helper()
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &spec))
+	return spec.Spec
+}
+
+func TestMerge(t *testing.T) {
+	pets := mergeSpec(t, mergePetsSpec)
+	store := mergeSpec(t, mergeStoreSpec)
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := openapi.Merge(pets, store)
+		require.ErrorContains(t, err, "spec 1: #/components/schemas/Pet: component already exists")
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		merged, err := openapi.MergeWithOptions([]*openapi.OpenAPI{pets, store}, openapi.RenameConflictingComponents())
+		require.NoError(t, err)
+
+		require.Equal(t, "Pets", merged.Info.Spec.Title)
+		require.Len(t, merged.Paths.Spec.Paths, 2)
+		require.Len(t, merged.Servers, 2)
+		require.Len(t, merged.Tags, 2)
+
+		// the shared Error schema is added once
+		require.Len(t, merged.Components.Spec.Schemas, 3)
+		require.NotNil(t, merged.Components.Spec.Schemas["Error"])
+		require.NotNil(t, merged.Components.Spec.Schemas["Pet_1"])
+
+		orders := merged.Paths.Spec.Paths["/orders"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"]
+		require.Equal(t, "#/components/schemas/Pet_1", orders.Spec.Spec.Content["application/json"].Spec.Schema.Spec.Items.Schema.Ref.Ref)
+		listPets := merged.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"]
+		require.Equal(t, "#/components/schemas/Pet", listPets.Spec.Spec.Content["application/json"].Spec.Schema.Spec.Items.Schema.Ref.Ref)
+
+		// the original documents are not modified
+		require.NotNil(t, store.Components.Spec.Schemas["Pet"])
+
+		validator, err := openapi.NewValidator(openapi.NewExtendable(merged))
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("rename referencing components", func(t *testing.T) {
+		// the Animals schemas are identical until the Pet schema is renamed, which is sorted after them
+		const doc = `{
+  "openapi": "3.1.1",
+  "info": {"title": "%s", "version": "1.0.0"},
+  "paths": {
+    "%s": {
+      "get": {
+        "responses": {"200": {"$ref": "#/components/responses/Pets"}}
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "Pets": {"description": "pets", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Animals"}}}}
+    },
+    "schemas": {
+      "Animals": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+      "Pet": {"type": "object", "properties": {"%s": {"type": "string"}}}
+    }
+  }
+}`
+		first := mergeSpec(t, fmt.Sprintf(doc, "First", "/pets", "name"))
+		second := mergeSpec(t, fmt.Sprintf(doc, "Second", "/animals", "kind"))
+		merged, err := openapi.MergeWithOptions([]*openapi.OpenAPI{first, second}, openapi.RenameConflictingComponents())
+		require.NoError(t, err)
+
+		schemas := merged.Components.Spec.Schemas
+		require.Len(t, schemas, 4)
+		require.Equal(t, "#/components/schemas/Pet", schemas["Animals"].Spec.Items.Schema.Ref.Ref)
+		require.Equal(t, "#/components/schemas/Pet_1", schemas["Animals_1"].Spec.Items.Schema.Ref.Ref)
+		require.NotNil(t, schemas["Pet_1"].Spec.Properties["kind"])
+
+		responses := merged.Components.Spec.Responses
+		require.Len(t, responses, 2)
+		require.Equal(t, "#/components/schemas/Animals_1", responses["Pets_1"].Spec.Spec.Content["application/json"].Spec.Schema.Ref.Ref)
+		animals := merged.Paths.Spec.Paths["/animals"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"]
+		require.Equal(t, "#/components/responses/Pets_1", animals.Ref.Ref)
+
+		validator, err := openapi.NewValidator(openapi.NewExtendable(merged))
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("path conflict", func(t *testing.T) {
+		_, err := openapi.Merge(pets, mergeSpec(t, mergePetsSpec))
+		require.ErrorContains(t, err, "spec 1: /paths/~1pets: path already exists")
+	})
+}
+
+func TestExtendable_Merge(t *testing.T) {
+	newDefaults := func() *openapi.Extendable[openapi.Operation] {
+		return openapi.NewOperationBuilder().
+			Description("default description").
+			Tags("pets").
+			Deprecated(true).
+			AddExt("rate-limit", 100).
+			AddExt("owner", "team-a").
+			Build()
+	}
+	newOperation := func() *openapi.Extendable[openapi.Operation] {
+		return openapi.NewOperationBuilder().
+			Summary("List pets").
+			Description("Returns all pets").
+			AddExt("rate-limit", 10).
+			Build()
+	}
+
+	t.Run("overwrite", func(t *testing.T) {
+		op := newOperation()
+		require.NoError(t, op.Merge(newDefaults(), openapi.MergeOverwrite))
+		require.Equal(t, "List pets", op.Spec.Summary)
+		require.Equal(t, "default description", op.Spec.Description)
+		require.Equal(t, []string{"pets"}, op.Spec.Tags)
+		require.Equal(t, true, op.Spec.Deprecated)
+		require.Equal(t, map[string]any{"x-rate-limit": 100, "x-owner": "team-a"}, op.Extensions)
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		op := newOperation()
+		defaults := newDefaults()
+		require.NoError(t, op.Merge(defaults, openapi.MergeKeep))
+		require.Equal(t, "List pets", op.Spec.Summary)
+		require.Equal(t, "Returns all pets", op.Spec.Description)
+		require.Equal(t, []string{"pets"}, op.Spec.Tags)
+		require.Equal(t, map[string]any{"x-rate-limit": 10, "x-owner": "team-a"}, op.Extensions)
+
+		// the values are copied
+		op.Spec.Tags[0] = "animals"
+		require.Equal(t, []string{"pets"}, defaults.Spec.Tags)
+	})
+
+	t.Run("maps are merged by key", func(t *testing.T) {
+		schema := openapi.NewSchemaBuilder().AddProperty("id", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).Build()
+		other := openapi.NewSchemaBuilder().AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build()
+		require.NoError(t, openapi.NewExtendable(schema.Spec).Merge(openapi.NewExtendable(other.Spec), openapi.MergeKeep))
+		require.Len(t, schema.Spec.Properties, 2)
+	})
+
+	t.Run("unsupported strategy", func(t *testing.T) {
+		err := newOperation().Merge(newDefaults(), openapi.MergeStrategy(42))
+		require.ErrorContains(t, err, "unsupported merge strategy 42")
+		require.Equal(t, true, errors.Is(err, openapi.ErrUnsupportedMergeStrategy))
+	})
+}
@@ -0,0 +1,244 @@
+// Package mock serves the mock responses generated from the OpenAPI documents.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+type options struct {
+	preferExamples bool
+	echoPathParams bool
+	exampleOpts    []openapi.ExampleOption
+}
+
+// Option is a type for the options of Handler function.
+type Option func(*options)
+
+// PreferExamples is an option to use the examples declared in the media types, `example` or the first of `examples`
+// sorted by name, instead of the values generated from the schemas.
+func PreferExamples() Option {
+	return func(o *options) {
+		o.preferExamples = true
+	}
+}
+
+// EchoPathParams is an option to set the values of the path parameters to the properties
+// of the same names in the object responses, e.g. `id` property for `/pets/{id}` path.
+// The values are converted according to the schemas of the parameters.
+func EchoPathParams() Option {
+	return func(o *options) {
+		o.echoPathParams = true
+	}
+}
+
+// WithExampleOptions is an option to pass the options to openapi.GenerateExample function.
+func WithExampleOptions(opts ...openapi.ExampleOption) Option {
+	return func(o *options) {
+		o.exampleOpts = append(o.exampleOpts, opts...)
+	}
+}
+
+// Handler returns a http.Handler, which responds to the requests with the mock responses
+// of the operations of the given document.
+//
+// The requests are routed by the path templates, see openapi.Paths.Match method, and by the methods;
+// the status code is 404 for the unknown paths and 405 for the unknown methods.
+// The response of the operation is the first successful one: the lowest explicit `2XX` code, then the `2XX` range
+// with 200 status code and then the default response with 200 status code, 501 is returned if there is none.
+// The content is selected by the Accept header of the request, 406 is returned if no content is acceptable.
+// The body is generated from the schema of the content, see openapi.GenerateExample function,
+// so the responses are the same for the same requests.
+func Handler(doc *openapi.OpenAPI, opts ...Option) http.Handler {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &handler{doc: doc, opts: o}
+}
+
+type handler struct {
+	doc  *openapi.OpenAPI
+	opts *options
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.doc.Paths == nil {
+		http.NotFound(w, r)
+		return
+	}
+	template, pathParams, ok := h.doc.Paths.Spec.MatchTemplate(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	pathItem, err := openapi.ResolvePathItem(h.doc.Paths.Spec.Paths[template], h.doc.Components)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	operations := pathItem.Spec.Operations()
+	op := operations[r.Method]
+	if op == nil {
+		w.Header().Set("Allow", strings.Join(allowedMethods(operations), ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	statusCode, ref := successResponse(op.Spec)
+	if ref == nil {
+		http.Error(w, "no successful response", http.StatusNotImplemented)
+		return
+	}
+	response, err := ref.GetSpec(h.doc.Components)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(response.Spec.Content) == 0 {
+		w.WriteHeader(statusCode)
+		return
+	}
+	mediaType, mt, ok := response.Spec.SelectContent(r.Header.Get("Accept"))
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+		return
+	}
+
+	value, err := h.example(mt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.opts.echoPathParams {
+		value = h.echo(value, pathParams, pathItem.Spec, op.Spec)
+	}
+	body, err := encode(mediaType, value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if strings.Contains(mediaType, "*") {
+		mediaType = "application/json"
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// example returns the declared example of the media type or the generated one.
+func (h *handler) example(mt *openapi.MediaType) (any, error) {
+	if h.opts.preferExamples {
+		if mt.Example != nil {
+			return mt.Example, nil
+		}
+		names := make([]string, 0, len(mt.Examples))
+		for name := range mt.Examples {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			example, err := mt.Examples[name].GetSpec(h.doc.Components)
+			if err != nil {
+				return nil, err
+			}
+			if example.Spec.Value != nil {
+				return example.Spec.Value, nil
+			}
+		}
+	}
+	if mt.Schema == nil {
+		return mt.Example, nil
+	}
+	return openapi.GenerateExample(mt.Schema, h.doc.Components, h.opts.exampleOpts...)
+}
+
+// echo sets the values of the path parameters to the properties of the object value.
+func (h *handler) echo(value any, pathParams map[string]string, pathItem *openapi.PathItem, op *openapi.Operation) any {
+	object, ok := value.(map[string]any)
+	if !ok || len(pathParams) == 0 {
+		return value
+	}
+	// copy the object to keep the schema examples untouched
+	object = copyObject(object)
+	params := make(map[string]*openapi.Parameter)
+	for _, refs := range [][]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{pathItem.Parameters, op.Parameters} {
+		for _, ref := range refs {
+			if p, err := ref.GetSpec(h.doc.Components); err == nil && p.Spec.In == openapi.InPath {
+				params[p.Spec.Name] = p.Spec
+			}
+		}
+	}
+	for name, raw := range pathParams {
+		var v any = raw
+		if p, found := params[name]; found {
+			if converted, err := p.DeserializeValue(raw); err == nil {
+				v = converted
+			}
+		}
+		object[name] = v
+	}
+	return object
+}
+
+func copyObject(object map[string]any) map[string]any {
+	result := make(map[string]any, len(object))
+	for k, v := range object {
+		result[k] = v
+	}
+	return result
+}
+
+// encode renders the value as JSON, except the string values of the non-JSON media types, which are written as is.
+func encode(mediaType string, value any) ([]byte, error) {
+	if s, ok := value.(string); ok && mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") && !strings.Contains(mediaType, "*") {
+		return []byte(s), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling example failed: %w", err)
+	}
+	return data, nil
+}
+
+func allowedMethods(operations map[string]*openapi.Extendable[openapi.Operation]) []string {
+	var methods []string
+	for _, method := range []string{
+		http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+	} {
+		if operations[method] != nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// successResponse returns the lowest explicit 2XX response, the 2XX range or the default response with the status code.
+func successResponse(op *openapi.Operation) (int, *openapi.RefOrSpec[openapi.Extendable[openapi.Response]]) {
+	if op.Responses == nil {
+		return 0, nil
+	}
+	responses := op.Responses.Spec
+	codes := make([]int, 0, len(responses.Response))
+	for key := range responses.Response {
+		if code, err := strconv.Atoi(key); err == nil && code >= 200 && code < 300 {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) > 0 {
+		code := slices.Min(codes)
+		return code, responses.Response[strconv.Itoa(code)]
+	}
+	if v, ok := responses.Response["2XX"]; ok {
+		return http.StatusOK, v
+	}
+	return http.StatusOK, responses.Default
+}
@@ -0,0 +1,137 @@
+package mock_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+	"github.com/sv-tools/openapi/mock"
+)
+
+const testSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+      "get": {
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/Pet"},
+                "example": {"id": 7, "name": "Tom"}
+              },
+              "text/plain": {"schema": {"type": "string", "examples": ["Tom"]}}
+            }
+          },
+          "404": {"description": "not found"}
+        }
+      },
+      "delete": {"responses": {"default": {"description": "deleted"}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["id", "name"],
+        "properties": {"id": {"type": "integer", "minimum": 1}, "name": {"type": "string", "examples": ["Fluffy"]}}
+      }
+    }
+  }
+}`
+
+func TestHandler(t *testing.T) {
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(testSpec), &doc))
+
+	for _, tt := range []struct {
+		name        string
+		opts        []mock.Option
+		method      string
+		path        string
+		accept      string
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "generated",
+			method:      http.MethodGet,
+			path:        "/pets/42",
+			accept:      "application/json",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			body:        `{"id": 1, "name": "Fluffy"}`,
+		},
+		{
+			name:        "declared example",
+			opts:        []mock.Option{mock.PreferExamples()},
+			method:      http.MethodGet,
+			path:        "/pets/42",
+			accept:      "application/json",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			body:        `{"id": 7, "name": "Tom"}`,
+		},
+		{
+			name:        "echo path params",
+			opts:        []mock.Option{mock.EchoPathParams()},
+			method:      http.MethodGet,
+			path:        "/pets/42",
+			accept:      "application/json",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			body:        `{"id": 42, "name": "Fluffy"}`,
+		},
+		{
+			name:        "text",
+			method:      http.MethodGet,
+			path:        "/pets/42",
+			accept:      "text/plain",
+			statusCode:  http.StatusOK,
+			contentType: "text/plain",
+			body:        `Tom`,
+		},
+		{name: "not acceptable", method: http.MethodGet, path: "/pets/42", accept: "image/png", statusCode: http.StatusNotAcceptable},
+		{name: "default response", method: http.MethodDelete, path: "/pets/42", statusCode: http.StatusOK},
+		{name: "method not allowed", method: http.MethodPost, path: "/pets/42", statusCode: http.StatusMethodNotAllowed},
+		{name: "not found", method: http.MethodGet, path: "/users/42", statusCode: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(mock.Handler(doc.Spec, tt.opts...))
+			defer server.Close()
+
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.statusCode, resp.StatusCode)
+			if tt.statusCode == http.StatusMethodNotAllowed {
+				require.Equal(t, "GET, DELETE", resp.Header.Get("Allow"))
+			}
+			if tt.contentType == "" {
+				return
+			}
+			require.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+			data, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			if tt.contentType == "application/json" {
+				require.JSONEq(t, tt.body, string(data))
+			} else {
+				require.Equal(t, tt.body, string(data))
+			}
+		})
+	}
+}
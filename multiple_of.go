@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// decimalRat returns the exact value of the number as it is written in JSON, e.g. `0.1` is 1/10,
+// not the nearest binary fraction, so the decimal multiples are checked without the float drift.
+func decimalRat(v float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	if !ok {
+		// NaN and Inf
+		return new(big.Rat)
+	}
+	return r
+}
+
+// isMultipleOf returns true if v is a multiple of the given positive divisor, e.g. `0.3` is a multiple of `0.1`.
+func isMultipleOf(v, divisor float64) bool {
+	d := decimalRat(divisor)
+	if d.Sign() <= 0 {
+		return false
+	}
+	return new(big.Rat).Quo(decimalRat(v), d).IsInt()
+}
+
+// lcmOf returns the least common multiple of the positive decimals,
+// which is the lcm of the numerators divided by the gcd of the denominators, e.g. lcm(0.4, 0.6) is 1.2.
+func lcmOf(a, b float64) float64 {
+	x, y := decimalRat(a), decimalRat(b)
+	if x.Sign() <= 0 || y.Sign() <= 0 {
+		return 0
+	}
+	gcdNum := new(big.Int).GCD(nil, nil, x.Num(), y.Num())
+	lcmNum := new(big.Int).Mul(x.Num(), y.Num())
+	lcmNum.Quo(lcmNum, gcdNum)
+	gcdDenom := new(big.Int).GCD(nil, nil, x.Denom(), y.Denom())
+	v, _ := new(big.Rat).SetFrac(lcmNum, gcdDenom).Float64()
+	return v
+}
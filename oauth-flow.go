@@ -1,5 +1,7 @@
 package openapi
 
+import "encoding/json"
+
 // OAuthFlow configuration details for a supported OAuth Flow
 //
 // https://spec.openapis.org/oas/v3.1.1#oauth-flow-object
@@ -23,7 +25,7 @@ type OAuthFlow struct {
 	// The map MAY be empty.
 	//
 	// Applies To: oauth2
-	Scopes map[string]string `json:"scopes,omitempty"`
+	Scopes map[string]string `json:"scopes"`
 	// REQUIRED.
 	// The authorization URL to be used for this flow.
 	// This MUST be in the form of a URL.
@@ -46,9 +48,33 @@ type OAuthFlow struct {
 	RefreshURL string `json:"refreshUrl,omitempty"`
 }
 
-func (o *OAuthFlow) validateSpec(path string, validator *Validator) []*validationError {
-	// all the validations are done in the parent object
-	return nil
+// MarshalJSON implements json.Marshaler interface.
+// The nil scopes are rendered as an empty object, because the field is required, but the map may be empty.
+func (o *OAuthFlow) MarshalJSON() ([]byte, error) {
+	type alias OAuthFlow
+	v := alias(*o)
+	if v.Scopes == nil {
+		v.Scopes = map[string]string{}
+	}
+	return json.Marshal(&v)
+}
+
+func (o *OAuthFlow) validateSpec(location string, validator *Validator) []*validationError {
+	// the required URLs depend on the type of the flow, so they are checked in the parent object
+	var errs []*validationError
+	if o.Scopes == nil {
+		errs = append(errs, newValidationError(joinLoc(location, "scopes"), ErrRequired))
+	}
+	if err := validator.checkURL(o.AuthorizationURL); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "authorizationUrl"), err))
+	}
+	if err := validator.checkURL(o.TokenURL); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "tokenUrl"), err))
+	}
+	if err := validator.checkURL(o.RefreshURL); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "refreshUrl"), err))
+	}
+	return errs
 }
 
 type OAuthFlowBuilder struct {
@@ -0,0 +1,82 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestOAuthFlow_Marshaling(t *testing.T) {
+	for _, data := range []string{
+		`{"tokenUrl": "https://example.com/token", "refreshUrl": "https://example.com/refresh", "scopes": {}}`,
+		`{"authorizationUrl": "https://example.com/auth", "scopes": {"read:pets": "read your pets"}, "x-foo": "bar"}`,
+	} {
+		t.Run(data, func(t *testing.T) {
+			var flow openapi.Extendable[openapi.OAuthFlow]
+			require.NoError(t, json.Unmarshal([]byte(data), &flow))
+			out, err := json.Marshal(&flow)
+			require.NoError(t, err)
+			require.JSONEq(t, data, string(out))
+		})
+	}
+
+	t.Run("builder", func(t *testing.T) {
+		flow := openapi.NewOAuthFlowBuilder().
+			TokenURL("https://example.com/token").
+			RefreshURL("https://example.com/refresh").
+			Scopes(map[string]string{}).
+			Build()
+		out, err := json.Marshal(flow)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"tokenUrl": "https://example.com/token", "refreshUrl": "https://example.com/refresh", "scopes": {}}`, string(out))
+	})
+	t.Run("nil scopes", func(t *testing.T) {
+		out, err := json.Marshal(openapi.NewOAuthFlowBuilder().TokenURL("https://example.com/token").Build())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"tokenUrl": "https://example.com/token", "scopes": {}}`, string(out))
+	})
+}
+
+func TestOAuthFlows_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		flow *openapi.Extendable[openapi.OAuthFlow]
+		err  string
+	}{
+		{
+			name: "valid",
+			flow: openapi.NewOAuthFlowBuilder().TokenURL("https://example.com/token").RefreshURL("https://example.com/refresh").Scopes(map[string]string{}).Build(),
+		},
+		{
+			name: "missing scopes",
+			flow: openapi.NewOAuthFlowBuilder().TokenURL("https://example.com/token").Build(),
+			err:  "flows/password/scopes: required",
+		},
+		{
+			name: "invalid refresh url",
+			flow: openapi.NewOAuthFlowBuilder().TokenURL("https://example.com/token").RefreshURL("http://[::1").Scopes(map[string]string{}).Build(),
+			err:  "flows/password/refreshUrl: invalid URL",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("oauth", openapi.NewSecuritySchemeBuilder().
+					Type(openapi.TypeOAuth2).
+					Flows(openapi.NewOAuthFlowsBuilder().Password(tt.flow).Build()).
+					Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			err = validator.ValidateSpec()
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "/components/securitySchemes/oauth/"+tt.err)
+			}
+		})
+	}
+}
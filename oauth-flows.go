@@ -65,6 +65,19 @@ func (o *OAuthFlows) validateSpec(location string, validator *Validator) []*vali
 	return errs
 }
 
+// hasScope returns true if the scope is declared in any of the flows.
+func (o *OAuthFlows) hasScope(name string) bool {
+	for _, flow := range []*Extendable[OAuthFlow]{o.Implicit, o.Password, o.ClientCredentials, o.AuthorizationCode} {
+		if flow == nil {
+			continue
+		}
+		if _, found := flow.Spec.Scopes[name]; found {
+			return true
+		}
+	}
+	return false
+}
+
 type OAuthFlowsBuilder struct {
 	spec *Extendable[OAuthFlows]
 }
@@ -2,6 +2,9 @@ package openapi
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -59,11 +62,17 @@ type OpenAPI struct {
 	Servers []*Extendable[Server] `json:"servers,omitempty"`
 }
 
+type operationEntry struct {
+	path      string
+	method    string
+	operation *Operation
+}
+
 func checkUnusedComponent[T any](name string, m map[string]T, validator *Validator) []*validationError {
 	var errs []*validationError
 	for k := range m {
 		id := joinLoc("#", "components", name, k)
-		if !validator.visited[id] {
+		if !validator.isVisited(id) {
 			errs = append(errs, newValidationError(id, ErrUnused))
 		}
 	}
@@ -86,33 +95,79 @@ func NewUnsupportedVersionError(version string) error {
 	return UnsupportedVersionError(version)
 }
 
+// versionRe matches the semantic version `major.minor.patch` with an optional pre-release suffix, e.g. `3.1.0-rc1`.
+var versionRe = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-[0-9A-Za-z.-]+)?$`)
+
+// parseVersion returns the major and minor parts of the given OpenAPI version.
+func parseVersion(version string) (major, minor int, ok bool) {
+	m := versionRe.FindStringSubmatch(version)
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+func isVersion31(version string) bool {
+	major, minor, ok := parseVersion(version)
+	return ok && major == 3 && minor == 1
+}
+
+// checkVersion validates the version of the document.
+// The documents of OpenAPI 3.1.x are supported only, the older versions, like 2.0 or 3.0.x,
+// are rejected, because they cannot be validated with the rules of OpenAPI 3.1.
+func checkVersion(version, exact string) error {
+	if !isVersion31(version) {
+		return NewUnsupportedVersionError(version)
+	}
+	if exact != "" && version != exact {
+		return fmt.Errorf("%w: %q is required", NewUnsupportedVersionError(version), exact)
+	}
+	return nil
+}
+
 func (o *OpenAPI) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	if o.OpenAPI == "" {
 		errs = append(errs, newValidationError(joinLoc(location, "openapi"), ErrRequired))
-	} else if !strings.HasPrefix(o.OpenAPI, "3.1.") {
-		errs = append(errs, newValidationError(joinLoc(location, "openapi"), NewUnsupportedVersionError(o.OpenAPI)))
+	} else if err := checkVersion(o.OpenAPI, validator.opts.exactVersion); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "openapi"), err))
 	}
 	if o.Info == nil {
 		errs = append(errs, newValidationError(joinLoc(location, "info"), ErrRequired))
 	} else {
 		errs = append(errs, o.Info.validateSpec(joinLoc(location, "info"), validator)...)
 	}
+	if validator.checkFailFast(errs) {
+		return errs
+	}
 
 	// validate tags first to memorize them for later checking
 	if o.Tags != nil {
+		declared := make(map[string]int, len(o.Tags))
 		for i, tag := range o.Tags {
-			errs = append(errs, tag.validateSpec(joinLoc(location, "tag", i), validator)...)
+			errs = append(errs, tag.validateSpec(joinLoc(location, "tags", i), validator)...)
+			if tag.Spec.Name == "" {
+				continue
+			}
+			if j, found := declared[tag.Spec.Name]; found {
+				errs = append(errs, newValidationError(joinLoc(location, "tags", i, "name"), "'%s' is not unique, also used at %s", tag.Spec.Name, joinLoc(location, "tags", j, "name")))
+				continue
+			}
+			declared[tag.Spec.Name] = i
 		}
 	}
 
-	if err := checkURL(o.JsonSchemaDialect); err != nil {
-		errs = append(errs, newValidationError(joinLoc(location, "jsonSchemaDialect"), err))
+	if o.JsonSchemaDialect != "" && o.OpenAPI != "" && !isVersion31(o.OpenAPI) {
+		errs = append(errs, newValidationError(joinLoc(location, "jsonSchemaDialect"), fmt.Errorf("%w: the field requires OpenAPI 3.1", NewUnsupportedVersionError(o.OpenAPI))))
+	} else if u, err := url.Parse(o.JsonSchemaDialect); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "jsonSchemaDialect"), fmt.Errorf("invalid URL: %w", err)))
+	} else if o.JsonSchemaDialect != "" && !u.IsAbs() {
+		errs = append(errs, newValidationError(joinLoc(location, "jsonSchemaDialect"), "must be an absolute URI, but got '%s'", o.JsonSchemaDialect))
 	}
 	if o.Servers != nil {
-		for i, server := range o.Servers {
-			errs = append(errs, server.validateSpec(joinLoc(location, "servers", i), validator)...)
-		}
+		errs = append(errs, validateServers(joinLoc(location, "servers"), o.Servers, validator)...)
 	}
 	if o.Paths != nil {
 		errs = append(errs, o.Paths.validateSpec(joinLoc(location, "paths"), validator)...)
@@ -125,6 +180,7 @@ func (o *OpenAPI) validateSpec(location string, validator *Validator) []*validat
 	if o.Components != nil {
 		errs = append(errs, o.Components.validateSpec(joinLoc(location, "components"), validator)...)
 	}
+	errs = append(errs, validateDynamicRefs(o)...)
 	if o.Security != nil {
 		for i, security := range o.Security {
 			errs = append(errs, security.validateSpec(joinLoc(location, "security", i), validator)...)
@@ -139,7 +195,7 @@ func (o *OpenAPI) validateSpec(location string, validator *Validator) []*validat
 
 	// check for unused
 	for i, t := range o.Tags {
-		if !validator.visited[joinLoc("tags", t.Spec.Name, "used")] {
+		if !validator.isVisited(joinLoc("tags", t.Spec.Name, "used")) {
 			errs = append(errs, newValidationError(joinLoc(location, "tags", i), fmt.Errorf("'%s': %w", t.Spec.Name, ErrUnused)))
 		}
 	}
@@ -156,14 +212,33 @@ func (o *OpenAPI) validateSpec(location string, validator *Validator) []*validat
 		errs = append(errs, checkUnusedComponent("paths", o.Components.Spec.Paths, validator)...)
 	}
 
-	for k, v := range validator.linkToOperationID {
-		if !validator.visited[joinLoc("operations", v)] {
-			errs = append(errs, newValidationError(k, "'%s' not found", v))
-		}
-	}
+	errs = append(errs, validator.checkOperationIDs()...)
 	return errs
 }
 
+// SchemaDialect returns the default value for the $schema keyword within the Schema Objects of the document,
+// which is the jsonSchemaDialect field or the Draft 2020-12 if the field is not set.
+func (o *OpenAPI) SchemaDialect() string {
+	if o.JsonSchemaDialect == "" {
+		return Draft202012
+	}
+	return o.JsonSchemaDialect
+}
+
+// RenameComponent changes the name of the component of the given kind and updates all the references to it in the document,
+// including the names of the security schemes in the security requirements and the schema names in the discriminator mappings.
+// It fails if the component does not exist, the new name is already in use or does not match the allowed pattern.
+func (o *OpenAPI) RenameComponent(kind ComponentKind, oldName, newName string) error {
+	if o.Components == nil {
+		return fmt.Errorf("%s: %w", kind.Ref(oldName), ErrComponentNotFound)
+	}
+	if err := o.Components.Spec.rename(kind, oldName, newName); err != nil {
+		return err
+	}
+	rewriteRefs(o, kind, oldName, newName)
+	return nil
+}
+
 type OpenAPIBuilder struct {
 	spec *Extendable[OpenAPI]
 }
@@ -277,3 +352,105 @@ func (b *OpenAPIBuilder) AddServers(servers ...*Extendable[Server]) *OpenAPIBuil
 	b.spec.Spec.Servers = append(b.spec.Spec.Servers, servers...)
 	return b
 }
+
+// FindOperation returns the path, the method and the operation with the given operationId.
+// The operations are searched in all path items, including the referenced ones, but not in the webhooks and callbacks.
+//
+// The index of the operations is built on each call, so the method is safe to call concurrently
+// as long as the document is not changed; use OperationIndex to cache the index for the repeated lookups.
+// If the same operationId is used by several operations, the first one is returned, sorted by path and method,
+// see IsOperationIDUnique.
+func (o *OpenAPI) FindOperation(id string) (path string, method string, op *Operation, ok bool) {
+	entries := o.operationIndex(false)[id]
+	if len(entries) == 0 {
+		return "", "", nil, false
+	}
+	return entries[0].path, entries[0].method, entries[0].operation, true
+}
+
+// IsOperationIDUnique returns false if the given operationId is used by several operations in the paths.
+func (o *OpenAPI) IsOperationIDUnique(id string) bool {
+	return len(o.operationIndex(false)[id]) < 2
+}
+
+// operationIndex returns the operations by operationId, the operations of the paths go first sorted by path and method.
+// If withCallbacks is set, the operations of the webhooks and the callbacks are added too,
+// the path of such entries is the location of the path item, e.g. `webhooks/newPet`.
+func (o *OpenAPI) operationIndex(withCallbacks bool) map[string][]operationEntry {
+	idx := &operationIndexer{
+		components:    o.Components,
+		withCallbacks: withCallbacks,
+		index:         make(map[string][]operationEntry),
+		seen:          make(map[*Operation]bool),
+	}
+	if o.Paths != nil {
+		for _, path := range sortedKeys(o.Paths.Spec.Paths) {
+			idx.pathItem(joinLoc("paths", path), path, o.Paths.Spec.Paths[path])
+		}
+	}
+	if !withCallbacks {
+		return idx.index
+	}
+	for _, name := range sortedKeys(o.WebHooks) {
+		idx.pathItem(joinLoc("webhooks", name), "", o.WebHooks[name])
+	}
+	if o.Components != nil {
+		for _, name := range sortedKeys(o.Components.Spec.Callbacks) {
+			idx.callback(joinLoc("components", "callbacks", name), o.Components.Spec.Callbacks[name])
+		}
+	}
+	return idx.index
+}
+
+type operationIndexer struct {
+	components    *Extendable[Components]
+	withCallbacks bool
+	index         map[string][]operationEntry
+	// seen holds the indexed operations, the same operation can be referenced several times
+	seen map[*Operation]bool
+}
+
+func (x *operationIndexer) pathItem(location, path string, ref *RefOrSpec[Extendable[PathItem]]) {
+	if ref == nil {
+		return
+	}
+	pathItem, err := ResolvePathItem(ref, x.components)
+	if err != nil {
+		return
+	}
+	if path == "" {
+		path = location
+	}
+	methods, operations := pathItem.Spec.operations()
+	for i, op := range operations {
+		if x.seen[op.Spec] {
+			continue
+		}
+		x.seen[op.Spec] = true
+		if op.Spec.OperationID != "" {
+			x.index[op.Spec.OperationID] = append(x.index[op.Spec.OperationID], operationEntry{
+				path:      path,
+				method:    methods[i],
+				operation: op.Spec,
+			})
+		}
+		if x.withCallbacks {
+			for _, name := range sortedKeys(op.Spec.Callbacks) {
+				x.callback(joinLoc(location, methods[i], "callbacks", name), op.Spec.Callbacks[name])
+			}
+		}
+	}
+}
+
+func (x *operationIndexer) callback(location string, ref *RefOrSpec[Extendable[Callback]]) {
+	if ref == nil {
+		return
+	}
+	callback, err := ref.GetSpec(x.components)
+	if err != nil {
+		return
+	}
+	for _, expr := range sortedKeys(callback.Spec.Paths) {
+		x.pathItem(joinLoc(location, expr), "", callback.Spec.Paths[expr])
+	}
+}
@@ -0,0 +1,190 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestOpenAPI_Version(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		version string
+		dialect string
+		opts    []openapi.ValidationOption
+		errs    []string
+	}{
+		{name: "2.0", version: "2.0", errs: []string{"/openapi: unsupported version: 2.0"}},
+		{name: "3.0.3", version: "3.0.3", errs: []string{"/openapi: unsupported version: 3.0.3"}},
+		{name: "3.1.1", version: "3.1.1"},
+		{name: "3.1.0", version: "3.1.0"},
+		{name: "3.1.0-rc1", version: "3.1.0-rc1"},
+		{name: "3.1", version: "3.1", errs: []string{"/openapi: unsupported version: 3.1"}},
+		{name: "exact", version: "3.1.1", opts: []openapi.ValidationOption{openapi.RequireVersion("3.1.1")}},
+		{
+			name:    "not exact",
+			version: "3.1.0",
+			opts:    []openapi.ValidationOption{openapi.RequireVersion("3.1.1")},
+			errs:    []string{`/openapi: unsupported version: 3.1.0: "3.1.1" is required`},
+		},
+		{name: "dialect 3.1.1", version: "3.1.1", dialect: "https://spec.openapis.org/oas/3.1/dialect/base"},
+		{
+			name:    "dialect 3.0.3",
+			version: "3.0.3",
+			dialect: "https://spec.openapis.org/oas/3.1/dialect/base",
+			errs: []string{
+				"/jsonSchemaDialect: unsupported version: 3.0.3: the field requires OpenAPI 3.1",
+				"/openapi: unsupported version: 3.0.3",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI(tt.version).
+				JsonSchemaDialect(tt.dialect).
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, tt.opts...)
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+				require.Equal(t, true, errors.Is(errs[i], openapi.UnsupportedVersionError(tt.version)))
+			}
+		})
+	}
+}
+
+func TestOpenAPI_JsonSchemaDialect(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		data := `{"openapi": "3.1.1", "info": {"title": "test", "version": "1.0.0"}, "jsonSchemaDialect": "https://example.com/dialect", "paths": {}}`
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, json.Unmarshal([]byte(data), &spec))
+		require.Equal(t, "https://example.com/dialect", spec.Spec.SchemaDialect())
+		newData, err := json.Marshal(spec)
+		require.NoError(t, err)
+		require.JSONEq(t, data, string(newData))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		spec := openapi.NewOpenAPIBuilder().JsonSchemaDialect("").Build()
+		require.Equal(t, openapi.Draft202012, spec.Spec.SchemaDialect())
+	})
+
+	for _, tt := range []struct {
+		dialect string
+		err     string
+	}{
+		{dialect: "https://spec.openapis.org/oas/3.1/dialect/base"},
+		{dialect: "https://json-schema.org/draft/2020-12/schema"},
+		{dialect: "http://json-schema.org/draft-07/schema#"},
+		{dialect: "dialect/base", err: "/jsonSchemaDialect: must be an absolute URI, but got 'dialect/base'"},
+		{dialect: "https://example.com/%zz", err: "/jsonSchemaDialect: invalid URL"},
+	} {
+		t.Run(tt.dialect, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				JsonSchemaDialect(tt.dialect).
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec)
+			require.NoError(t, err)
+			if tt.err == "" {
+				require.NoError(t, validator.ValidateSpec())
+			} else {
+				require.ErrorContains(t, validator.ValidateSpec(), tt.err)
+			}
+		})
+	}
+}
+
+func TestOpenAPI_Tags(t *testing.T) {
+	newSpec := func(tags []string, opTags ...string) *openapi.Extendable[openapi.OpenAPI] {
+		op := openapi.NewOperationBuilder().Tags(opTags...).Build()
+		op.Spec.Responses = openapi.NewResponsesBuilder().AddResponse("200", openapi.NewResponseBuilder().Description("ok").Build()).Build().Spec
+		b := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddPath("/pets", openapi.NewPathItemBuilder().Get(op).Build())
+		for _, name := range tags {
+			b.AddTags(openapi.NewTagBuilder().Name(name).Build())
+		}
+		return b.Build()
+	}
+
+	for _, tt := range []struct {
+		name     string
+		spec     *openapi.Extendable[openapi.OpenAPI]
+		opts     []openapi.ValidationOption
+		errs     []string
+		warnings []string
+	}{
+		{name: "valid", spec: newSpec([]string{"pets", "store"}, "pets", "store")},
+		{
+			name: "duplicate",
+			spec: newSpec([]string{"pets", "store", "pets"}, "pets", "store"),
+			errs: []string{"/tags/2/name: 'pets' is not unique, also used at /tags/0/name"},
+		},
+		{
+			name:     "undeclared",
+			spec:     newSpec([]string{"pets"}, "pets", "store"),
+			warnings: []string{"/paths/~1pets/get/tags/1: 'store' is not declared in the root tags"},
+		},
+		{
+			name: "undeclared allowed",
+			spec: newSpec([]string{"pets"}, "pets", "store"),
+			opts: []openapi.ValidationOption{openapi.AllowUndefinedTagsInOperation()},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(tt.spec, tt.opts...)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+			require.Len(t, warnings, len(tt.warnings))
+			for i, w := range tt.warnings {
+				require.ErrorContains(t, warnings[i], w)
+			}
+		})
+	}
+}
+
+func TestOpenAPIBuilder(t *testing.T) {
+	op := openapi.NewOperationBuilder().OperationID("listPets").Tags("pets").Build()
+	op.Spec.Responses = openapi.NewResponsesBuilder().AddResponse("200", openapi.NewResponseBuilder().Description("ok").Build()).Build().Spec
+	components := openapi.NewComponents()
+	components.Spec.Add("Bearer", openapi.NewSecuritySchemeBuilder().Type(openapi.TypeHTTP).Scheme("bearer").Build())
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Pets").Version("1.0.0").Build()).
+		AddServers(openapi.NewServerBuilder().URL("https://api.example.com").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().Get(op).Build()).
+		Components(components).
+		AddTags(openapi.NewTagBuilder().Name("pets").Build()).
+		Security(openapi.SecurityRequirement{"Bearer": {}}).
+		Build()
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "openapi": "3.1.1",
+  "jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "servers": [{"url": "https://api.example.com"}],
+  "paths": {"/pets": {"get": {"operationId": "listPets", "tags": ["pets"], "responses": {"200": {"description": "ok"}}}}},
+  "components": {"securitySchemes": {"Bearer": {"type": "http", "scheme": "bearer"}}},
+  "security": [{"Bearer": []}],
+  "tags": [{"name": "pets"}]
+}`, string(data))
+}
@@ -1,7 +1,8 @@
 package openapi
 
 import (
-	"strings"
+	"fmt"
+	"net/url"
 )
 
 // Operation Describes a single API operation on a path.
@@ -104,25 +105,13 @@ type Operation struct {
 func (o *Operation) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	if o.OperationID != "" {
-		id := joinLoc("operations", o.OperationID)
-		if validator.visited[id] {
-			errs = append(errs, newValidationError(joinLoc(location, "operationId"), "'%s' is not unique", o.OperationID))
-		} else {
-			validator.visited[id] = true
-		}
+		validator.addOperationID(joinLoc(location, "operationId"), o.OperationID)
+	} else if validator.opts.warnMissingOperationID {
+		errs = append(errs, newValidationWarning(joinLoc(location, "operationId"), "is recommended to identify the operation"))
 	}
 
 	if o.RequestBody != nil {
-		nextLoc := joinLoc(location, "requestBody")
-		errs = append(errs, o.RequestBody.validateSpec(nextLoc, validator)...)
-		switch {
-		case !validator.opts.allowRequestBodyForGet && strings.HasSuffix(location, "get"):
-			errs = append(errs, newValidationError(location, "not allowed for get"))
-		case !validator.opts.allowRequestBodyForDelete && strings.HasSuffix(location, "delete"):
-			errs = append(errs, newValidationError(nextLoc, "not allowed for delete"))
-		case !validator.opts.allowRequestBodyForHead && strings.HasSuffix(location, "head"):
-			errs = append(errs, newValidationError(nextLoc, "not allowed for head"))
-		}
+		errs = append(errs, o.RequestBody.validateSpec(joinLoc(location, "requestBody"), validator)...)
 	}
 	if o.Responses != nil {
 		errs = append(errs, o.Responses.validateSpec(joinLoc(location, "responses"), validator)...)
@@ -139,13 +128,14 @@ func (o *Operation) validateSpec(location string, validator *Validator) []*valid
 		for i, p := range o.Parameters {
 			errs = append(errs, p.validateSpec(joinLoc(location, "parameters", i), validator)...)
 		}
+		errs = append(errs, validateParameterDuplicates(location, o.Parameters, validator)...)
 	}
 	if o.Tags != nil {
 		for i, t := range o.Tags {
-			if !validator.opts.allowUndefinedTagsInOperation && !validator.visited[joinLoc("tags", t)] {
-				errs = append(errs, newValidationError(joinLoc(location, "tags", i), "'%s' not found", t))
+			if !validator.opts.allowUndefinedTagsInOperation && !validator.isVisited(joinLoc("tags", t)) {
+				errs = append(errs, newValidationWarning(joinLoc(location, "tags", i), "'%s' is not declared in the root tags", t))
 			}
-			validator.visited[joinLoc("tags", t, "used")] = true
+			validator.markVisited(joinLoc("tags", t, "used"))
 		}
 	}
 	if o.Security != nil {
@@ -154,14 +144,57 @@ func (o *Operation) validateSpec(location string, validator *Validator) []*valid
 		}
 	}
 	if o.Servers != nil {
-		for i, s := range o.Servers {
-			errs = append(errs, s.validateSpec(joinLoc(location, "servers", i), validator)...)
-		}
+		errs = append(errs, validateServers(joinLoc(location, "servers"), o.Servers, validator)...)
 	}
 
 	return errs
 }
 
+// BindQuery binds the given query values using the `query` parameters of the operation
+// and the parameters of the given path item, which are not overridden by the operation,
+// see BindQuery function for details.
+// The path item can be nil. The components are used to resolve the referenced parameters.
+func (o *Operation) BindQuery(values url.Values, pathItem *PathItem, components *Extendable[Components]) (map[string]any, []error) {
+	params, errs := EffectiveParameters(o, pathItem, components)
+	result, bindErrs := BindQuery(values, params)
+	return result, append(errs, bindErrs...)
+}
+
+// EffectiveParameters returns the parameters of the operation combined with the parameters of the given path item,
+// which are not overridden by the operation. A parameter is overridden if the operation declares a parameter
+// with the same name and location, the names of the headers are case-insensitive.
+// The parameters of the operation go first in the declared order, followed by the rest of the path item parameters.
+// The components are used to resolve the referenced parameters, the unresolved ones are skipped and reported as errors.
+func EffectiveParameters(op *Operation, pathItem *PathItem, components *Extendable[Components]) ([]*Parameter, []error) {
+	var (
+		params []*Parameter
+		errs   []error
+		seen   = make(map[string]bool)
+	)
+	collect := func(prefix string, refs []*RefOrSpec[Extendable[Parameter]]) {
+		for i, p := range refs {
+			param, err := p.GetSpec(components)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s[%d]: %w", prefix, i, err))
+				continue
+			}
+			key := param.Spec.key()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			params = append(params, param.Spec)
+		}
+	}
+	if op != nil {
+		collect("parameters", op.Parameters)
+	}
+	if pathItem != nil {
+		collect("pathItem.parameters", pathItem.Parameters)
+	}
+	return params, errs
+}
+
 type OperationBuilder struct {
 	spec *Extendable[Operation]
 }
@@ -0,0 +1,56 @@
+package openapi
+
+import "sync"
+
+// OperationIndex memorizes the operations of the paths of the given document by operationId,
+// so the repeated lookups do not walk the whole document as FindOperation does.
+//
+// The index is built on the first lookup and is safe for concurrent use.
+// The changes of the document made after the first lookup are not visible, call Reset method to rebuild the index.
+type OperationIndex struct {
+	doc     *OpenAPI
+	mu      sync.RWMutex
+	entries map[string][]operationEntry
+}
+
+// NewOperationIndex creates an index of the operations of the given document.
+func NewOperationIndex(doc *OpenAPI) *OperationIndex {
+	return &OperationIndex{doc: doc}
+}
+
+// Reset removes the indexed operations, so the index is rebuilt on the next lookup.
+func (x *OperationIndex) Reset() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries = nil
+}
+
+// FindOperation is like OpenAPI.FindOperation, but uses the cached index.
+func (x *OperationIndex) FindOperation(id string) (path string, method string, op *Operation, ok bool) {
+	entries := x.get()[id]
+	if len(entries) == 0 {
+		return "", "", nil, false
+	}
+	return entries[0].path, entries[0].method, entries[0].operation, true
+}
+
+// IsOperationIDUnique is like OpenAPI.IsOperationIDUnique, but uses the cached index.
+func (x *OperationIndex) IsOperationIDUnique(id string) bool {
+	return len(x.get()[id]) < 2
+}
+
+func (x *OperationIndex) get() map[string][]operationEntry {
+	x.mu.RLock()
+	entries := x.entries
+	x.mu.RUnlock()
+	if entries != nil {
+		return entries
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.entries == nil {
+		x.entries = x.doc.operationIndex(false)
+	}
+	return x.entries
+}
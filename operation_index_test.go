@@ -0,0 +1,61 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestOperationIndex(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(linkSpec), &spec))
+	doc := spec.Spec.Clone()
+	index := openapi.NewOperationIndex(doc)
+
+	path, method, op, ok := index.FindOperation("getUserAddress")
+	require.Equal(t, true, ok)
+	require.Equal(t, "/users/{userid}/address", path)
+	require.Equal(t, "get", method)
+	require.Equal(t, "getUserAddress", op.OperationID)
+	require.Equal(t, true, index.IsOperationIDUnique("getUserAddress"))
+
+	_, _, op, ok = index.FindOperation("getUserPhone")
+	require.Equal(t, false, ok)
+	require.Nil(t, op)
+
+	t.Run("concurrent", func(t *testing.T) {
+		index := openapi.NewOperationIndex(doc)
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, _, ok := index.FindOperation("getUserAddress")
+				require.Equal(t, true, ok)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		item, err := doc.Paths.Spec.Paths["/users/{userid}/address"].GetSpec(doc.Components)
+		require.NoError(t, err)
+		item.Spec.Get.Spec.OperationID = "getAddress"
+
+		// the cached operations are returned until the index is reset
+		_, _, _, ok := index.FindOperation("getUserAddress")
+		require.Equal(t, true, ok)
+		_, _, _, ok = index.FindOperation("getAddress")
+		require.Equal(t, false, ok)
+
+		index.Reset()
+		_, _, _, ok = index.FindOperation("getUserAddress")
+		require.Equal(t, false, ok)
+		path, _, _, ok := index.FindOperation("getAddress")
+		require.Equal(t, true, ok)
+		require.Equal(t, "/users/{userid}/address", path)
+	})
+}
@@ -0,0 +1,179 @@
+package openapi_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestOperation_OperationID(t *testing.T) {
+	newSpec := func(ids ...string) *openapi.Extendable[openapi.OpenAPI] {
+		b := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build())
+		for i, id := range ids {
+			b.AddPath("/pets/"+strconv.Itoa(i), openapi.NewPathItemBuilder().
+				Get(openapi.NewOperationBuilder().OperationID(id).Build()).
+				Build())
+		}
+		return b.Build()
+	}
+
+	for _, tt := range []struct {
+		name     string
+		spec     *openapi.Extendable[openapi.OpenAPI]
+		opts     []openapi.ValidationOption
+		errs     []string
+		warnings []string
+	}{
+		{name: "unique", spec: newSpec("listPets", "getPet")},
+		{
+			name: "duplicate",
+			spec: newSpec("listPets", "getPet", "listPets"),
+			errs: []string{
+				"/paths/~1pets~10/get/operationId: 'listPets' is not unique, also used at /paths/~1pets~12/get/operationId",
+				"/paths/~1pets~12/get/operationId: 'listPets' is not unique, also used at /paths/~1pets~10/get/operationId",
+			},
+		},
+		{name: "empty", spec: newSpec("", "")},
+		{
+			name: "empty with warning",
+			spec: newSpec("listPets", ""),
+			opts: []openapi.ValidationOption{openapi.WarnMissingOperationID()},
+			warnings: []string{
+				"/paths/~1pets~11/get/operationId: is recommended to identify the operation",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(tt.spec, tt.opts...)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+			require.Len(t, warnings, len(tt.warnings))
+			for i, w := range tt.warnings {
+				require.ErrorContains(t, warnings[i], w)
+			}
+		})
+	}
+}
+
+func TestOperation_Parameters(t *testing.T) {
+	newParam := func(name, in, description string) *openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]] {
+		return openapi.NewParameterBuilder().
+			Name(name).
+			In(in).
+			Description(description).
+			Required(in == openapi.InPath).
+			Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+			Build()
+	}
+	newSpec := func(pathItemParams, opParams []*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]) *openapi.Extendable[openapi.OpenAPI] {
+		op := openapi.NewOperationBuilder().Parameters(opParams...).Build()
+		op.Spec.Responses = openapi.NewResponsesBuilder().AddResponse("200", openapi.NewResponseBuilder().Description("ok").Build()).Build().Spec
+		return openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddPath("/pets/{id}", openapi.NewPathItemBuilder().Parameters(pathItemParams...).Get(op).Build()).
+			Build()
+	}
+
+	t.Run("override", func(t *testing.T) {
+		spec := newSpec(
+			[]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+				newParam("id", openapi.InPath, "common id"),
+				newParam("X-Request-ID", openapi.InHeader, "common request id"),
+				newParam("limit", openapi.InQuery, "common limit"),
+			},
+			[]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+				newParam("limit", openapi.InQuery, "operation limit"),
+				newParam("x-request-id", openapi.InHeader, "operation request id"),
+				newParam("limit", openapi.InHeader, "limit header"),
+			},
+		)
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+
+		pathItem := spec.Spec.Paths.Spec.Paths["/pets/{id}"].Spec.Spec
+		params, errs := openapi.EffectiveParameters(pathItem.Get.Spec, pathItem, spec.Spec.Components)
+		require.Empty(t, errs)
+		var descriptions []string
+		for _, p := range params {
+			descriptions = append(descriptions, p.Description)
+		}
+		require.Equal(t, []string{"operation limit", "operation request id", "limit header", "common id"}, descriptions)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		spec := newSpec(
+			[]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+				newParam("id", openapi.InPath, "common id"),
+				newParam("id", openapi.InPath, "another id"),
+			},
+			[]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+				newParam("X-Request-ID", openapi.InHeader, "request id"),
+				newParam("x-request-id", openapi.InHeader, "another request id"),
+			},
+		)
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		errs, _ := validator.Validate()
+		require.Len(t, errs, 2)
+		require.ErrorContains(t, errs[0], "/paths/~1pets~1{id}/get/parameters/1: duplicate of 'header' parameter 'x-request-id' declared at /paths/~1pets~1{id}/get/parameters/0")
+		require.ErrorContains(t, errs[1], "/paths/~1pets~1{id}/parameters/1: duplicate of 'path' parameter 'id' declared at /paths/~1pets~1{id}/parameters/0")
+	})
+
+	t.Run("ignored headers", func(t *testing.T) {
+		spec := newSpec(
+			[]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+				newParam("id", openapi.InPath, "common id"),
+			},
+			[]*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]{
+				newParam("content-type", openapi.InHeader, "content type"),
+				newParam("Authorization", openapi.InQuery, "not a header"),
+			},
+		)
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		errs, warnings := validator.Validate()
+		require.Empty(t, errs)
+		require.Len(t, warnings, 1)
+		require.ErrorContains(t, warnings[0], "/paths/~1pets~1{id}/get/parameters/0/name: 'content-type' header parameter is ignored")
+	})
+
+	t.Run("exploded cookie array", func(t *testing.T) {
+		op := openapi.NewOperationBuilder().Parameters(
+			openapi.NewParameterBuilder().Name("ids").In(openapi.InCookie).Explode(true).
+				Schema(openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build())).Build()).
+				Build(),
+			openapi.NewParameterBuilder().Name("session").In(openapi.InCookie).Explode(true).
+				Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+				Build(),
+			openapi.NewParameterBuilder().Name("tags").In(openapi.InCookie).
+				Schema(openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build())).Build()).
+				Build(),
+			openapi.NewParameterBuilder().Name("colors").In(openapi.InCookie).Explode(false).
+				Schema(openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build())).Build()).
+				Build(),
+		).Build()
+		op.Spec.Responses = openapi.NewResponsesBuilder().AddResponse("200", openapi.NewResponseBuilder().Description("ok").Build()).Build().Spec
+		spec := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddPath("/pets", openapi.NewPathItemBuilder().Get(op).Build()).
+			Build()
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		errs, warnings := validator.Validate()
+		require.Empty(t, errs)
+		require.Len(t, warnings, 2)
+		require.ErrorContains(t, warnings[0], "/paths/~1pets/get/parameters/0/explode: exploded arrays are poorly supported in cookies")
+		require.ErrorContains(t, warnings[1], "/paths/~1pets/get/parameters/2/explode: exploded arrays are poorly supported in cookies")
+	})
+}
@@ -0,0 +1,385 @@
+package overlay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPath = errors.New("invalid JSONPath")
+
+type selectorKind int
+
+const (
+	selectName selectorKind = iota
+	selectIndex
+	selectWildcard
+	selectFilter
+)
+
+type selector struct {
+	kind   selectorKind
+	name   string
+	index  int
+	filter *filter
+}
+
+type segment struct {
+	// descendant is true for the `..` segments, which select the children of the node and all its descendants
+	descendant bool
+	selectors  []selector
+}
+
+// path is a parsed JSONPath query.
+type path []segment
+
+// filter is a filter expression, either an existence test (`@.name`) or a comparison (`@.name == 'value'`).
+type filter struct {
+	query path
+	op    string
+	value any
+}
+
+// node is a value of the document and its location as the list of map keys (string) and array indexes (int).
+type node struct {
+	location []any
+	value    any
+}
+
+func parsePath(expr string) (path, error) {
+	p := pathParser{expr: expr}
+	if !p.consume("$") {
+		return nil, fmt.Errorf("%w %q: must start with '$'", ErrInvalidPath, expr)
+	}
+	segments, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.expr) {
+		return nil, p.error("unexpected %q", p.expr[p.pos:])
+	}
+	return segments, nil
+}
+
+type pathParser struct {
+	expr string
+	pos  int
+}
+
+func (p *pathParser) error(format string, args ...any) error {
+	return fmt.Errorf("%w %q at %d: %s", ErrInvalidPath, p.expr, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *pathParser) skipSpaces() {
+	for p.pos < len(p.expr) && strings.ContainsRune(" \t\n\r", rune(p.expr[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *pathParser) consume(s string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) segments() (path, error) {
+	var segments path
+	for p.pos < len(p.expr) {
+		var s segment
+		switch {
+		case p.consume(".."):
+			s.descendant = true
+			if !strings.HasPrefix(p.expr[p.pos:], "[") {
+				sel, err := p.memberName()
+				if err != nil {
+					return nil, err
+				}
+				s.selectors = []selector{sel}
+				break
+			}
+			p.pos++
+			sels, err := p.bracketed()
+			if err != nil {
+				return nil, err
+			}
+			s.selectors = sels
+		case p.consume("."):
+			sel, err := p.memberName()
+			if err != nil {
+				return nil, err
+			}
+			s.selectors = []selector{sel}
+		case p.consume("["):
+			sels, err := p.bracketed()
+			if err != nil {
+				return nil, err
+			}
+			s.selectors = sels
+		default:
+			return segments, nil
+		}
+		segments = append(segments, s)
+	}
+	return segments, nil
+}
+
+func (p *pathParser) memberName() (selector, error) {
+	if p.consume("*") {
+		return selector{kind: selectWildcard}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		if c == '.' || c == '[' || c == ' ' || c == ']' || c == '=' || c == '!' || c == ')' || c == '&' || c == '|' {
+			break
+		}
+		p.pos++
+	}
+	if start == p.pos {
+		return selector{}, p.error("name expected")
+	}
+	return selector{kind: selectName, name: p.expr[start:p.pos]}, nil
+}
+
+// bracketed parses the comma separated selectors after the opening bracket.
+func (p *pathParser) bracketed() ([]selector, error) {
+	var selectors []selector
+	for {
+		p.skipSpaces()
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipSpaces()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.error("',' or ']' expected")
+		}
+	}
+}
+
+func (p *pathParser) selector() (selector, error) {
+	switch {
+	case p.consume("*"):
+		return selector{kind: selectWildcard}, nil
+	case p.consume("?"):
+		f, err := p.filter()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: selectFilter, filter: f}, nil
+	case p.pos < len(p.expr) && (p.expr[p.pos] == '\'' || p.expr[p.pos] == '"'):
+		name, err := p.quoted()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: selectName, name: name}, nil
+	}
+	start := p.pos
+	if p.pos < len(p.expr) && p.expr[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.expr) && p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9' {
+		p.pos++
+	}
+	i, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		return selector{}, p.error("selector expected")
+	}
+	return selector{kind: selectIndex, index: i}, nil
+}
+
+func (p *pathParser) quoted() (string, error) {
+	quote := p.expr[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && p.pos < len(p.expr):
+			b.WriteByte(p.expr[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.error("unterminated string")
+}
+
+func (p *pathParser) filter() (*filter, error) {
+	p.skipSpaces()
+	parens := p.consume("(")
+	p.skipSpaces()
+	if !p.consume("@") {
+		return nil, p.error("'@' expected")
+	}
+	query, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	f := filter{query: query}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!="} {
+		if p.consume(op) {
+			f.op = op
+			p.skipSpaces()
+			if f.value, err = p.literal(); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	p.skipSpaces()
+	if parens && !p.consume(")") {
+		return nil, p.error("')' expected")
+	}
+	return &f, nil
+}
+
+func (p *pathParser) literal() (any, error) {
+	if p.pos < len(p.expr) && (p.expr[p.pos] == '\'' || p.expr[p.pos] == '"') {
+		return p.quoted()
+	}
+	for _, v := range []struct {
+		token string
+		value any
+	}{
+		{"true", true},
+		{"false", false},
+		{"null", nil},
+	} {
+		if p.consume(v.token) {
+			return v.value, nil
+		}
+	}
+	start := p.pos
+	for p.pos < len(p.expr) && strings.ContainsRune("+-.0123456789eE", rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	n, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		return nil, p.error("literal expected")
+	}
+	return n, nil
+}
+
+// evaluate returns the nodes selected by the path in the document order.
+func (q path) evaluate(root any) []node {
+	nodes := []node{{value: root}}
+	for _, s := range q {
+		var next []node
+		for _, n := range nodes {
+			if s.descendant {
+				for _, d := range descendants(n) {
+					next = append(next, s.apply(d)...)
+				}
+			} else {
+				next = append(next, s.apply(n)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// descendants returns the given node and all its descendants.
+func descendants(n node) []node {
+	nodes := []node{n}
+	for _, c := range children(n) {
+		nodes = append(nodes, descendants(c)...)
+	}
+	return nodes
+}
+
+// children returns the values of an object, sorted by keys for stable order, or the items of an array.
+func children(n node) []node {
+	var nodes []node
+	switch v := n.value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			nodes = append(nodes, node{location: childLocation(n.location, k), value: v[k]})
+		}
+	case []any:
+		for i, item := range v {
+			nodes = append(nodes, node{location: childLocation(n.location, i), value: item})
+		}
+	}
+	return nodes
+}
+
+func childLocation(location []any, key any) []any {
+	return append(slices.Clip(location), key)
+}
+
+func (s segment) apply(n node) []node {
+	var nodes []node
+	for _, sel := range s.selectors {
+		switch sel.kind {
+		case selectName:
+			if m, ok := n.value.(map[string]any); ok {
+				if v, found := m[sel.name]; found {
+					nodes = append(nodes, node{location: childLocation(n.location, sel.name), value: v})
+				}
+			}
+		case selectIndex:
+			if a, ok := n.value.([]any); ok {
+				i := sel.index
+				if i < 0 {
+					i += len(a)
+				}
+				if i >= 0 && i < len(a) {
+					nodes = append(nodes, node{location: childLocation(n.location, i), value: a[i]})
+				}
+			}
+		case selectWildcard:
+			nodes = append(nodes, children(n)...)
+		case selectFilter:
+			for _, c := range children(n) {
+				if sel.filter.match(c.value) {
+					nodes = append(nodes, c)
+				}
+			}
+		}
+	}
+	return nodes
+}
+
+func (f *filter) match(v any) bool {
+	nodes := f.query.evaluate(v)
+	if f.op == "" {
+		return len(nodes) > 0
+	}
+	equal := len(nodes) == 1 && equalValues(nodes[0].value, f.value)
+	if f.op == "==" {
+		return equal
+	}
+	return !equal
+}
+
+func equalValues(a, b any) bool {
+	if n, ok := a.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return false
+		}
+		a = f
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,280 @@
+// Package overlay applies the OpenAPI Overlay documents to the OpenAPI documents.
+//
+// https://spec.openapis.org/overlay/v1.0.0.html
+package overlay
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+var (
+	// ErrUnsupportedVersion is returned if the version of the Overlay document is not 1.x.
+	ErrUnsupportedVersion = errors.New("unsupported overlay version")
+	// ErrTargetRequired is returned if an action has no target.
+	ErrTargetRequired = errors.New("target is required")
+)
+
+// Overlay is the root object of the Overlay document.
+//
+// https://spec.openapis.org/overlay/v1.0.0.html#overlay-object
+//
+// Example:
+//
+//	overlay: 1.0.0
+//	info:
+//	  title: Production servers
+//	  version: 1.0.0
+//	extends: openapi.yaml
+//	actions:
+//	  - target: $.servers
+//	    update:
+//	      url: https://api.example.com
+type Overlay struct {
+	// REQUIRED.
+	// The version number of the Overlay Specification that the Overlay document uses.
+	Overlay string `json:"overlay"`
+	// REQUIRED.
+	// Provides metadata about the Overlay.
+	Info *openapi.Extendable[Info] `json:"info"`
+	// The URI reference, which identifies the target document (such as an OpenAPI document) this overlay applies to.
+	Extends string `json:"extends,omitempty"`
+	// REQUIRED.
+	// An ordered list of actions to be applied to the target document.
+	Actions []*openapi.Extendable[Action] `json:"actions"`
+}
+
+// Info provides metadata about the Overlay.
+//
+// https://spec.openapis.org/overlay/v1.0.0.html#info-object
+type Info struct {
+	// REQUIRED.
+	// A human readable description of the purpose of the overlay.
+	Title string `json:"title"`
+	// REQUIRED.
+	// A version identifier for indicating changes to the Overlay document.
+	Version string `json:"version"`
+}
+
+// Action describes a change of the target document.
+//
+// https://spec.openapis.org/overlay/v1.0.0.html#action-object
+type Action struct {
+	// REQUIRED.
+	// A JSONPath expression selecting nodes in the target document.
+	Target string `json:"target"`
+	// A description of the action.
+	// CommonMark syntax MAY be used for rich text representation.
+	Description string `json:"description,omitempty"`
+	// An object with the properties to be merged with the object(s) located by the target,
+	// or a value to be appended to the array(s) located by the target.
+	// It has no impact if the remove field is true.
+	Update any `json:"update,omitempty"`
+	// A boolean value that indicates that the target object or array MUST be removed from the map or array it is contained in.
+	Remove bool `json:"remove,omitempty"`
+}
+
+// Apply applies the actions of the overlay to the given document in order.
+//
+// The objects located by the target are deep merged with the update: the properties of the objects are merged recursively,
+// the other values are replaced, the update is appended to the arrays (the items of the update are appended,
+// if it is an array as well).
+// The located objects are removed from their parents, if the remove field is true.
+// A target, which does not match any node, is not an error.
+//
+// The target supports a subset of JSONPath (RFC 9535): the names (`.name`, `['name']`), the wildcards (`.*`, `[*]`),
+// the indexes (`[0]`, `[-1]`), the descendants (`..name`) and the filters with
+// the existence tests and the comparisons of the relative paths with the literals (`[?@.deprecated == true]`).
+//
+// The document is updated only if all actions are applied successfully.
+func Apply(doc *openapi.OpenAPI, ov *Overlay) error {
+	if !strings.HasPrefix(ov.Overlay, "1.") {
+		return fmt.Errorf("%w: %q", ErrUnsupportedVersion, ov.Overlay)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var root any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return err
+	}
+
+	for i, action := range ov.Actions {
+		if err := apply(&root, action.Spec); err != nil {
+			return fmt.Errorf("actions/%d: %w", i, err)
+		}
+	}
+
+	data, err = json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	var updated openapi.OpenAPI
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return err
+	}
+	*doc = updated
+	return nil
+}
+
+func apply(root *any, action *Action) error {
+	if action.Target == "" {
+		return ErrTargetRequired
+	}
+	path, err := parsePath(action.Target)
+	if err != nil {
+		return err
+	}
+	nodes := path.evaluate(*root)
+	if action.Remove {
+		locations := make([][]any, len(nodes))
+		for i, n := range nodes {
+			locations[i] = n.location
+		}
+		// remove the nodes in the reverse order of the locations: the higher indexes of the same array
+		// and the descendants before their ancestors, so the locations of the other nodes stay valid
+		slices.SortFunc(locations, func(a, b []any) int {
+			return compareLocations(b, a)
+		})
+		locations = slices.CompactFunc(locations, func(a, b []any) bool {
+			return compareLocations(a, b) == 0
+		})
+		for _, location := range locations {
+			remove(root, location)
+		}
+		return nil
+	}
+	if action.Update == nil {
+		return nil
+	}
+	for _, n := range nodes {
+		// a copy of the update for each node, so the merged nodes do not share the values
+		update, err := normalize(action.Update)
+		if err != nil {
+			return err
+		}
+		set(root, n.location, merge(n.value, update))
+	}
+	return nil
+}
+
+// normalize converts the given value into the generic JSON form, so it can be merged.
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var ret any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// merge merges the update into the target.
+func merge(target, update any) any {
+	switch t := target.(type) {
+	case map[string]any:
+		u, ok := update.(map[string]any)
+		if !ok {
+			return update
+		}
+		for k, v := range u {
+			if existing, found := t[k]; found {
+				t[k] = merge(existing, v)
+			} else {
+				t[k] = v
+			}
+		}
+		return t
+	case []any:
+		if u, ok := update.([]any); ok {
+			return append(t, u...)
+		}
+		return append(t, update)
+	default:
+		return update
+	}
+}
+
+// set replaces the value at the given location.
+func set(root *any, location []any, value any) {
+	if len(location) == 0 {
+		*root = value
+		return
+	}
+	parent := get(*root, location[:len(location)-1])
+	switch p := parent.(type) {
+	case map[string]any:
+		p[location[len(location)-1].(string)] = value
+	case []any:
+		p[location[len(location)-1].(int)] = value
+	}
+}
+
+// compareLocations compares two locations key by key, the indexes of arrays are compared as numbers,
+// a location goes before its descendants.
+func compareLocations(a, b []any) int {
+	for i := range min(len(a), len(b)) {
+		var c int
+		switch x := a[i].(type) {
+		case int:
+			y, _ := b[i].(int)
+			c = cmp.Compare(x, y)
+		case string:
+			y, _ := b[i].(string)
+			c = strings.Compare(x, y)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// remove deletes the value at the given location from its parent.
+func remove(root *any, location []any) {
+	if len(location) == 0 {
+		*root = nil
+		return
+	}
+	parent := get(*root, location[:len(location)-1])
+	switch p := parent.(type) {
+	case map[string]any:
+		delete(p, location[len(location)-1].(string))
+	case []any:
+		i := location[len(location)-1].(int)
+		if i < len(p) {
+			// the slice header is stored in the parent, so set the shortened slice back
+			set(root, location[:len(location)-1], append(p[:i:i], p[i+1:]...))
+		}
+	}
+}
+
+func get(root any, location []any) any {
+	v := root
+	for _, key := range location {
+		switch t := v.(type) {
+		case map[string]any:
+			v = t[key.(string)]
+		case []any:
+			v = t[key.(int)]
+		default:
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,169 @@
+package overlay_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+	"github.com/sv-tools/openapi/overlay"
+)
+
+const testSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "servers": [{"url": "https://dev.example.com"}],
+  "paths": {
+    "/pets": {
+      "get": {"operationId": "listPets", "responses": {"200": {"description": "ok"}}}
+    },
+    "/v1/pets": {
+      "get": {"operationId": "listPetsV1", "deprecated": true, "responses": {"200": {"description": "ok"}}}
+    }
+  }
+}`
+
+func parse[T any](t *testing.T, data string) *T {
+	t.Helper()
+	var v openapi.Extendable[T]
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+	return v.Spec
+}
+
+func TestApply(t *testing.T) {
+	doc := parse[openapi.OpenAPI](t, testSpec)
+	ov := parse[overlay.Overlay](t, `{
+	  "overlay": "1.0.0",
+	  "info": {"title": "Production", "version": "1.0.0"},
+	  "extends": "openapi.json",
+	  "actions": [
+	    {"target": "$.servers", "description": "add the production server", "update": {"url": "https://api.example.com"}},
+	    {"target": "$.paths[?@.get.deprecated == true]", "description": "remove the deprecated paths", "remove": true},
+	    {"target": "$.paths['/pets'].get", "update": {"summary": "List all pets", "tags": ["pets"]}},
+	    {"target": "$.info", "update": {"x-audience": "public"}}
+	  ]
+	}`)
+
+	require.NoError(t, overlay.Apply(doc, ov))
+	require.Len(t, doc.Servers, 2)
+	require.Equal(t, "https://dev.example.com", doc.Servers[0].Spec.URL)
+	require.Equal(t, "https://api.example.com", doc.Servers[1].Spec.URL)
+	require.Len(t, doc.Paths.Spec.Paths, 1)
+	get := doc.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec
+	require.Equal(t, "listPets", get.OperationID)
+	require.Equal(t, "List all pets", get.Summary)
+	require.Equal(t, []string{"pets"}, get.Tags)
+	require.Equal(t, "public", doc.Info.Extensions["x-audience"])
+}
+
+func TestApply_Targets(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		target string
+		ops    []string
+	}{
+		{name: "name", target: "$.paths./pets.get", ops: []string{"listPetsV1"}},
+		{name: "quoted", target: `$.paths["/pets"]["get"]`, ops: []string{"listPetsV1"}},
+		{name: "wildcard", target: "$.paths.*.get", ops: nil},
+		{name: "descendant", target: "$..get", ops: nil},
+		{name: "filter existence", target: "$.paths.*[?@.deprecated]", ops: []string{"listPets"}},
+		{name: "filter not equal", target: "$.paths.*[?(@.operationId != 'listPets')]", ops: []string{"listPets"}},
+		{name: "not found", target: "$.paths['/users']", ops: []string{"listPets", "listPetsV1"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parse[openapi.OpenAPI](t, testSpec)
+			ov := &overlay.Overlay{
+				Overlay: "1.0.0",
+				Actions: []*openapi.Extendable[overlay.Action]{
+					openapi.NewExtendable(&overlay.Action{Target: tt.target, Remove: true}),
+				},
+			}
+			require.NoError(t, overlay.Apply(doc, ov))
+			var ops []string
+			for _, item := range doc.Paths.Spec.Paths {
+				if item.Spec.Spec.Get != nil {
+					ops = append(ops, item.Spec.Spec.Get.Spec.OperationID)
+				}
+			}
+			if len(ops) == 2 && ops[0] > ops[1] {
+				ops[0], ops[1] = ops[1], ops[0]
+			}
+			require.Equal(t, tt.ops, ops)
+		})
+	}
+}
+
+func TestApply_RemoveArrayItems(t *testing.T) {
+	for _, tt := range []struct {
+		target  string
+		servers []string
+	}{
+		{target: "$.servers[-1,0]", servers: []string{"b"}},
+		{target: "$.servers[0,0]", servers: []string{"b", "c"}},
+		{target: "$.servers[1,0]", servers: []string{"c"}},
+		{target: "$.servers[0,2,1]", servers: nil},
+		{target: "$.servers[?@.url == 'b', 0]", servers: []string{"c"}},
+	} {
+		t.Run(tt.target, func(t *testing.T) {
+			doc := parse[openapi.OpenAPI](t, `{
+			  "openapi": "3.1.1",
+			  "info": {"title": "Pets", "version": "1.0.0"},
+			  "servers": [{"url": "a"}, {"url": "b"}, {"url": "c"}]
+			}`)
+			ov := &overlay.Overlay{
+				Overlay: "1.0.0",
+				Actions: []*openapi.Extendable[overlay.Action]{
+					openapi.NewExtendable(&overlay.Action{Target: tt.target, Remove: true}),
+				},
+			}
+			require.NoError(t, overlay.Apply(doc, ov))
+			var servers []string
+			for _, s := range doc.Servers {
+				servers = append(servers, s.Spec.URL)
+			}
+			require.Equal(t, tt.servers, servers)
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		ov       *overlay.Overlay
+		err      string
+		sentinel error
+	}{
+		{
+			name:     "version",
+			ov:       &overlay.Overlay{Overlay: "2.0.0"},
+			err:      `unsupported overlay version: "2.0.0"`,
+			sentinel: overlay.ErrUnsupportedVersion,
+		},
+		{
+			name: "no target",
+			ov: &overlay.Overlay{Overlay: "1.0.0", Actions: []*openapi.Extendable[overlay.Action]{
+				openapi.NewExtendable(&overlay.Action{Remove: true}),
+			}},
+			err:      "actions/0: target is required",
+			sentinel: overlay.ErrTargetRequired,
+		},
+		{
+			name: "invalid target",
+			ov: &overlay.Overlay{Overlay: "1.0.0", Actions: []*openapi.Extendable[overlay.Action]{
+				openapi.NewExtendable(&overlay.Action{Target: "$.paths[", Remove: true}),
+			}},
+			err: "actions/0: invalid JSONPath",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parse[openapi.OpenAPI](t, testSpec)
+			err := overlay.Apply(doc, tt.ov)
+			require.ErrorContains(t, err, tt.err)
+			if tt.sentinel != nil {
+				require.Equal(t, true, errors.Is(err, tt.sentinel))
+			}
+			require.Len(t, doc.Paths.Spec.Paths, 2)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -155,7 +156,8 @@ type Parameter struct {
 	// For other types of parameters this property has no effect.
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
-	Explode bool `json:"explode"`
+	// The field is nil if it is not set, then the default value of the style is used.
+	Explode *bool `json:"explode,omitempty"`
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by [RFC3986]
 	//   :/?#[]@!$&'()*+,;=
 	// to be included without percent-encoding.
@@ -177,22 +179,60 @@ type Parameter struct {
 	Required bool `json:"required,omitempty"`
 }
 
+// effectiveExplode returns the explode field if it is set, otherwise the default value of the style:
+// true for form style and false for all other styles.
+func (o *Parameter) effectiveExplode() bool {
+	if o.Explode != nil {
+		return *o.Explode
+	}
+	return o.effectiveStyle() == StyleForm
+}
+
+// key returns the identifier of the parameter, which is a combination of the location and the name,
+// the name of a header is case-insensitive.
+func (o *Parameter) key() string {
+	if o.In == InHeader {
+		return o.In + ":" + strings.ToLower(o.Name)
+	}
+	return o.In + ":" + o.Name
+}
+
+// ignoredHeaders are the header parameters, which SHALL be ignored, because they are described by other fields:
+// the media types of the request body and the responses, and the security schemes.
+var ignoredHeaders = []string{"Accept", "Content-Type", "Authorization"}
+
+// validateParameterDuplicates reports the parameters with the same name and location declared in the same list.
+func validateParameterDuplicates(location string, params []*RefOrSpec[Extendable[Parameter]], validator *Validator) []*validationError {
+	var errs []*validationError
+	declared := make(map[string]int, len(params))
+	for i, v := range params {
+		param, err := v.GetSpecCached(validator.cache)
+		if err != nil {
+			// the broken references are reported by the parameter validation
+			continue
+		}
+		key := param.Spec.key()
+		if j, found := declared[key]; found {
+			errs = append(errs, newValidationError(joinLoc(location, "parameters", i), "duplicate of '%s' parameter '%s' declared at %s", param.Spec.In, param.Spec.Name, joinLoc(location, "parameters", j)))
+			continue
+		}
+		declared[key] = i
+	}
+	return errs
+}
+
 func (o *Parameter) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 	if o.Schema != nil && o.Content != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "schema&content"), ErrMutuallyExclusive))
 	}
-	if o.Example != nil && len(o.Examples) > 0 {
-		errs = append(errs, newValidationError(joinLoc(location, "example&examples"), ErrMutuallyExclusive))
-	}
+	errs = append(errs, validateExamples(location, o.Example, o.Examples, validator)...)
 
 	if l := len(o.Content); l > 0 {
 		if l != 1 {
 			errs = append(errs, newValidationError(joinLoc(location, "content"), "invalid number of items, expected only one, but got '%d'", l))
 		}
-		for k, v := range o.Content {
-			errs = append(errs, v.validateSpec(joinLoc(location, "content", k), validator)...)
-		}
+		errs = append(errs, validateContent(joinLoc(location, "content"), o.Content, validator)...)
 	}
 	if o.Schema != nil {
 		errs = append(errs, o.Schema.validateSpec(joinLoc(location, "schema"), validator)...)
@@ -233,16 +273,28 @@ func (o *Parameter) validateSpec(location string, validator *Validator) []*valid
 		errs = append(errs, newValidationError(joinLoc(location, "name"), ErrRequired))
 	case o.In == InPath && !PathNamePattern.MatchString(o.Name):
 		errs = append(errs, newValidationError(joinLoc(location, "name"), "must match pattern '%s', but got '%s'", PathNamePattern, o.Name))
+	case o.In == InHeader && slices.ContainsFunc(ignoredHeaders, func(h string) bool { return strings.EqualFold(h, o.Name) }):
+		errs = append(errs, newValidationWarning(joinLoc(location, "name"), "'%s' header parameter is ignored, use the request body, responses or security schemes instead", o.Name))
 	case !o.AllowReserved && o.In == InQuery && strings.ContainsAny(o.Name, ReservedCharacters):
-		errs = append(errs, newValidationError(joinLoc(location, "name"), "'%s' contains reserved characters: '%s'", o.Name, ReservedCharacters))
+		errs = append(errs, newValidationWarning(joinLoc(location, "name"), "'%s' contains reserved characters: '%s'", o.Name, ReservedCharacters))
 	}
 
 	if o.AllowReserved && o.In != InQuery {
 		errs = append(errs, newValidationError(joinLoc(location, "allowReserved"), "only allowed when `in` is '%s'", InQuery))
 	}
 
-	if o.AllowEmptyValue && o.In != InQuery {
-		errs = append(errs, newValidationError(joinLoc(location, "allowEmptyValue"), "only allowed when `in` is '%s'", InQuery))
+	if o.In == InCookie && o.effectiveExplode() && o.Schema != nil {
+		if schema, err := o.Schema.GetSpecCached(validator.cache); err == nil && schemaType(schema) == ArrayType {
+			errs = append(errs, newValidationWarning(joinLoc(location, "explode"), "exploded arrays are poorly supported in cookies, the clients may keep only one of the cookies with the same name"))
+		}
+	}
+
+	if o.AllowEmptyValue {
+		if o.In != InQuery {
+			errs = append(errs, newValidationError(joinLoc(location, "allowEmptyValue"), "only allowed when `in` is '%s'", InQuery))
+		} else {
+			errs = append(errs, newValidationWarning(joinLoc(location, "allowEmptyValue"), "usage is not recommended and it is likely to be removed in a later revision"))
+		}
 	}
 
 	if !o.Required && o.In == InPath {
@@ -274,7 +326,7 @@ func (o *Parameter) validateSpec(location string, validator *Validator) []*valid
 	}
 	if len(o.Examples) > 0 {
 		for k, v := range o.Examples {
-			example, err := v.GetSpec(validator.spec.Spec.Components)
+			example, err := v.GetSpecCached(validator.cache)
 			if err != nil {
 				// do not add the error, because it is already validated earlier
 				continue
@@ -303,6 +355,15 @@ func (b *ParameterBuilder) Build() *RefOrSpec[Extendable[Parameter]] {
 	return b.spec
 }
 
+// BuildValid validates and returns the built object, or the validation errors if the object is invalid.
+// The object is validated standalone, so the references and the examples are not checked.
+func (b *ParameterBuilder) BuildValid() (*RefOrSpec[Extendable[Parameter]], error) {
+	if err := validateDetached("", b.spec); err != nil {
+		return nil, err
+	}
+	return b.spec, nil
+}
+
 func (b *ParameterBuilder) Extensions(v map[string]any) *ParameterBuilder {
 	b.spec.Spec.Extensions = v
 	return b
@@ -370,7 +431,7 @@ func (b *ParameterBuilder) Name(v string) *ParameterBuilder {
 }
 
 func (b *ParameterBuilder) Explode(v bool) *ParameterBuilder {
-	b.spec.Spec.Spec.Explode = v
+	b.spec.Spec.Spec.Explode = &v
 	return b
 }
 
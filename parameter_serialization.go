@@ -0,0 +1,713 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidValue is returned if the value of a parameter cannot be serialized or deserialized.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrUnsupportedStyle is returned if the style of a parameter cannot be used for the value or the location.
+	ErrUnsupportedStyle = errors.New("unsupported style")
+)
+
+// keyValue is a single property of an object value.
+type keyValue struct {
+	key   string
+	value string
+}
+
+// effectiveStyle returns the style of the parameter or the default style based on the location.
+func (o *Parameter) effectiveStyle() string {
+	if o.Style != "" {
+		return o.Style
+	}
+	switch o.In {
+	case InQuery, InCookie:
+		return StyleForm
+	default:
+		return StyleSimple
+	}
+}
+
+// SerializeValue renders the given value according to the parameter's `style`, `explode` and `in` fields.
+//
+// https://spec.openapis.org/oas/v3.1.1#style-examples
+//
+// The value can be a primitive (string, number, boolean or nil), a slice or array (an array value)
+// or a map with string keys or a struct (an object value); the properties of an object are sorted by name.
+// The result for `form`, `spaceDelimited`, `pipeDelimited` and `deepObject` styles includes the name of the parameter,
+// e.g. `color=blue&color=black`, so it can be appended to the query string as is;
+// the result for `simple` style contains the value only, e.g. `blue,black`.
+//
+// The `cookie` parameters of `form` style are rendered as the content of the `Cookie` header,
+// so the pairs are separated by `; ` instead of `&`, e.g. `color=blue; color=black`.
+//
+// The values of the `path` and `query` parameters are percent-encoded,
+// the reserved characters are kept as is for the `query` parameters with `allowReserved` set to true.
+// Only the characters, which are not allowed in the cookie values (`;`, `,`, space, `"` and `%` itself),
+// are percent-encoded for the `cookie` parameters.
+func (o *Parameter) SerializeValue(v any) (string, error) {
+	style := o.effectiveStyle()
+	explode := o.effectiveExplode()
+	escape := o.escapeFunc()
+	name := escape(o.Name)
+
+	primitive, array, object, err := splitValue(v)
+	if err != nil {
+		return "", fmt.Errorf("parameter %q: %w", o.Name, err)
+	}
+	for i := range array {
+		array[i] = escape(array[i])
+	}
+	for i := range object {
+		object[i].key = escape(object[i].key)
+		object[i].value = escape(object[i].value)
+	}
+	isPrimitive := array == nil && object == nil
+	primitive = escape(primitive)
+
+	switch style {
+	case StyleMatrix:
+		switch {
+		case isPrimitive && primitive == "", array != nil && len(array) == 0:
+			// the empty values have no `=` suffix
+			return ";" + name, nil
+		case isPrimitive:
+			return ";" + name + "=" + primitive, nil
+		case array != nil && explode:
+			return ";" + name + "=" + strings.Join(array, ";"+name+"="), nil
+		case array != nil:
+			return ";" + name + "=" + strings.Join(array, ","), nil
+		case explode:
+			return ";" + joinObject(object, "=", ";"), nil
+		default:
+			return ";" + name + "=" + joinObject(object, ",", ","), nil
+		}
+	case StyleLabel:
+		switch {
+		case isPrimitive:
+			return "." + primitive, nil
+		case array != nil && explode:
+			return "." + strings.Join(array, "."), nil
+		case array != nil:
+			return "." + strings.Join(array, ","), nil
+		case explode:
+			return "." + joinObject(object, "=", "."), nil
+		default:
+			return "." + joinObject(object, ",", ","), nil
+		}
+	case StyleForm:
+		sep := "&"
+		if o.In == InCookie {
+			sep = "; "
+		}
+		switch {
+		case isPrimitive:
+			return name + "=" + primitive, nil
+		case array != nil && explode:
+			return name + "=" + strings.Join(array, sep+name+"="), nil
+		case array != nil:
+			return name + "=" + strings.Join(array, ","), nil
+		case explode:
+			return joinObject(object, "=", sep), nil
+		default:
+			return name + "=" + joinObject(object, ",", ","), nil
+		}
+	case StyleSimple:
+		switch {
+		case isPrimitive:
+			return primitive, nil
+		case array != nil:
+			return strings.Join(array, ","), nil
+		case explode:
+			return joinObject(object, "=", ","), nil
+		default:
+			return joinObject(object, ",", ","), nil
+		}
+	case StyleSpaceDelimited, StylePipeDelimited:
+		sep := "%20"
+		if style == StylePipeDelimited {
+			sep = "|"
+		}
+		switch {
+		case isPrimitive:
+			return "", fmt.Errorf("parameter %q: %w: style '%s' does not support primitive values", o.Name, ErrInvalidValue, style)
+		case array != nil && explode:
+			return name + "=" + strings.Join(array, "&"+name+"="), nil
+		case array != nil:
+			return name + "=" + strings.Join(array, sep), nil
+		default:
+			return name + "=" + joinObject(object, sep, sep), nil
+		}
+	case StyleDeepObject:
+		if object == nil {
+			return "", fmt.Errorf("parameter %q: %w: style '%s' supports object values only", o.Name, ErrInvalidValue, style)
+		}
+		parts := make([]string, len(object))
+		for i, kv := range object {
+			parts[i] = name + "[" + kv.key + "]=" + kv.value
+		}
+		return strings.Join(parts, "&"), nil
+	default:
+		return "", fmt.Errorf("parameter %q: %w '%s'", o.Name, ErrUnsupportedStyle, style)
+	}
+}
+
+// escapeFunc returns the function to percent-encode the values depending on the parameter location.
+func (o *Parameter) escapeFunc() func(string) string {
+	switch o.In {
+	case InPath:
+		return func(s string) string { return percentEncode(s, false) }
+	case InQuery:
+		return func(s string) string { return percentEncode(s, o.AllowReserved) }
+	case InCookie:
+		return cookieEscaper.Replace
+	default:
+		return func(s string) string { return s }
+	}
+}
+
+// percentEncode encodes all characters except the unreserved ones as defined in RFC3986,
+// and the reserved ones if allowReserved is true.
+func percentEncode(s string, allowReserved bool) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case allowReserved && strings.IndexByte(ReservedCharacters, c) >= 0:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0F])
+		}
+	}
+	return b.String()
+}
+
+func joinObject(object []keyValue, kvSep, sep string) string {
+	parts := make([]string, len(object))
+	for i, kv := range object {
+		parts[i] = kv.key + kvSep + kv.value
+	}
+	return strings.Join(parts, sep)
+}
+
+// splitValue converts the given value into the string representation of a primitive, an array or an object value.
+// The array is nil for non-array values and the object is nil for non-object values.
+func splitValue(v any) (primitive string, array []string, object []keyValue, err error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "", nil, nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return "", nil, nil, nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), nil, nil, nil
+		}
+		array = make([]string, rv.Len())
+		for i := range rv.Len() {
+			if array[i], err = formatPrimitive(rv.Index(i)); err != nil {
+				return "", nil, nil, err
+			}
+		}
+		return "", array, nil, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return "", nil, nil, fmt.Errorf("%w: unsupported map key type %s", ErrInvalidValue, rv.Type().Key())
+		}
+		object = make([]keyValue, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			value, err := formatPrimitive(iter.Value())
+			if err != nil {
+				return "", nil, nil, err
+			}
+			object = append(object, keyValue{key: iter.Key().String(), value: value})
+		}
+		slices.SortFunc(object, func(a, b keyValue) int { return strings.Compare(a.key, b.key) })
+		return "", nil, object, nil
+	case reflect.Struct:
+		// use the json representation of the struct to respect the field tags
+		data, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return "", nil, nil, err
+		}
+		var m map[string]any
+		if err := json.Unmarshal(data, &m); err != nil {
+			return "", nil, nil, err
+		}
+		return splitValue(m)
+	default:
+		primitive, err = formatPrimitive(rv)
+		return primitive, nil, nil, err
+	}
+}
+
+func formatPrimitive(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported value type %s, expected a primitive", ErrInvalidValue, v.Type())
+	}
+}
+
+// DeserializeValue parses the given raw value according to the parameter's `style`, `explode` and `in` fields.
+// It is the reverse operation of SerializeValue, so the raw value is expected in the same form,
+// e.g. `;color=blue,black` for `matrix` style or `color=blue&color=black` for exploded `form` style.
+// The raw value of a `cookie` parameter of `form` style is the content of the `Cookie` header,
+// e.g. `color=blue; color=black; session=1`, the other cookies are ignored.
+//
+// The result is a primitive, `[]any` or `map[string]any` depending on the type of the parameter's schema.
+// The primitives are converted to int64, float64, bool or string according to the schema type,
+// the items and properties schemas are used for the values of arrays and objects.
+// The schema must be inline, the references are not resolved; a string value is returned if the type is unknown.
+func (o *Parameter) DeserializeValue(raw string) (any, error) {
+	switch o.effectiveStyle() {
+	case StyleForm, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject:
+		if o.In == InCookie {
+			return o.DeserializeValues(parseCookies(raw))
+		}
+		values, err := url.ParseQuery(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", o.Name, err)
+		}
+		return o.DeserializeValues(values)
+	}
+
+	unescape := func(s string) (string, error) { return s, nil }
+	if o.In == InPath {
+		unescape = url.PathUnescape
+	}
+	schema := o.inlineSchema()
+	typ := schemaType(schema)
+	explode := o.effectiveExplode()
+
+	var (
+		tokens []string
+		pairs  bool // the tokens are in `key=value` form
+	)
+	switch style := o.effectiveStyle(); style {
+	case StyleMatrix:
+		if !strings.HasPrefix(raw, ";") {
+			return nil, fmt.Errorf("parameter %q: %w: style '%s' value must start with ';', but got '%s'", o.Name, ErrInvalidValue, style, raw)
+		}
+		segments := strings.Split(raw[1:], ";")
+		if typ == ObjectType && explode {
+			tokens, pairs = segments, true
+			break
+		}
+		for _, segment := range segments {
+			name, value, _ := strings.Cut(segment, "=")
+			if name != o.Name {
+				return nil, fmt.Errorf("parameter %q: %w: unexpected name '%s'", o.Name, ErrInvalidValue, name)
+			}
+			if typ == ArrayType && explode {
+				tokens = append(tokens, value)
+			} else {
+				tokens = append(tokens, strings.Split(value, ",")...)
+			}
+		}
+	case StyleLabel:
+		if !strings.HasPrefix(raw, ".") {
+			return nil, fmt.Errorf("parameter %q: %w: style '%s' value must start with '.', but got '%s'", o.Name, ErrInvalidValue, style, raw)
+		}
+		raw = raw[1:]
+		switch {
+		case typ != ArrayType && typ != ObjectType:
+			tokens = []string{raw}
+		case explode:
+			tokens, pairs = strings.Split(raw, "."), typ == ObjectType
+		default:
+			tokens = strings.Split(raw, ",")
+		}
+	case StyleSimple:
+		switch {
+		case typ != ArrayType && typ != ObjectType:
+			tokens = []string{raw}
+		default:
+			tokens, pairs = strings.Split(raw, ","), explode && typ == ObjectType
+		}
+	default:
+		return nil, fmt.Errorf("parameter %q: %w '%s'", o.Name, ErrUnsupportedStyle, style)
+	}
+
+	// the empty arrays are rendered without values, e.g. `;color`, `.` or an empty string
+	if typ == ArrayType && len(tokens) == 1 && tokens[0] == "" {
+		tokens = tokens[:0]
+	}
+	for i := range tokens {
+		var err error
+		if tokens[i], err = unescape(tokens[i]); err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", o.Name, err)
+		}
+	}
+
+	switch typ {
+	case ArrayType:
+		return convertArray(o.Name, tokens, schema)
+	case ObjectType:
+		if pairs {
+			return convertPairs(o.Name, tokens, schema)
+		}
+		return convertObject(o.Name, tokens, schema)
+	default:
+		return convertPrimitive(o.Name, strings.Join(tokens, ","), schema)
+	}
+}
+
+// DeserializeValues parses the value of the parameter from the given query values
+// according to the parameter's `style` and `explode` fields.
+// It supports `form`, `spaceDelimited`, `pipeDelimited` and `deepObject` styles only.
+//
+// The exploded objects of `form` style use all properties of the parameter's schema,
+// or all the values if the schema allows additional properties.
+func (o *Parameter) DeserializeValues(values url.Values) (any, error) {
+	schema := o.inlineSchema()
+	typ := schemaType(schema)
+	explode := o.effectiveExplode()
+	// the query values are decoded by url.ParseQuery, but the cookie values are not
+	unescape := func(s string) string { return s }
+	if o.In == InCookie {
+		unescape = cookieUnescaper.Replace
+	}
+
+	switch style := o.effectiveStyle(); style {
+	case StyleForm:
+		switch {
+		case typ == ArrayType && explode:
+			if !values.Has(o.Name) {
+				return nil, nil
+			}
+			tokens := make([]string, len(values[o.Name]))
+			for i, v := range values[o.Name] {
+				tokens[i] = unescape(v)
+			}
+			return convertArray(o.Name, tokens, schema)
+		case typ == ObjectType && explode:
+			object := make(map[string]any)
+			for k, v := range values {
+				k = unescape(k)
+				propSchema, ok := propertySchema(schema, k)
+				if !ok || len(v) == 0 {
+					continue
+				}
+				value, err := convertPrimitive(o.Name, unescape(v[0]), propSchema)
+				if err != nil {
+					return nil, err
+				}
+				object[k] = value
+			}
+			if len(object) == 0 {
+				return nil, nil
+			}
+			return object, nil
+		}
+		if !values.Has(o.Name) {
+			return nil, nil
+		}
+		raw := values.Get(o.Name)
+		tokens := strings.Split(raw, ",")
+		for i := range tokens {
+			tokens[i] = unescape(tokens[i])
+		}
+		switch typ {
+		case ArrayType:
+			return convertArray(o.Name, tokens, schema)
+		case ObjectType:
+			return convertObject(o.Name, tokens, schema)
+		default:
+			return convertPrimitive(o.Name, unescape(raw), schema)
+		}
+	case StyleSpaceDelimited, StylePipeDelimited:
+		if !values.Has(o.Name) {
+			return nil, nil
+		}
+		sep := " "
+		if style == StylePipeDelimited {
+			sep = "|"
+		}
+		var tokens []string
+		if typ == ArrayType && explode {
+			tokens = values[o.Name]
+		} else {
+			tokens = strings.Split(values.Get(o.Name), sep)
+		}
+		switch typ {
+		case ArrayType:
+			return convertArray(o.Name, tokens, schema)
+		case ObjectType:
+			return convertObject(o.Name, tokens, schema)
+		default:
+			return nil, fmt.Errorf("parameter %q: %w: style '%s' does not support primitive values", o.Name, ErrInvalidValue, style)
+		}
+	case StyleDeepObject:
+		prefix := o.Name + "["
+		var object map[string]any
+		for k, v := range values {
+			if !strings.HasPrefix(k, prefix) || !strings.HasSuffix(k, "]") || len(v) == 0 {
+				continue
+			}
+			key := k[len(prefix) : len(k)-1]
+			propSchema, _ := propertySchema(schema, key)
+			value, err := convertPrimitive(o.Name, v[0], propSchema)
+			if err != nil {
+				return nil, err
+			}
+			if object == nil {
+				object = make(map[string]any)
+			}
+			object[key] = value
+		}
+		if object == nil {
+			return nil, nil
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("parameter %q: %w: style '%s' cannot be used with query values", o.Name, ErrUnsupportedStyle, style)
+	}
+}
+
+var (
+	// cookieEscaper percent-encodes the characters, which are not allowed in the cookie values or separate them.
+	cookieEscaper = strings.NewReplacer("%", "%25", ";", "%3B", ",", "%2C", " ", "%20", `"`, "%22")
+	// cookieUnescaper decodes the characters encoded by cookieEscaper, the other percent-encoded sequences are kept as is.
+	cookieUnescaper = strings.NewReplacer("%25", "%", "%3B", ";", "%3b", ";", "%2C", ",", "%2c", ",", "%20", " ", "%22", `"`)
+)
+
+// parseCookies returns the values of the cookies from the content of the `Cookie` header by their names,
+// the values are kept as is to be decoded after splitting them into the tokens, see DeserializeValues.
+func parseCookies(raw string) url.Values {
+	values := make(url.Values)
+	for _, pair := range strings.Split(raw, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || name == "" {
+			continue
+		}
+		values.Add(name, value)
+	}
+	return values
+}
+
+// inlineSchema returns the parameter's schema if it is defined inline, otherwise nil.
+func (o *Parameter) inlineSchema() *Schema {
+	if o.Schema == nil {
+		return nil
+	}
+	return o.Schema.Spec
+}
+
+// schemaType returns the first non-null type of the given schema,
+// or detects the type by the array or object specific fields.
+func schemaType(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Type != nil {
+		for _, t := range *schema.Type {
+			if t != NullType {
+				return t
+			}
+		}
+	}
+	switch {
+	case schema.Items != nil || len(schema.PrefixItems) > 0:
+		return ArrayType
+	case len(schema.Properties) > 0 || schema.AdditionalProperties != nil:
+		return ObjectType
+	default:
+		return ""
+	}
+}
+
+// propertySchema returns the inline schema of the given property of an object schema,
+// the second value is false if the property is not allowed by the schema.
+func propertySchema(schema *Schema, name string) (*Schema, bool) {
+	if schema == nil {
+		return nil, true
+	}
+	if prop, ok := schema.Properties[name]; ok {
+		return prop.Spec, true
+	}
+	if ap := schema.AdditionalProperties; ap != nil {
+		if ap.Schema != nil {
+			return ap.Schema.Spec, true
+		}
+		return nil, ap.Allowed
+	}
+	return nil, len(schema.Properties) == 0
+}
+
+func convertArray(name string, tokens []string, schema *Schema) ([]any, error) {
+	array := make([]any, len(tokens))
+	for i, token := range tokens {
+		var itemSchema *Schema
+		switch {
+		case schema == nil:
+		case i < len(schema.PrefixItems):
+			itemSchema = schema.PrefixItems[i].Spec
+		case schema.Items != nil && schema.Items.Schema != nil:
+			itemSchema = schema.Items.Schema.Spec
+		}
+		v, err := convertPrimitive(name, token, itemSchema)
+		if err != nil {
+			return nil, err
+		}
+		array[i] = v
+	}
+	return array, nil
+}
+
+// convertObject converts the list of tokens in form of `key1,value1,key2,value2` into an object.
+func convertObject(name string, tokens []string, schema *Schema) (map[string]any, error) {
+	if len(tokens)%2 != 0 {
+		return nil, fmt.Errorf("parameter %q: %w: odd number of object tokens '%d'", name, ErrInvalidValue, len(tokens))
+	}
+	object := make(map[string]any, len(tokens)/2)
+	for i := 0; i < len(tokens); i += 2 {
+		propSchema, _ := propertySchema(schema, tokens[i])
+		v, err := convertPrimitive(name, tokens[i+1], propSchema)
+		if err != nil {
+			return nil, err
+		}
+		object[tokens[i]] = v
+	}
+	return object, nil
+}
+
+// convertPairs converts the list of tokens in form of `key=value` into an object.
+func convertPairs(name string, tokens []string, schema *Schema) (map[string]any, error) {
+	object := make(map[string]any, len(tokens))
+	for _, token := range tokens {
+		key, value, found := strings.Cut(token, "=")
+		if !found {
+			return nil, fmt.Errorf("parameter %q: %w: expected 'key=value' pair, but got '%s'", name, ErrInvalidValue, token)
+		}
+		propSchema, _ := propertySchema(schema, key)
+		v, err := convertPrimitive(name, value, propSchema)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = v
+	}
+	return object, nil
+}
+
+// convertPrimitive converts the raw value into the first matched type of the given schema.
+func convertPrimitive(name, raw string, schema *Schema) (any, error) {
+	if schema == nil || schema.Type == nil {
+		return raw, nil
+	}
+	for _, t := range *schema.Type {
+		switch t {
+		case StringType:
+			return raw, nil
+		case IntegerType:
+			if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				return v, nil
+			}
+		case NumberType:
+			if v, err := strconv.ParseFloat(raw, 64); err == nil {
+				return v, nil
+			}
+		case BooleanType:
+			if v, err := strconv.ParseBool(raw); err == nil {
+				return v, nil
+			}
+		case NullType:
+			if raw == "" {
+				return nil, nil
+			}
+		default:
+			return raw, nil
+		}
+	}
+	return nil, fmt.Errorf("parameter %q: %w: unable to convert '%s' to %v", name, ErrInvalidValue, raw, []string(*schema.Type))
+}
+
+// BindQuery binds the given query values using the `query` parameters from the given list,
+// applying the style and explode rules of each parameter.
+// The other parameters are ignored.
+//
+// The result contains the values of the found parameters by their names.
+// The exploded objects of `form` style do not take the values of the other parameters from the list.
+// The errors are returned for the missing required parameters and for the empty values
+// of the parameters without `allowEmptyValue`.
+// The values are expected to be already decoded, e.g. by url.ParseQuery, so `allowReserved` is not checked:
+// it only defines whether the reserved characters are percent-encoded in the query string.
+func BindQuery(values url.Values, params []*Parameter) (map[string]any, []error) {
+	result := make(map[string]any)
+	var errs []error
+	declared := make(map[string]bool, len(params))
+	for _, p := range params {
+		if p != nil && p.In == InQuery {
+			declared[p.Name] = true
+		}
+	}
+	for _, p := range params {
+		if p == nil || p.In != InQuery {
+			continue
+		}
+		if values.Has(p.Name) && values.Get(p.Name) == "" && !p.AllowEmptyValue {
+			errs = append(errs, fmt.Errorf("parameter %q: %w: empty value is not allowed", p.Name, ErrInvalidValue))
+			continue
+		}
+		own := values
+		if p.effectiveStyle() == StyleForm && p.effectiveExplode() {
+			// the names of the object properties are not prefixed, so exclude the values of the other parameters
+			own = make(url.Values, len(values))
+			for k, v := range values {
+				if k == p.Name || !declared[k] {
+					own[k] = v
+				}
+			}
+		}
+		v, err := p.DeserializeValues(own)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if v == nil {
+			if p.Required {
+				errs = append(errs, fmt.Errorf("parameter %q: %w", p.Name, ErrRequired))
+			}
+			continue
+		}
+		result[p.Name] = v
+	}
+	return result, errs
+}
@@ -0,0 +1,471 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestParameter_SerializeValue(t *testing.T) {
+	var (
+		empty     = ""
+		primitive = "blue"
+		array     = []string{"blue", "black", "brown"}
+		object    = map[string]int{"R": 100, "G": 200, "B": 150}
+	)
+
+	// https://spec.openapis.org/oas/v3.1.1#style-examples
+	for _, tt := range []struct {
+		in       string
+		style    string
+		explode  bool
+		value    any
+		expected string
+	}{
+		{in: openapi.InPath, style: openapi.StyleMatrix, value: empty, expected: ";color"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, value: primitive, expected: ";color=blue"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, value: array, expected: ";color=blue,black,brown"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, value: object, expected: ";color=B,150,G,200,R,100"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, value: primitive, expected: ";color=blue"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, value: array, expected: ";color=blue;color=black;color=brown"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, value: object, expected: ";B=150;G=200;R=100"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, value: []int{3, 4, 5}, expected: ";color=3,4,5"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, value: []string{}, expected: ";color"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, value: []string{}, expected: ";color"},
+		{in: openapi.InPath, style: openapi.StyleLabel, value: empty, expected: "."},
+		{in: openapi.InPath, style: openapi.StyleLabel, value: primitive, expected: ".blue"},
+		{in: openapi.InPath, style: openapi.StyleLabel, value: array, expected: ".blue,black,brown"},
+		{in: openapi.InPath, style: openapi.StyleLabel, value: object, expected: ".B,150,G,200,R,100"},
+		{in: openapi.InPath, style: openapi.StyleLabel, explode: true, value: array, expected: ".blue.black.brown"},
+		{in: openapi.InPath, style: openapi.StyleLabel, explode: true, value: object, expected: ".B=150.G=200.R=100"},
+		{in: openapi.InPath, value: primitive, expected: "blue"},
+		{in: openapi.InPath, value: array, expected: "blue,black,brown"},
+		{in: openapi.InPath, value: object, expected: "B,150,G,200,R,100"},
+		{in: openapi.InPath, explode: true, value: object, expected: "B=150,G=200,R=100"},
+		{in: openapi.InPath, value: "a b/c", expected: "a%20b%2Fc"},
+		{in: openapi.InHeader, value: "a b/c", expected: "a b/c"},
+		{in: openapi.InQuery, value: empty, expected: "color="},
+		{in: openapi.InQuery, value: primitive, expected: "color=blue"},
+		{in: openapi.InQuery, value: array, expected: "color=blue,black,brown"},
+		{in: openapi.InQuery, value: object, expected: "color=B,150,G,200,R,100"},
+		{in: openapi.InQuery, explode: true, value: array, expected: "color=blue&color=black&color=brown"},
+		{in: openapi.InQuery, explode: true, value: object, expected: "B=150&G=200&R=100"},
+		{in: openapi.InQuery, style: openapi.StyleSpaceDelimited, value: array, expected: "color=blue%20black%20brown"},
+		{in: openapi.InQuery, style: openapi.StyleSpaceDelimited, value: object, expected: "color=B%20150%20G%20200%20R%20100"},
+		{in: openapi.InQuery, style: openapi.StylePipeDelimited, value: array, expected: "color=blue|black|brown"},
+		{in: openapi.InQuery, style: openapi.StylePipeDelimited, value: object, expected: "color=B|150|G|200|R|100"},
+		{in: openapi.InQuery, style: openapi.StyleDeepObject, explode: true, value: object, expected: "color[B]=150&color[G]=200&color[R]=100"},
+		{in: openapi.InQuery, value: struct {
+			Name string `json:"name"`
+			Age  int    `json:"age"`
+		}{Name: "John", Age: 42}, expected: "color=age,42,name,John"},
+		{in: openapi.InCookie, value: primitive, expected: "color=blue"},
+		{in: openapi.InCookie, value: array, expected: "color=blue,black,brown"},
+		{in: openapi.InCookie, explode: true, value: array, expected: "color=blue; color=black; color=brown"},
+		{in: openapi.InCookie, explode: true, value: object, expected: "B=150; G=200; R=100"},
+		{in: openapi.InQuery, value: 1.5, expected: "color=1.5"},
+		{in: openapi.InQuery, value: true, expected: "color=true"},
+		{in: openapi.InQuery, value: nil, expected: "color="},
+	} {
+		t.Run(fmt.Sprintf("%s %s explode=%t %v", tt.in, tt.style, tt.explode, tt.value), func(t *testing.T) {
+			p := openapi.NewParameterBuilder().Name("color").In(tt.in).Style(tt.style).Explode(tt.explode).Build().Spec.Spec
+			actual, err := p.SerializeValue(tt.value)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParameter_SerializeValue_AllowReserved(t *testing.T) {
+	p := &openapi.Parameter{Name: "redirect", In: openapi.InQuery}
+	actual, err := p.SerializeValue("https://example.com/?a=b")
+	require.NoError(t, err)
+	require.Equal(t, "redirect=https%3A%2F%2Fexample.com%2F%3Fa%3Db", actual)
+
+	p.AllowReserved = true
+	actual, err = p.SerializeValue("https://example.com/?a=b c")
+	require.NoError(t, err)
+	require.Equal(t, "redirect=https://example.com/?a=b%20c", actual)
+}
+
+func TestParameter_SerializeValue_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param *openapi.Parameter
+		value any
+		err   string
+	}{
+		{
+			name:  "spaceDelimited primitive",
+			param: &openapi.Parameter{Name: "color", In: openapi.InQuery, Style: openapi.StyleSpaceDelimited},
+			value: "blue",
+			err:   "does not support primitive values",
+		},
+		{
+			name:  "deepObject array",
+			param: &openapi.Parameter{Name: "color", In: openapi.InQuery, Style: openapi.StyleDeepObject},
+			value: []string{"blue"},
+			err:   "supports object values only",
+		},
+		{
+			name:  "nested array",
+			param: &openapi.Parameter{Name: "color", In: openapi.InQuery},
+			value: [][]string{{"blue"}},
+			err:   "expected a primitive",
+		},
+		{
+			name:  "unknown style",
+			param: &openapi.Parameter{Name: "color", In: openapi.InQuery, Style: "foo"},
+			value: "blue",
+			err:   "unsupported style 'foo'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.param.SerializeValue(tt.value)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestParameter_DeserializeValue(t *testing.T) {
+	var (
+		primitiveSchema = openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+		arraySchema     = openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(
+			openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()),
+		).Build()
+		intArraySchema = openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(
+			openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()),
+		).Build()
+		objectSchema = openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+				AddProperty("R", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+				AddProperty("G", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+				AddProperty("B", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+				Build()
+
+		primitive = "blue"
+		array     = []any{"blue", "black", "brown"}
+		object    = map[string]any{"R": int64(100), "G": int64(200), "B": int64(150)}
+	)
+
+	for _, tt := range []struct {
+		in       string
+		style    string
+		explode  bool
+		schema   *openapi.RefOrSpec[openapi.Schema]
+		raw      string
+		expected any
+	}{
+		{in: openapi.InPath, style: openapi.StyleMatrix, schema: primitiveSchema, raw: ";color", expected: ""},
+		{in: openapi.InPath, style: openapi.StyleMatrix, schema: primitiveSchema, raw: ";color=blue", expected: primitive},
+		{in: openapi.InPath, style: openapi.StyleMatrix, schema: arraySchema, raw: ";color=blue,black,brown", expected: array},
+		{in: openapi.InPath, style: openapi.StyleMatrix, schema: objectSchema, raw: ";color=R,100,G,200,B,150", expected: object},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, schema: arraySchema, raw: ";color=blue;color=black;color=brown", expected: array},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, schema: objectSchema, raw: ";R=100;G=200;B=150", expected: object},
+		{in: openapi.InPath, style: openapi.StyleMatrix, schema: intArraySchema, raw: ";color=3,4,5", expected: []any{int64(3), int64(4), int64(5)}},
+		{in: openapi.InPath, style: openapi.StyleLabel, schema: primitiveSchema, raw: ".blue", expected: primitive},
+		{in: openapi.InPath, style: openapi.StyleLabel, schema: arraySchema, raw: ".blue,black,brown", expected: array},
+		{in: openapi.InPath, style: openapi.StyleLabel, schema: objectSchema, raw: ".R,100,G,200,B,150", expected: object},
+		{in: openapi.InPath, style: openapi.StyleLabel, explode: true, schema: arraySchema, raw: ".blue.black.brown", expected: array},
+		{in: openapi.InPath, style: openapi.StyleLabel, explode: true, schema: objectSchema, raw: ".R=100.G=200.B=150", expected: object},
+		{in: openapi.InPath, schema: primitiveSchema, raw: "a%20b%2Fc", expected: "a b/c"},
+		{in: openapi.InPath, schema: arraySchema, raw: "blue,black,brown", expected: array},
+		{in: openapi.InPath, schema: objectSchema, raw: "R,100,G,200,B,150", expected: object},
+		{in: openapi.InPath, explode: true, schema: objectSchema, raw: "R=100,G=200,B=150", expected: object},
+		{in: openapi.InHeader, schema: arraySchema, raw: "blue,black,brown", expected: array},
+		{in: openapi.InQuery, schema: primitiveSchema, raw: "color=", expected: ""},
+		{in: openapi.InQuery, schema: primitiveSchema, raw: "color=blue", expected: primitive},
+		{in: openapi.InQuery, schema: arraySchema, raw: "color=blue,black,brown", expected: array},
+		{in: openapi.InQuery, schema: objectSchema, raw: "color=R,100,G,200,B,150", expected: object},
+		{in: openapi.InQuery, explode: true, schema: arraySchema, raw: "color=blue&color=black&color=brown", expected: array},
+		{in: openapi.InQuery, explode: true, schema: objectSchema, raw: "R=100&G=200&B=150&other=1", expected: object},
+		{in: openapi.InQuery, style: openapi.StyleSpaceDelimited, schema: arraySchema, raw: "color=blue%20black%20brown", expected: array},
+		{in: openapi.InQuery, style: openapi.StyleSpaceDelimited, schema: objectSchema, raw: "color=R%20100%20G%20200%20B%20150", expected: object},
+		{in: openapi.InQuery, style: openapi.StylePipeDelimited, schema: arraySchema, raw: "color=blue|black|brown", expected: array},
+		{in: openapi.InQuery, style: openapi.StylePipeDelimited, schema: objectSchema, raw: "color=R|100|G|200|B|150", expected: object},
+		{in: openapi.InQuery, style: openapi.StyleDeepObject, explode: true, schema: objectSchema, raw: "color[R]=100&color[G]=200&color[B]=150&other=1", expected: object},
+		{in: openapi.InCookie, schema: primitiveSchema, raw: "session=1; color=blue", expected: primitive},
+		{in: openapi.InCookie, schema: arraySchema, raw: "color=blue,black,brown", expected: array},
+		{in: openapi.InCookie, explode: true, schema: arraySchema, raw: "color=blue; color=black;color=brown", expected: array},
+		{in: openapi.InCookie, explode: true, schema: objectSchema, raw: "R=100; G=200; B=150; session=1", expected: object},
+		{in: openapi.InCookie, schema: primitiveSchema, raw: "color=a+b%20c%2F", expected: "a+b c%2F"},
+		{in: openapi.InCookie, schema: arraySchema, raw: "color=a%3Bb,c%2Cd", expected: []any{"a;b", "c,d"}},
+		{in: openapi.InQuery, raw: "color=1", expected: "1"},
+		{in: openapi.InQuery, schema: primitiveSchema, raw: "other=1", expected: nil},
+	} {
+		t.Run(fmt.Sprintf("%s %s explode=%t %s", tt.in, tt.style, tt.explode, tt.raw), func(t *testing.T) {
+			p := openapi.NewParameterBuilder().Name("color").In(tt.in).Style(tt.style).Explode(tt.explode).Schema(tt.schema).Build().Spec.Spec
+			actual, err := p.DeserializeValue(tt.raw)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParameter_RoundTrip_Cookie(t *testing.T) {
+	arraySchema := openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(
+		openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()),
+	).Build()
+	for _, tt := range []struct {
+		explode    bool
+		schema     *openapi.RefOrSpec[openapi.Schema]
+		value      any
+		serialized string
+	}{
+		{value: "a;b", serialized: "color=a%3Bb"},
+		{value: `say "hi", 100%`, serialized: "color=say%20%22hi%22%2C%20100%25"},
+		{schema: arraySchema, value: []any{"a;b", "c,d"}, serialized: "color=a%3Bb,c%2Cd"},
+		{explode: true, schema: arraySchema, value: []any{"a;b", "c d"}, serialized: "color=a%3Bb; color=c%20d"},
+	} {
+		t.Run(tt.serialized, func(t *testing.T) {
+			p := openapi.NewParameterBuilder().Name("color").In(openapi.InCookie).Explode(tt.explode).Schema(tt.schema).Build().Spec.Spec
+			serialized, err := p.SerializeValue(tt.value)
+			require.NoError(t, err)
+			require.Equal(t, tt.serialized, serialized)
+
+			actual, err := p.DeserializeValue(serialized)
+			require.NoError(t, err)
+			require.Equal(t, tt.value, actual)
+		})
+	}
+}
+
+func TestParameter_RoundTrip_EmptyArray(t *testing.T) {
+	schema := openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(
+		openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()),
+	).Build()
+	for _, tt := range []struct {
+		in         string
+		style      string
+		explode    bool
+		serialized string
+	}{
+		{in: openapi.InPath, style: openapi.StyleMatrix, serialized: ";color"},
+		{in: openapi.InPath, style: openapi.StyleMatrix, explode: true, serialized: ";color"},
+		{in: openapi.InPath, style: openapi.StyleLabel, serialized: "."},
+		{in: openapi.InPath, style: openapi.StyleLabel, explode: true, serialized: "."},
+		{in: openapi.InPath, style: openapi.StyleSimple, serialized: ""},
+		{in: openapi.InHeader, style: openapi.StyleSimple, explode: true, serialized: ""},
+	} {
+		t.Run(fmt.Sprintf("%s %s explode=%t", tt.in, tt.style, tt.explode), func(t *testing.T) {
+			p := openapi.NewParameterBuilder().Name("color").In(tt.in).Style(tt.style).Explode(tt.explode).Schema(schema).Build().Spec.Spec
+			serialized, err := p.SerializeValue([]any{})
+			require.NoError(t, err)
+			require.Equal(t, tt.serialized, serialized)
+
+			actual, err := p.DeserializeValue(serialized)
+			require.NoError(t, err)
+			require.Equal(t, []any{}, actual)
+		})
+	}
+}
+
+func TestParameter_ExplodeDefault(t *testing.T) {
+	array := []any{"blue", "black"}
+	for _, tt := range []struct {
+		doc        string
+		serialized string
+		raw        string
+	}{
+		{
+			doc:        `{"name": "color", "in": "query", "schema": {"type": "array"}}`,
+			serialized: "color=blue&color=black",
+			raw:        "color=blue&color=black",
+		},
+		{
+			doc:        `{"name": "color", "in": "cookie", "schema": {"type": "array"}}`,
+			serialized: "color=blue; color=black",
+			raw:        "color=blue; color=black",
+		},
+		{
+			doc:        `{"name": "color", "in": "query", "explode": false, "schema": {"type": "array"}}`,
+			serialized: "color=blue,black",
+			raw:        "color=blue,black",
+		},
+		{
+			doc:        `{"name": "color", "in": "path", "required": true, "schema": {"type": "array"}}`,
+			serialized: "blue,black",
+			raw:        "blue,black",
+		},
+	} {
+		t.Run(tt.doc, func(t *testing.T) {
+			var p openapi.Parameter
+			require.NoError(t, json.Unmarshal([]byte(tt.doc), &p))
+
+			serialized, err := p.SerializeValue(array)
+			require.NoError(t, err)
+			require.Equal(t, tt.serialized, serialized)
+
+			actual, err := p.DeserializeValue(tt.raw)
+			require.NoError(t, err)
+			require.Equal(t, array, actual)
+
+			data, err := json.Marshal(&p)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.doc, string(data))
+
+			serialized, err = openapi.DeepCopy(&p).SerializeValue(array)
+			require.NoError(t, err)
+			require.Equal(t, tt.serialized, serialized)
+		})
+	}
+	t.Run("struct literal", func(t *testing.T) {
+		explode := false
+		p := openapi.Parameter{Name: "color", In: openapi.InQuery, Explode: &explode}
+		serialized, err := p.SerializeValue(array)
+		require.NoError(t, err)
+		require.Equal(t, "color=blue,black", serialized)
+
+		p.Explode = nil
+		serialized, err = p.SerializeValue(array)
+		require.NoError(t, err)
+		require.Equal(t, "color=blue&color=black", serialized)
+	})
+}
+
+func TestParameter_DeserializeValues(t *testing.T) {
+	explode := true
+	p := &openapi.Parameter{
+		Name:    "filter",
+		In:      openapi.InQuery,
+		Style:   openapi.StyleDeepObject,
+		Explode: &explode,
+		Schema: openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+			AddProperty("age", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+			AddProperty("active", openapi.NewSchemaBuilder().Type(openapi.BooleanType).Build()).
+			Build(),
+	}
+	actual, err := p.DeserializeValues(url.Values{"filter[age]": {"42"}, "filter[active]": {"true"}, "page": {"2"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"age": int64(42), "active": true}, actual)
+
+	_, err = p.DeserializeValues(url.Values{"filter[age]": {"old"}})
+	require.ErrorContains(t, err, "unable to convert 'old' to [integer]")
+	require.Equal(t, true, errors.Is(err, openapi.ErrInvalidValue))
+
+	p = &openapi.Parameter{Name: "id", In: openapi.InPath, Schema: openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()}
+	_, err = p.DeserializeValues(url.Values{"id": {"1"}})
+	require.ErrorContains(t, err, "cannot be used with query values")
+}
+
+func TestOperation_BindQuery(t *testing.T) {
+	components := openapi.NewComponents()
+	components.Spec.Add("Page", openapi.NewParameterBuilder().
+		Name("page").
+		In(openapi.InQuery).
+		Schema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+		Build(),
+	)
+	operation := openapi.NewOperationBuilder().AddParameters(
+		openapi.NewParameterBuilder().
+			Name("tags").
+			In(openapi.InQuery).
+			Explode(true).
+			Required(true).
+			Schema(openapi.NewSchemaBuilder().Type(openapi.ArrayType).Items(
+				openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()),
+			).Build()).
+			Build(),
+		openapi.NewParameterBuilder().
+			Name("filter").
+			In(openapi.InQuery).
+			Style(openapi.StyleDeepObject).
+			Explode(true).
+			Schema(openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+				AddProperty("age", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+				AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+				Build()).
+			Build(),
+		openapi.NewParameterBuilder().
+			Name("q").
+			In(openapi.InQuery).
+			AllowEmptyValue(true).
+			Build(),
+		openapi.NewParameterBuilder().
+			Name("X-Request-ID").
+			In(openapi.InHeader).
+			Required(true).
+			Build(),
+		openapi.NewRefOrExtSpec[openapi.Parameter]("#/components/parameters/Page"),
+	).Build()
+
+	t.Run("valid", func(t *testing.T) {
+		values, err := url.ParseQuery("tags=a&tags=b&filter[age]=42&filter[name]=John&q=&page=2&other=1")
+		require.NoError(t, err)
+		actual, errs := operation.Spec.BindQuery(values, nil, components)
+		require.Empty(t, errs)
+		require.Equal(t, map[string]any{
+			"tags":   []any{"a", "b"},
+			"filter": map[string]any{"age": int64(42), "name": "John"},
+			"q":      "",
+			"page":   int64(2),
+		}, actual)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		values, err := url.ParseQuery("filter[age]=old&page=")
+		require.NoError(t, err)
+		actual, errs := operation.Spec.BindQuery(values, nil, components)
+		require.Empty(t, actual)
+		require.Len(t, errs, 3)
+		require.ErrorContains(t, errs[0], `parameter "tags": required`)
+		require.ErrorContains(t, errs[1], `parameter "filter": invalid value: unable to convert 'old' to [integer]`)
+		require.ErrorContains(t, errs[2], `parameter "page": invalid value: empty value is not allowed`)
+	})
+
+	t.Run("default explode", func(t *testing.T) {
+		values, err := url.ParseQuery("ids=1&ids=2")
+		require.NoError(t, err)
+		var p openapi.Parameter
+		require.NoError(t, json.Unmarshal([]byte(`{"name": "ids", "in": "query", "schema": {"type": "array", "items": {"type": "integer"}}}`), &p))
+		actual, errs := openapi.BindQuery(values, []*openapi.Parameter{&p})
+		require.Empty(t, errs)
+		require.Equal(t, map[string]any{"ids": []any{int64(1), int64(2)}}, actual)
+	})
+
+	t.Run("path item", func(t *testing.T) {
+		pathItem := openapi.NewPathItemBuilder().Parameters(
+			openapi.NewParameterBuilder().Name("limit").In(openapi.InQuery).
+				Schema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+				Build(),
+			openapi.NewParameterBuilder().Name("page").In(openapi.InQuery).
+				Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+				Build(),
+		).Build()
+		values, err := url.ParseQuery("tags=a&limit=10&page=2")
+		require.NoError(t, err)
+		actual, errs := operation.Spec.BindQuery(values, pathItem.Spec.Spec, components)
+		require.Empty(t, errs)
+		require.Equal(t, map[string]any{
+			"tags":  []any{"a"},
+			"limit": int64(10),
+			"page":  int64(2),
+		}, actual)
+	})
+
+	t.Run("reserved characters", func(t *testing.T) {
+		params := []*openapi.Parameter{
+			{Name: "since", In: openapi.InQuery},
+			{Name: "next", In: openapi.InQuery, AllowReserved: true},
+		}
+		values, err := url.ParseQuery("since=2024-01-01T00%3A00%3A00Z&next=/pets?page=2")
+		require.NoError(t, err)
+		actual, errs := openapi.BindQuery(values, params)
+		require.Empty(t, errs)
+		require.Equal(t, map[string]any{"since": "2024-01-01T00:00:00Z", "next": "/pets?page=2"}, actual)
+	})
+
+	t.Run("exploded object", func(t *testing.T) {
+		params := []*openapi.Parameter{
+			{Name: "filter", In: openapi.InQuery, Schema: openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()},
+			{Name: "page", In: openapi.InQuery, Schema: openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()},
+		}
+		actual, errs := openapi.BindQuery(url.Values{"age": {"42"}, "page": {"2"}}, params)
+		require.Empty(t, errs)
+		require.Equal(t, map[string]any{"filter": map[string]any{"age": "42"}, "page": int64(2)}, actual)
+	})
+}
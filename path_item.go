@@ -1,5 +1,15 @@
 package openapi
 
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
 // PathItem describes the operations available on a single path.
 // A Path Item MAY be empty, due to ACL constraints.
 // The path itself is still exposed to the documentation viewer but they will not know which operations and parameters are available.
@@ -75,11 +85,10 @@ func (o *PathItem) validateSpec(location string, validator *Validator) []*valida
 		for i, v := range o.Parameters {
 			errs = append(errs, v.validateSpec(joinLoc(location, "parameters", i), validator)...)
 		}
+		errs = append(errs, validateParameterDuplicates(location, o.Parameters, validator)...)
 	}
 	if len(o.Servers) > 0 {
-		for i, v := range o.Servers {
-			errs = append(errs, v.validateSpec(joinLoc(location, "servers", i), validator)...)
-		}
+		errs = append(errs, validateServers(joinLoc(location, "servers"), o.Servers, validator)...)
 	}
 	if o.Get != nil {
 		errs = append(errs, o.Get.validateSpec(joinLoc(location, "get"), validator)...)
@@ -105,9 +114,233 @@ func (o *PathItem) validateSpec(location string, validator *Validator) []*valida
 	if o.Trace != nil {
 		errs = append(errs, o.Trace.validateSpec(joinLoc(location, "trace"), validator)...)
 	}
+	errs = append(errs, o.validateRequestBodies(location, validator)...)
+	return errs
+}
+
+// validateRequestBodies reports the request bodies of GET, HEAD, DELETE and TRACE operations,
+// which have no defined semantics for the content of the request and break some clients.
+// The issues are the warnings, unless DisallowUnexpectedRequestBody option is used.
+func (o *PathItem) validateRequestBodies(location string, validator *Validator) []*validationError {
+	var errs []*validationError
+	for _, v := range []struct {
+		method    string
+		operation *Extendable[Operation]
+		allowed   bool
+	}{
+		{"get", o.Get, validator.opts.allowRequestBodyForGet},
+		{"head", o.Head, validator.opts.allowRequestBodyForHead},
+		{"delete", o.Delete, validator.opts.allowRequestBodyForDelete},
+		{"trace", o.Trace, false},
+	} {
+		if v.allowed || v.operation == nil || v.operation.Spec == nil || v.operation.Spec.RequestBody == nil {
+			continue
+		}
+		loc := joinLoc(location, v.method, "requestBody")
+		if validator.opts.disallowUnexpectedRequestBody {
+			errs = append(errs, newValidationError(loc, "not allowed for %s", v.method))
+		} else {
+			errs = append(errs, newValidationWarning(loc, "is not recommended for %s", v.method))
+		}
+	}
+	return errs
+}
+
+// pathItemMethods is the list of the HTTP methods supported by the path item in order of the fields.
+var pathItemMethods = []string{
+	http.MethodGet,
+	http.MethodPut,
+	http.MethodPost,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodHead,
+	http.MethodPatch,
+	http.MethodTrace,
+}
+
+// operationField returns the pointer to the field of the operation for the given uppercase HTTP method
+// or nil if the method is not supported.
+func (o *PathItem) operationField(method string) **Extendable[Operation] {
+	switch method {
+	case http.MethodGet:
+		return &o.Get
+	case http.MethodPut:
+		return &o.Put
+	case http.MethodPost:
+		return &o.Post
+	case http.MethodDelete:
+		return &o.Delete
+	case http.MethodOptions:
+		return &o.Options
+	case http.MethodHead:
+		return &o.Head
+	case http.MethodPatch:
+		return &o.Patch
+	case http.MethodTrace:
+		return &o.Trace
+	}
+	return nil
+}
+
+// Operations returns the defined operations by uppercase HTTP methods, e.g. `GET`.
+func (o *PathItem) Operations() map[string]*Extendable[Operation] {
+	ret := make(map[string]*Extendable[Operation])
+	for _, method := range pathItemMethods {
+		if op := *o.operationField(method); op != nil {
+			ret[method] = op
+		}
+	}
+	return ret
+}
+
+// ErrUnsupportedMethod is returned by AddOperation for the HTTP methods, which are not supported by the path item.
+var ErrUnsupportedMethod = errors.New("unsupported method")
+
+// AddOperation sets the operation for the given HTTP method, the method is case-insensitive.
+// An error is returned for the methods, which are not supported by the path item, e.g. `CONNECT`.
+func (o *PathItem) AddOperation(method string, op *Extendable[Operation]) error {
+	field := o.operationField(strings.ToUpper(method))
+	if field == nil {
+		return fmt.Errorf("%w %q", ErrUnsupportedMethod, method)
+	}
+	*field = op
+	return nil
+}
+
+// operations returns the defined operations by lowercase method names in the order of the fields.
+func (o *PathItem) operations() ([]string, []*Extendable[Operation]) {
+	var (
+		methods    []string
+		operations []*Extendable[Operation]
+	)
+	for _, method := range pathItemMethods {
+		if op := *o.operationField(method); op != nil {
+			methods = append(methods, strings.ToLower(method))
+			operations = append(operations, op)
+		}
+	}
+	return methods, operations
+}
+
+// validatePathParameters checks that every variable of the path template is declared as a path parameter
+// for each operation, either on the path item level or on the operation level,
+// and that there are no path parameters for the variables absent from the template.
+func (o *PathItem) validatePathParameters(location, template string, validator *Validator) []*validationError {
+	vars := make(map[string]bool)
+	for _, m := range pathTemplatePattern.FindAllStringSubmatch(template, -1) {
+		vars[m[1]] = true
+	}
+
+	var errs []*validationError
+	// collectPathParams returns the names of the path parameters and reports the extra ones
+	collectPathParams := func(location string, params []*RefOrSpec[Extendable[Parameter]]) map[string]bool {
+		names := make(map[string]bool)
+		for i, v := range params {
+			param, err := v.GetSpecCached(validator.cache)
+			if err != nil || param.Spec.In != InPath {
+				// the broken references are reported by the parameter validation
+				continue
+			}
+			names[param.Spec.Name] = true
+			if !vars[param.Spec.Name] {
+				errs = append(errs, newValidationError(joinLoc(location, "parameters", i), "path parameter '%s' is not used in the path template '%s'", param.Spec.Name, template))
+			}
+		}
+		return names
+	}
+
+	common := collectPathParams(location, o.Parameters)
+	methods, operations := o.operations()
+	for i, operation := range operations {
+		opLocation := joinLoc(location, methods[i])
+		declared := collectPathParams(opLocation, operation.Spec.Parameters)
+		var missing []string
+		for name := range vars {
+			if !common[name] && !declared[name] {
+				missing = append(missing, name)
+			}
+		}
+		slices.Sort(missing)
+		for _, name := range missing {
+			errs = append(errs, newValidationError(joinLoc(opLocation, "parameters"), "path parameter '%s' is not declared", name))
+		}
+	}
 	return errs
 }
 
+// ResolvePathItem returns the path item combined from the referenced one and the fields declared next to `$ref`.
+//
+// The fields declared locally override the same fields of the referenced path item: the operations are overridden
+// by method, the parameters by name and location, the servers as a whole. The summary and the description of the
+// reference take precedence over both. If the item is not a reference, its spec is returned as is,
+// otherwise the result is a new path item, which shares the operations with the given item and the referenced one,
+// so neither of them is modified and the operations can be passed to Validator.ValidateRequest.
+func ResolvePathItem(item *RefOrSpec[Extendable[PathItem]], c *Extendable[Components]) (*Extendable[PathItem], error) {
+	spec, err := item.GetSpec(c)
+	if err != nil || !item.IsRef() {
+		return spec, err
+	}
+	resolved := &Extendable[PathItem]{Spec: new(PathItem), Extensions: maps.Clone(spec.Extensions)}
+	*resolved.Spec = *spec.Spec
+	if local := item.Spec; local != nil && local.Spec != nil {
+		for _, method := range pathItemMethods {
+			if op := *local.Spec.operationField(method); op != nil {
+				*resolved.Spec.operationField(method) = op
+			}
+		}
+		if local.Spec.Summary != "" {
+			resolved.Spec.Summary = local.Spec.Summary
+		}
+		if local.Spec.Description != "" {
+			resolved.Spec.Description = local.Spec.Description
+		}
+		if len(local.Spec.Servers) > 0 {
+			resolved.Spec.Servers = local.Spec.Servers
+		}
+		if len(local.Spec.Parameters) > 0 {
+			resolved.Spec.Parameters = overrideParameters(spec.Spec.Parameters, local.Spec.Parameters, c)
+		}
+		for k, v := range local.Extensions {
+			if resolved.Extensions == nil {
+				resolved.Extensions = make(map[string]any, len(local.Extensions))
+			}
+			resolved.Extensions[k] = v
+		}
+	}
+	if item.Ref.Summary != "" {
+		resolved.Spec.Summary = item.Ref.Summary
+	}
+	if item.Ref.Description != "" {
+		resolved.Spec.Description = item.Ref.Description
+	}
+	return resolved, nil
+}
+
+// overrideParameters replaces the inherited parameters by the given ones with the same name and location
+// and appends the rest, the unresolved parameters are kept as is.
+func overrideParameters(inherited, params []*RefOrSpec[Extendable[Parameter]], c *Extendable[Components]) []*RefOrSpec[Extendable[Parameter]] {
+	if len(inherited) == 0 {
+		return params
+	}
+	index := make(map[string]int, len(inherited))
+	ret := slices.Clone(inherited)
+	for i, v := range inherited {
+		if p, err := v.GetSpec(c); err == nil {
+			index[p.Spec.key()] = i
+		}
+	}
+	for _, v := range params {
+		if p, err := v.GetSpec(c); err == nil {
+			if i, found := index[p.Spec.key()]; found {
+				ret[i] = v
+				continue
+			}
+		}
+		ret = append(ret, v)
+	}
+	return ret
+}
+
 type PathItemBuilder struct {
 	spec *RefOrSpec[Extendable[PathItem]]
 }
@@ -119,9 +352,20 @@ func NewPathItemBuilder() *PathItemBuilder {
 }
 
 func (b *PathItemBuilder) Build() *RefOrSpec[Extendable[PathItem]] {
+	if b.spec.Ref != nil && reflect.ValueOf(*b.spec.Spec.Spec).IsZero() && len(b.spec.Spec.Extensions) == 0 {
+		// a plain reference without the sibling fields
+		b.spec.Spec = nil
+	}
 	return b.spec
 }
 
+// Ref makes the path item a reference to the given path item, e.g. `#/components/pathItems/Pets`,
+// the fields set by the builder are kept next to `$ref`, see ResolvePathItem function.
+func (b *PathItemBuilder) Ref(v string) *PathItemBuilder {
+	b.spec.Ref = &Ref{Ref: v}
+	return b
+}
+
 func (b *PathItemBuilder) Extensions(v map[string]any) *PathItemBuilder {
 	b.spec.Spec.Extensions = v
 	return b
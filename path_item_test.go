@@ -0,0 +1,277 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestPathItem_Ref(t *testing.T) {
+	data := `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets/{id}": {
+      "$ref": "#/components/paths/Pet",
+      "summary": "A single pet",
+      "parameters": [{"name": "id", "in": "path", "required": true, "explode": false, "description": "local", "schema": {"type": "integer"}}],
+      "delete": {"responses": {"204": {"description": "deleted"}}}
+    },
+    "/cats/{id}": {"$ref": "#/components/paths/Pet"}
+  },
+  "components": {
+    "paths": {
+      "Pet": {
+        "summary": "Pet",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "explode": false, "schema": {"type": "string"}},
+          {"name": "X-Trace", "in": "header", "explode": false, "schema": {"type": "string"}}
+        ],
+        "get": {"responses": {"200": {"description": "ok"}}}
+      }
+    }
+  }
+}`
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+
+	item := doc.Spec.Paths.Spec.Paths["/pets/{id}"]
+	require.Equal(t, true, item.IsRef())
+	require.NotNil(t, item.Spec)
+	require.NotNil(t, item.Spec.Spec.Delete)
+	require.Nil(t, doc.Spec.Paths.Spec.Paths["/cats/{id}"].Spec)
+
+	t.Run("marshal", func(t *testing.T) {
+		out, err := json.Marshal(&doc)
+		require.NoError(t, err)
+		require.JSONEq(t, data, string(out))
+	})
+
+	t.Run("get spec", func(t *testing.T) {
+		spec, err := item.GetSpec(doc.Spec.Components)
+		require.NoError(t, err)
+		require.Equal(t, "Pet", spec.Spec.Summary)
+		require.NotNil(t, spec.Spec.Get)
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		resolved, err := openapi.ResolvePathItem(item, doc.Spec.Components)
+		require.NoError(t, err)
+		require.Equal(t, "A single pet", resolved.Spec.Summary)
+		require.NotNil(t, resolved.Spec.Get)
+		require.NotNil(t, resolved.Spec.Delete)
+		require.Len(t, resolved.Spec.Parameters, 2)
+		require.Equal(t, "local", resolved.Spec.Parameters[0].Spec.Spec.Description)
+		require.Equal(t, "X-Trace", resolved.Spec.Parameters[1].Spec.Spec.Name)
+
+		// the referenced path item is not modified
+		component := doc.Spec.Components.Spec.Paths["Pet"].Spec
+		require.Equal(t, "Pet", component.Spec.Summary)
+		require.Nil(t, component.Spec.Delete)
+		require.Len(t, component.Spec.Parameters, 2)
+
+		plain, err := openapi.ResolvePathItem(doc.Spec.Paths.Spec.Paths["/cats/{id}"], doc.Spec.Components)
+		require.NoError(t, err)
+		require.Equal(t, component, plain)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		validator, err := openapi.NewValidator(&doc)
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("validate siblings", func(t *testing.T) {
+		broken := openapi.NewPathItemBuilder().
+			Ref("#/components/paths/Pet").
+			Post(openapi.NewOperationBuilder().Build()).
+			Build()
+		spec := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddPath("/pets/{name}", broken).
+			Components(doc.Spec.Components).
+			Build()
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		errs, _ := validator.Validate()
+		require.Len(t, errs, 3)
+		require.ErrorContains(t, errs[0], "/paths/~1pets~1{name}/get/parameters: path parameter 'name' is not declared")
+		require.ErrorContains(t, errs[1], "/paths/~1pets~1{name}/parameters/0: path parameter 'id' is not used in the path template '/pets/{name}'")
+		require.ErrorContains(t, errs[2], "/paths/~1pets~1{name}/post/parameters: path parameter 'name' is not declared")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		var strict *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, openapi.DecodeStrict([]byte(data), &strict))
+	})
+}
+
+func TestPathItem_RefSiblings(t *testing.T) {
+	const data = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "$ref": "#/components/paths/Pets",
+      "parameters": [{"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}],
+      "post": {
+        "operationId": "createPet",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}}}},
+        "responses": {"201": {"description": "created", "links": {"list": {"operationId": "listPets"}}}}
+      }
+    }
+  },
+  "components": {
+    "paths": {
+      "Pets": {
+        "get": {
+          "operationId": "listPets",
+          "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}],
+          "responses": {"200": {"description": "ok", "links": {"create": {"operationId": "createPet"}}}}
+        }
+      }
+    }
+  }
+}`
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+	validator, err := openapi.NewValidator(&doc)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	t.Run("find operation", func(t *testing.T) {
+		for _, id := range []string{"createPet", "listPets"} {
+			path, _, op, ok := doc.Spec.FindOperation(id)
+			require.Equal(t, true, ok)
+			require.Equal(t, "/pets", path)
+			require.Equal(t, id, op.OperationID)
+		}
+	})
+
+	t.Run("resolve link", func(t *testing.T) {
+		op, err := (&openapi.Link{OperationID: "createPet"}).ResolveOperation(doc.Spec)
+		require.NoError(t, err)
+		require.Equal(t, "createPet", op.OperationID)
+	})
+
+	t.Run("match", func(t *testing.T) {
+		item, _, ok := doc.Spec.Paths.Spec.Match("/pets", doc.Spec.Components)
+		require.Equal(t, true, ok)
+		require.NotNil(t, item)
+		require.NotNil(t, item.Get)
+		require.NotNil(t, item.Post)
+		require.Len(t, item.Parameters, 1)
+	})
+
+	t.Run("validate request", func(t *testing.T) {
+		resolved, err := openapi.ResolvePathItem(doc.Spec.Paths.Spec.Paths["/pets"], doc.Spec.Components)
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{}`))
+		r.Header.Set("Content-Type", "application/json")
+		errs := validator.ValidateRequest(resolved.Spec.Post.Spec, r)
+		require.Len(t, errs, 2)
+		require.ErrorContains(t, errs[0], "/paths/~1pets/parameters/0: required")
+		require.ErrorContains(t, errs[1], "/paths/~1pets/post/requestBody: jsonschema validation failed")
+
+		r = httptest.NewRequest(http.MethodGet, "/pets?limit=ten", nil)
+		r.Header.Set("X-Tenant", "acme")
+		errs = validator.ValidateRequest(resolved.Spec.Get.Spec, r)
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "/components/paths/Pets/get/parameters/0: ")
+	})
+}
+
+func TestPathItem_Operations(t *testing.T) {
+	get := openapi.NewOperationBuilder().OperationID("listPets").Build()
+	post := openapi.NewOperationBuilder().OperationID("createPet").Build()
+
+	item := openapi.NewPathItemBuilder().Build().Spec.Spec
+	require.Empty(t, item.Operations())
+	require.NoError(t, item.AddOperation("GET", get))
+	require.NoError(t, item.AddOperation("post", post))
+	require.ErrorContains(t, item.AddOperation("CONNECT", post), `unsupported method "CONNECT"`)
+	require.ErrorContains(t, item.AddOperation("", post), `unsupported method ""`)
+	require.Equal(t, true, errors.Is(item.AddOperation("CONNECT", post), openapi.ErrUnsupportedMethod))
+
+	require.Equal(t, get, item.Get)
+	require.Equal(t, post, item.Post)
+	operations := item.Operations()
+	require.Len(t, operations, 2)
+	ids := make(map[string]string)
+	for method, op := range operations {
+		ids[method] = op.Spec.OperationID
+	}
+	require.Equal(t, map[string]string{"GET": "listPets", "POST": "createPet"}, ids)
+
+	require.NoError(t, item.AddOperation("Get", nil))
+	require.Len(t, item.Operations(), 1)
+}
+
+func TestPathItem_RequestBody(t *testing.T) {
+	body := func() *openapi.RefOrSpec[openapi.Extendable[openapi.RequestBody]] {
+		return openapi.NewRequestBodyBuilder().
+			AddContent("application/json", openapi.NewMediaTypeBuilder().Schema(openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()).Build()).
+			Build()
+	}
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().RequestBody(body()).Build()).
+			Post(openapi.NewOperationBuilder().RequestBody(body()).Build()).
+			Trace(openapi.NewOperationBuilder().RequestBody(body()).Build()).
+			Build()).
+		Build()
+
+	for _, tt := range []struct {
+		name     string
+		opts     []openapi.ValidationOption
+		errs     []string
+		warnings []string
+	}{
+		{
+			name: "default",
+			warnings: []string{
+				"/paths/~1pets/get/requestBody: is not recommended for get",
+				"/paths/~1pets/trace/requestBody: is not recommended for trace",
+			},
+		},
+		{
+			name: "allowed for get",
+			opts: []openapi.ValidationOption{openapi.AllowRequestBodyForGet()},
+			warnings: []string{
+				"/paths/~1pets/trace/requestBody: is not recommended for trace",
+			},
+		},
+		{
+			name: "as errors",
+			opts: []openapi.ValidationOption{openapi.DisallowUnexpectedRequestBody()},
+			errs: []string{
+				"/paths/~1pets/get/requestBody: not allowed for get",
+				"/paths/~1pets/trace/requestBody: not allowed for trace",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(spec, tt.opts...)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+			require.Len(t, warnings, len(tt.warnings))
+			for i, w := range tt.warnings {
+				require.ErrorContains(t, warnings[i], w)
+			}
+		})
+	}
+}
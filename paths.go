@@ -2,7 +2,10 @@ package openapi
 
 import (
 	"encoding/json"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // Paths holds the relative paths to the individual endpoints and their operations.
@@ -57,6 +60,36 @@ func (o *Paths) validateSpec(location string, validator *Validator) []*validatio
 			errs = append(errs, newValidationError(joinLoc(location, k), "path item cannot be empty"))
 		} else {
 			errs = append(errs, v.validateSpec(joinLoc(location, k), validator)...)
+			item, err := v.GetSpecCached(validator.cache)
+			if v.Ref != nil && v.Spec != nil {
+				// the operations declared next to `$ref` must declare the path parameters too
+				item, err = ResolvePathItem(v, validator.spec.Spec.Components)
+			}
+			if err == nil {
+				errs = append(errs, item.Spec.validatePathParameters(joinLoc(location, k), k, validator)...)
+				methods, operations := item.Spec.operations()
+				for i, op := range operations {
+					errs = append(errs, validateDeprecatedUsages(joinLoc(location, k, methods[i]), op, item, validator)...)
+				}
+			}
+		}
+	}
+
+	// the templated paths with the same hierarchy but different templated names are identical
+	identical := make(map[string][]string)
+	for k := range o.Paths {
+		if strings.Contains(k, "{") {
+			n := normalizePathTemplate(k)
+			identical[n] = append(identical[n], k)
+		}
+	}
+	for _, keys := range identical {
+		if len(keys) < 2 {
+			continue
+		}
+		slices.Sort(keys)
+		for _, k := range keys[1:] {
+			errs = append(errs, newValidationError(joinLoc(location, k), "identical to '%s', templated paths must differ not only in the names of the parameters", keys[0]))
 		}
 	}
 	return errs
@@ -73,6 +106,184 @@ func (o *Paths) Add(path string, item *RefOrSpec[Extendable[PathItem]]) *Paths {
 	return o
 }
 
+// Keys returns the paths in lexical order.
+func (o *Paths) Keys() []string {
+	keys := make([]string, 0, len(o.Paths))
+	for k := range o.Paths {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Sort returns the paths ordered by the given function, PathPrecedence is used if the function is nil.
+// The map of the paths is not changed, so the result is an ordered view of the keys.
+func (o *Paths) Sort(less func(a, b string) bool) []string {
+	if less == nil {
+		less = PathPrecedence
+	}
+	keys := o.Keys()
+	slices.SortStableFunc(keys, func(a, b string) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return keys
+}
+
+// PathPrecedence reports whether the path a goes before the path b in the order of matching precedence:
+// the concrete paths before the templated ones, e.g. `/pets/mine` before `/pets/{id}`, and then lexically.
+func PathPrecedence(a, b string) bool {
+	if at, bt := strings.Contains(a, "{"), strings.Contains(b, "{"); at != bt {
+		return bt
+	}
+	return a < b
+}
+
+// Match finds the path item for the given request path, e.g. `/pets/123` for `/pets/{id}`,
+// and returns it together with the values of the path parameters.
+//
+// The concrete paths are matched before the templated ones, and the templated paths are compared
+// segment by segment, so `/users/me/pets` is matched by `/users/me/{kind}` rather than by `/users/{id}/pets`.
+// The path is ambiguous and not matched if there are several identical templated paths,
+// which differ only in the names of the parameters, e.g. `/pets/{id}` and `/pets/{name}`.
+// The referenced path items are resolved using the given components, see ResolvePathItem;
+// the path item is nil if the reference cannot be resolved, see MatchTemplate method to get the path template.
+func (o *Paths) Match(path string, c *Extendable[Components]) (*PathItem, map[string]string, bool) {
+	template, params, ok := o.MatchTemplate(path)
+	if !ok {
+		return nil, nil, false
+	}
+	var item *PathItem
+	if v, err := ResolvePathItem(o.Paths[template], c); err == nil && v != nil {
+		item = v.Spec
+	}
+	return item, params, true
+}
+
+// MatchTemplate finds the path template for the given request path and returns it
+// together with the values of the path parameters, see Match method for the details.
+func (o *Paths) MatchTemplate(path string) (string, map[string]string, bool) {
+	if _, found := o.Paths[path]; found && !strings.Contains(path, "{") {
+		return path, map[string]string{}, true
+	}
+
+	var (
+		best       string
+		bestParams map[string]string
+		ambiguous  bool
+	)
+	for template := range o.Paths {
+		if !strings.Contains(template, "{") {
+			continue
+		}
+		params, ok := matchPathTemplate(template, path)
+		if !ok {
+			continue
+		}
+		if best == "" {
+			best, bestParams = template, params
+			continue
+		}
+		switch c := comparePathTemplates(template, best); {
+		case c < 0:
+			best, bestParams, ambiguous = template, params, false
+		case c == 0:
+			ambiguous = true
+			// keep the first template in the lexical order to be stable for the identical specificity
+			if template < best {
+				best, bestParams = template, params
+			}
+		}
+	}
+	if best == "" || ambiguous && hasIdenticalTemplates(o.Paths, best) {
+		return "", nil, false
+	}
+	return best, bestParams, true
+}
+
+var (
+	pathTemplatePattern = regexp.MustCompile(`{([^{}]+)}`)
+	pathTemplateCache   sync.Map
+)
+
+// normalizePathTemplate removes the names of the parameters, e.g. `/pets/{id}` becomes `/pets/{}`.
+func normalizePathTemplate(template string) string {
+	return pathTemplatePattern.ReplaceAllString(template, "{}")
+}
+
+// hasIdenticalTemplates returns true if there are other templates with the same hierarchy as the given one.
+func hasIdenticalTemplates(paths map[string]*RefOrSpec[Extendable[PathItem]], template string) bool {
+	normalized := normalizePathTemplate(template)
+	for k := range paths {
+		if k != template && normalizePathTemplate(k) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPathTemplate(template, path string) (map[string]string, bool) {
+	var re *regexp.Regexp
+	if v, ok := pathTemplateCache.Load(template); ok {
+		re = v.(*regexp.Regexp)
+	} else {
+		var b strings.Builder
+		b.WriteString("^")
+		last := 0
+		for _, loc := range pathTemplatePattern.FindAllStringIndex(template, -1) {
+			b.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+			b.WriteString("([^/]+)")
+			last = loc[1]
+		}
+		b.WriteString(regexp.QuoteMeta(template[last:]))
+		b.WriteString("$")
+		re = regexp.MustCompile(b.String())
+		pathTemplateCache.Store(template, re)
+	}
+
+	values := re.FindStringSubmatch(path)
+	if values == nil {
+		return nil, false
+	}
+	names := pathTemplatePattern.FindAllStringSubmatch(template, -1)
+	params := make(map[string]string, len(names))
+	for i, name := range names {
+		params[name[1]] = values[i+1]
+	}
+	return params, true
+}
+
+// comparePathTemplates compares the specificity of the templates segment by segment,
+// a concrete segment is more specific than a partially templated one, which is more specific than a fully templated one.
+// The negative result means that the first template is more specific.
+func comparePathTemplates(a, b string) int {
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+	score := func(segment string) int {
+		switch {
+		case !strings.Contains(segment, "{"):
+			return 0
+		case pathTemplatePattern.ReplaceAllString(segment, "") != "":
+			return 1
+		default:
+			return 2
+		}
+	}
+	// the templates matching the same path have the same number of segments
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := score(as[i]) - score(bs[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
 func NewPaths() *Extendable[Paths] {
 	return NewExtendable[Paths](&Paths{})
 }
@@ -0,0 +1,168 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestPaths_Match(t *testing.T) {
+	paths := openapi.NewPaths()
+	for _, p := range []string{
+		"/pets",
+		"/pets/mine",
+		"/pets/{id}",
+		"/pets/{id}.json",
+		"/users/{uid}/pets/{pid}",
+		"/users/me/pets/{pid}",
+		"/users/{uid}/{kind}/{pid}",
+		"/shops/{sid}",
+		"/shops/{name}",
+	} {
+		paths.Spec.Add(p, openapi.NewPathItemBuilder().Summary(p).Build())
+	}
+	paths.Spec.Add("/refs/{id}", openapi.NewRefOrSpec[openapi.Extendable[openapi.PathItem]]("#/components/pathItems/Ref"))
+
+	for _, tt := range []struct {
+		path     string
+		template string
+		params   map[string]string
+	}{
+		{path: "/pets", template: "/pets", params: map[string]string{}},
+		{path: "/pets/mine", template: "/pets/mine", params: map[string]string{}},
+		{path: "/pets/123", template: "/pets/{id}", params: map[string]string{"id": "123"}},
+		{path: "/pets/123.json", template: "/pets/{id}.json", params: map[string]string{"id": "123"}},
+		{path: "/users/42/pets/7", template: "/users/{uid}/pets/{pid}", params: map[string]string{"uid": "42", "pid": "7"}},
+		{path: "/users/me/pets/7", template: "/users/me/pets/{pid}", params: map[string]string{"pid": "7"}},
+		{path: "/users/42/toys/7", template: "/users/{uid}/{kind}/{pid}", params: map[string]string{"uid": "42", "kind": "toys", "pid": "7"}},
+		{path: "/shops/1"},
+		{path: "/pets/1/2"},
+		{path: "/unknown"},
+		{path: "/refs/1", template: "/refs/{id}", params: map[string]string{"id": "1"}},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			template, params, ok := paths.Spec.MatchTemplate(tt.path)
+			require.Equal(t, tt.template != "", ok)
+			require.Equal(t, tt.template, template)
+			require.Equal(t, tt.params, params)
+
+			item, params, ok := paths.Spec.Match(tt.path, nil)
+			require.Equal(t, tt.template != "", ok)
+			require.Equal(t, tt.params, params)
+			if tt.template == "" || tt.template == "/refs/{id}" {
+				require.Nil(t, item)
+			} else {
+				require.NotNil(t, item)
+				require.Equal(t, tt.template, item.Summary)
+			}
+		})
+	}
+}
+
+func TestPaths_IdenticalTemplates(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddPath("/pets/{id}", openapi.NewPathItemBuilder().Build()).
+		AddPath("/pets/{petId}", openapi.NewPathItemBuilder().Build()).
+		AddPath("/pets/{name}", openapi.NewPathItemBuilder().Build()).
+		AddPath("/pets/{id}/toys", openapi.NewPathItemBuilder().Build()).
+		AddPath("/pets/mine", openapi.NewPathItemBuilder().Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 2)
+	require.ErrorContains(t, errs[0], "/paths/~1pets~1{name}: identical to '/pets/{id}'")
+	require.ErrorContains(t, errs[1], "/paths/~1pets~1{petId}: identical to '/pets/{id}'")
+}
+
+func TestPaths_PathParameters(t *testing.T) {
+	idParam := func(name string) *openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]] {
+		return openapi.NewParameterBuilder().Name(name).In(openapi.InPath).Required(true).Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build()
+	}
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddComponent("uid", idParam("uid")).
+		AddPath("/users/{uid}/pets/{pid}", openapi.NewPathItemBuilder().
+			Parameters(openapi.NewRefOrExtSpec[openapi.Parameter]("#/components/parameters/uid")).
+			Get(openapi.NewOperationBuilder().AddParameters(idParam("pid")).Build()).
+			Put(openapi.NewOperationBuilder().Build()).
+			Delete(openapi.NewOperationBuilder().AddParameters(idParam("pid"), idParam("extra")).Build()).
+			Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().
+			Parameters(idParam("id")).
+			Build()).
+		Build()
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 3)
+	require.ErrorContains(t, errs[0], "/paths/~1pets/parameters/0: path parameter 'id' is not used in the path template '/pets'")
+	require.ErrorContains(t, errs[1], "/paths/~1users~1{uid}~1pets~1{pid}/delete/parameters/1: path parameter 'extra' is not used in the path template '/users/{uid}/pets/{pid}'")
+	require.ErrorContains(t, errs[2], "/paths/~1users~1{uid}~1pets~1{pid}/put/parameters: path parameter 'pid' is not declared")
+}
+
+func TestPaths_Sort(t *testing.T) {
+	paths := openapi.NewPaths()
+	for _, p := range []string{
+		"/users/{uid}/pets",
+		"/pets/{id}",
+		"/users/me",
+		"/pets",
+		"/{resource}",
+		"/pets/mine",
+		"/users/{uid}",
+	} {
+		paths.Spec.Add(p, openapi.NewPathItemBuilder().Build())
+	}
+
+	require.Equal(t, []string{
+		"/pets",
+		"/pets/mine",
+		"/pets/{id}",
+		"/users/me",
+		"/users/{uid}",
+		"/users/{uid}/pets",
+		"/{resource}",
+	}, paths.Spec.Keys())
+	require.Equal(t, []string{
+		"/pets",
+		"/pets/mine",
+		"/users/me",
+		"/pets/{id}",
+		"/users/{uid}",
+		"/users/{uid}/pets",
+		"/{resource}",
+	}, paths.Spec.Sort(nil))
+	require.Equal(t, []string{
+		"/{resource}",
+		"/users/{uid}/pets",
+		"/users/{uid}",
+		"/users/me",
+		"/pets/{id}",
+		"/pets/mine",
+		"/pets",
+	}, paths.Spec.Sort(func(a, b string) bool { return a > b }))
+}
+
+func TestPathPrecedence(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		less bool
+	}{
+		{a: "/pets/mine", b: "/pets/{id}", less: true},
+		{a: "/pets/{id}", b: "/pets/mine", less: false},
+		{a: "/zoo", b: "/pets/{id}", less: true},
+		{a: "/pets", b: "/pets/mine", less: true},
+		{a: "/pets/{id}", b: "/users/{id}", less: true},
+		{a: "/pets", b: "/pets", less: false},
+	} {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.less, openapi.PathPrecedence(tt.a, tt.b))
+		})
+	}
+}
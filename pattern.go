@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"regexp"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ecmaOnlyPattern matches the constructs of ECMA-262 regular expressions, which are not supported by RE2 syntax
+// of Go regexp package: lookaheads `(?=`, `(?!`, lookbehinds `(?<=`, `(?<!` and backreferences `\1`, `\k<name>`.
+// The construct must not be escaped, so it is preceded by an even number of backslashes.
+var ecmaOnlyPattern = regexp.MustCompile(`(?:^|[^\\])(?:\\\\)*(?:\(\?<?[=!]|\\[1-9]|\\k<)`)
+
+// compiledPattern is the result of the compilation of a pattern stored in the cache of the validator.
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// regexp compiles the pattern once per validator, the cache is not bounded, but it holds only the patterns of
+// the schemas of the document and of the validated formats, so its size is limited by the size of the document.
+// The cache is safe for concurrent use by the parallel validation.
+func (v *Validator) regexp(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := v.patterns.Load(pattern); ok {
+		c := cached.(compiledPattern)
+		return c.re, c.err
+	}
+	re, err := regexp.Compile(pattern)
+	v.patterns.Store(pattern, compiledPattern{re: re, err: err})
+	return re, err
+}
+
+// validatePattern checks that the pattern is supported by Go regexp package.
+// The valid ECMA-262 patterns, which cannot be compiled because of the unsupported constructs, are reported as
+// the warnings, because they are not enforced by the validation of the data, the other patterns as the errors.
+func (v *Validator) validatePattern(location, pattern string) *validationError {
+	_, err := v.regexp(pattern)
+	switch {
+	case err == nil:
+		return nil
+	case ecmaOnlyPattern.MatchString(pattern):
+		return newValidationWarning(location, "uses ECMA-262 features unsupported by Go regexp and is not enforced: %w", err)
+	default:
+		return newValidationError(location, err)
+	}
+}
+
+// unenforcedRegexp matches any string, it replaces the patterns with the constructs unsupported by Go regexp.
+type unenforcedRegexp string
+
+func (r unenforcedRegexp) MatchString(string) bool {
+	return true
+}
+
+func (r unenforcedRegexp) String() string {
+	return string(r)
+}
+
+// compilePattern is the regexp engine of the jsonschema compiler, which ignores the patterns with the ECMA-262
+// constructs unsupported by Go regexp instead of failing the compilation of the whole schema, see validatePattern.
+func (v *Validator) compilePattern(pattern string) (jsonschema.Regexp, error) {
+	re, err := v.regexp(pattern)
+	if err != nil {
+		if ecmaOnlyPattern.MatchString(pattern) {
+			return unenforcedRegexp(pattern), nil
+		}
+		return nil, err
+	}
+	return re, nil
+}
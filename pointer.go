@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const pointerNotFoundPrefix = "pointer not found: "
+
+type PointerNotFoundError string
+
+func (e PointerNotFoundError) Error() string {
+	return pointerNotFoundPrefix + string(e)
+}
+
+func (e PointerNotFoundError) Is(target error) bool {
+	return strings.HasPrefix(target.Error(), pointerNotFoundPrefix)
+}
+
+func NewPointerNotFoundError(pointer string) error {
+	return PointerNotFoundError(pointer)
+}
+
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// splitPointer splits the given JSON Pointer or URI fragment into the list of unescaped tokens.
+func splitPointer(pointer string) ([]string, error) {
+	if strings.HasPrefix(pointer, "#") {
+		p, err := url.PathUnescape(pointer[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pointer %q: %w", pointer, err)
+		}
+		pointer = p
+	}
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q: must start with a forward slash (`/`)", pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i := range parts {
+		parts[i] = jsonPointerUnescaper.Replace(parts[i])
+	}
+	return parts, nil
+}
+
+// ResolvePointer looks up the object located by the given JSON Pointer (RFC6901) in the given root object.
+//
+// The pointer can be in form of the URI fragment, e.g. `#/paths/~1users~1{id}/get`.
+// The lookup is performed directly on the Go objects using the `json` field tags,
+// so the returned value is the actual object from the tree (not a copy).
+func ResolvePointer(root any, pointer string) (any, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(root)
+	for _, token := range tokens {
+		next, ok := lookupToken(v, token)
+		if !ok {
+			return nil, NewPointerNotFoundError(pointer)
+		}
+		v = next
+	}
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return nil, NewPointerNotFoundError(pointer)
+	}
+	return v.Interface(), nil
+}
+
+func lookupToken(v reflect.Value, token string) (reflect.Value, bool) {
+	// dereference everything except pointers to structs
+	for (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && !v.IsNil() {
+		if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+			break
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		item := v.MapIndex(reflect.ValueOf(token).Convert(v.Type().Key()))
+		return item, item.IsValid()
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= v.Len() {
+			return reflect.Value{}, false
+		}
+		return v.Index(i), true
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		return lookupStructToken(v, token)
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// lookupStructToken searches the token in the fields of a pointer to a struct.
+// The fields with `json:"-"` tag or without the tag (like Extendable.Spec, RefOrSpec.Spec, Paths.Paths, etc.)
+// are transparent, so the token is looked up inside them.
+func lookupStructToken(ptr reflect.Value, token string) (reflect.Value, bool) {
+	v := ptr.Elem()
+	t := v.Type()
+	var transparent []reflect.Value
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case token:
+			return v.Field(i), true
+		case "-", "":
+			transparent = append(transparent, v.Field(i))
+		}
+	}
+	for _, field := range transparent {
+		if next, ok := lookupToken(field, token); ok {
+			return next, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -2,7 +2,10 @@ package openapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -32,6 +35,8 @@ type Ref struct {
 // RefOrSpec holds either Ref or any OpenAPI spec type.
 //
 // NOTE: The Ref object takes precedent over Spec if using json Marshal and Unmarshal functions.
+// The only exception is a path item, which allows the fields next to `$ref`, so both Ref and Spec are set
+// if the reference has the sibling fields, see ResolvePathItem function.
 type RefOrSpec[T any] struct {
 	Ref  *Ref `json:"-"`
 	Spec *T   `json:"-"`
@@ -84,51 +89,135 @@ func (o *RefOrSpec[T]) getLocationOrRef(location string) string {
 
 // GetSpec return a Spec if it is set or loads it from Components in case of Ref or an error
 func (o *RefOrSpec[T]) GetSpec(c *Extendable[Components]) (*T, error) {
-	return o.getSpec(c, make(visitedObjects))
+	return o.getSpec(c, nil)
+}
+
+// MustGetSpec is like GetSpec, but panics if the spec cannot be resolved.
+// It is intended for the tests and the code generators, where the document is known to be valid.
+func (o *RefOrSpec[T]) MustGetSpec(c *Extendable[Components]) *T {
+	spec, err := o.GetSpec(c)
+	if err != nil {
+		panic(fmt.Sprintf("openapi: resolving %T failed: %v", o, err))
+	}
+	return spec
+}
+
+// IsRef returns true if the object is a reference.
+func (o *RefOrSpec[T]) IsRef() bool {
+	return o != nil && o.Ref != nil
+}
+
+// IsSpec returns true if the object holds the spec and is not a reference.
+func (o *RefOrSpec[T]) IsSpec() bool {
+	return o != nil && o.Ref == nil && o.Spec != nil
+}
+
+// RefString returns the value of the `$ref` field or an empty string if the object is not a reference.
+func (o *RefOrSpec[T]) RefString() string {
+	if !o.IsRef() {
+		return ""
+	}
+	return o.Ref.Ref
 }
 
 const specNotFoundPrefix = "spec not found: "
 
+var (
+	// ErrRefTargetNotFound is the reason of SpecNotFoundError if the referenced component does not exist.
+	ErrRefTargetNotFound = errors.New("ref target not found")
+	// ErrRefWrongType is the reason of SpecNotFoundError if the referenced component is of another type,
+	// e.g. a parameter refers to a schema.
+	ErrRefWrongType = errors.New("ref target has wrong type")
+)
+
 type SpecNotFoundError struct {
-	message        string
-	visitedObjects string
+	message string
+	visited []string
+	cycle   []string
+	reason  error
 }
 
 func (e *SpecNotFoundError) Error() string {
-	return specNotFoundPrefix + e.message + "; visited refs: " + e.visitedObjects
+	return specNotFoundPrefix + e.message + "; visited refs: " + strings.Join(e.visited, ", ")
 }
 
 func (e *SpecNotFoundError) Is(target error) bool {
 	return strings.HasPrefix(target.Error(), specNotFoundPrefix)
 }
 
+// Unwrap returns the reason of the error, ErrRefTargetNotFound or ErrRefWrongType, if it is known.
+func (e *SpecNotFoundError) Unwrap() error {
+	return e.reason
+}
+
+// VisitedRefs returns the refs resolved before the error in order of resolving.
+func (e *SpecNotFoundError) VisitedRefs() []string {
+	return slices.Clone(e.visited)
+}
+
+// Cycle returns the refs of the detected cycle starting and ending with the repeated ref,
+// e.g. `[A B C A]`, or nil if the error is not caused by a cycle.
+func (e *SpecNotFoundError) Cycle() []string {
+	return slices.Clone(e.cycle)
+}
+
+// NewSpecNotFoundError creates SpecNotFoundError, the visited refs are sorted, because the order is unknown.
 func NewSpecNotFoundError(message string, visitedObjects visitedObjects) error {
+	visited := make([]string, 0, len(visitedObjects))
+	for k := range visitedObjects {
+		visited = append(visited, k)
+	}
+	slices.Sort(visited)
 	return &SpecNotFoundError{
-		message:        message,
-		visitedObjects: visitedObjects.String(),
+		message: message,
+		visited: visited,
 	}
 }
 
-func (o *RefOrSpec[T]) getSpec(c *Extendable[Components], visited visitedObjects) (*T, error) {
+// newRefChainError creates SpecNotFoundError with the visited refs in order of resolving.
+func newRefChainError(message string, chain []string) error {
+	return &SpecNotFoundError{
+		message: message,
+		visited: chain,
+	}
+}
+
+// newRefReasonError creates SpecNotFoundError with the given reason.
+func newRefReasonError(reason error, message string, chain []string) error {
+	return &SpecNotFoundError{
+		message: message,
+		visited: chain,
+		reason:  reason,
+	}
+}
+
+// getSpec resolves the reference, the chain is the list of the already resolved refs to detect the cycles.
+func (o *RefOrSpec[T]) getSpec(c *Extendable[Components], chain []string) (*T, error) {
 	// some guards
 	switch {
-	case o.Spec != nil:
+	case o.Ref == nil && o.Spec != nil:
 		return o.Spec, nil
 	case o.Ref == nil:
-		return nil, NewSpecNotFoundError("nil Ref", visited)
-	case visited[o.Ref.Ref]:
-		return nil, NewSpecNotFoundError(fmt.Sprintf("cycle ref %q detected", o.Ref.Ref), visited)
+		return nil, newRefChainError("nil Ref", chain)
+	case slices.Contains(chain, o.Ref.Ref):
+		// the cycle starts from the repeated ref, the refs before it only lead to the cycle
+		cycle := append(slices.Clone(chain[slices.Index(chain, o.Ref.Ref):]), o.Ref.Ref)
+		return nil, &SpecNotFoundError{
+			message: fmt.Sprintf("cycle ref %q detected: %s", o.Ref.Ref, strings.Join(cycle, " -> ")),
+			visited: chain,
+			cycle:   cycle,
+		}
 	case !strings.HasPrefix(o.Ref.Ref, "#/components/"):
 		// TODO: support loading by url
-		return nil, NewSpecNotFoundError(fmt.Sprintf("loading outside of components is not implemented for the ref %q", o.Ref.Ref), visited)
+		return nil, newRefChainError(fmt.Sprintf("loading outside of components is not implemented for the ref %q", o.Ref.Ref), chain)
 	case c == nil:
-		return nil, NewSpecNotFoundError("components is required, but got nil", visited)
+		return nil, newRefChainError("components is required, but got nil", chain)
 	}
-	visited[o.Ref.Ref] = true
+	chain = append(chain, o.Ref.Ref)
 
 	parts := strings.SplitN(o.Ref.Ref[13:], "/", 2)
 	if len(parts) != 2 {
-		return nil, NewSpecNotFoundError(fmt.Sprintf("incorrect ref %q", o.Ref.Ref), visited)
+		return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("incorrect ref %q", o.Ref.Ref), chain)
 	}
 	objName := parts[1]
 	var ref any
@@ -152,24 +241,30 @@ func (o *RefOrSpec[T]) getSpec(c *Extendable[Components], visited visitedObjects
 	case "paths":
 		ref = c.Spec.Paths[objName]
 	default:
-		return nil, NewSpecNotFoundError(fmt.Sprintf("unexpected component %q", ref), visited)
+		return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("unexpected component %q", parts[0]), chain)
 	}
 	obj, ok := ref.(*RefOrSpec[T])
 	if !ok {
-		return nil, NewSpecNotFoundError(fmt.Sprintf("expected spec of type %T, but got %T", RefOrSpec[T]{}, ref), visited)
+		return nil, newRefReasonError(ErrRefWrongType, fmt.Sprintf("expected spec of type %T, but got %T", RefOrSpec[T]{}, ref), chain)
+	}
+	if obj == nil {
+		return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("component %q not found", o.Ref.Ref), chain)
 	}
-	if obj.Spec != nil {
+	if obj.Ref == nil && obj.Spec != nil {
 		return obj.Spec, nil
 	}
-	return obj.getSpec(c, visited)
+	return obj.getSpec(c, chain)
 }
 
 // MarshalJSON implements json.Marshaler interface.
 func (o *RefOrSpec[T]) MarshalJSON() ([]byte, error) {
 	var v any
-	if o.Ref != nil {
+	switch {
+	case o.Ref != nil && o.Spec != nil:
+		return marshalRefWithSiblings(o.Ref, o.Spec)
+	case o.Ref != nil:
 		v = o.Ref
-	} else {
+	default:
 		v = o.Spec
 	}
 	data, err := json.Marshal(&v)
@@ -183,6 +278,11 @@ func (o *RefOrSpec[T]) MarshalJSON() ([]byte, error) {
 func (o *RefOrSpec[T]) UnmarshalJSON(data []byte) error {
 	if json.Unmarshal(data, &o.Ref) == nil && o.Ref.Ref != "" {
 		o.Spec = nil
+		if _, ok := any(o).(*RefOrSpec[Extendable[PathItem]]); ok && hasRefSiblings(data) {
+			if err := json.Unmarshal(data, &o.Spec); err != nil {
+				return fmt.Errorf("%T: %w", o.Spec, err)
+			}
+		}
 		return nil
 	}
 
@@ -193,7 +293,43 @@ func (o *RefOrSpec[T]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// hasRefSiblings returns true if the reference object contains any fields except `$ref`, `summary` and `description`.
+func hasRefSiblings(data []byte) bool {
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(data, &raw) != nil {
+		return false
+	}
+	for k := range raw {
+		if k != "$ref" && k != "summary" && k != "description" {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalRefWithSiblings marshals the reference together with the fields of the given spec.
+func marshalRefWithSiblings(ref *Ref, spec any) ([]byte, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%T: %w", spec, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%T: %w", spec, err)
+	}
+	if data, err = json.Marshal(ref); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
 func (o *RefOrSpec[T]) validateSpec(location string, validator *Validator) []*validationError {
+	if validator.stopped() {
+		return nil
+	}
 	var errs []*validationError
 	if o.Spec != nil {
 		if spec, ok := any(o.Spec).(validatable); ok {
@@ -201,18 +337,183 @@ func (o *RefOrSpec[T]) validateSpec(location string, validator *Validator) []*va
 		} else {
 			errs = append(errs, newValidationError(location, NewUnsupportedSpecTypeError(o.Spec)))
 		}
-	} else {
-		// do not validate already visited refs
-		if validator.visited[o.Ref.Ref] {
-			return errs
+	}
+	if o.Ref != nil && !validator.detached {
+		key := o.Ref.Ref
+		schema, embedded := any(o).(*RefOrSpec[Schema])
+		embedded = embedded && validator.schemaResolver.isEmbedded(schema)
+		if embedded {
+			// the relative refs of the different schema resources can be the same
+			key = validator.schemaResolver.BaseURI(schema) + " " + key
+		}
+		validator.markVisited(key)
+		// the refs are resolved at every location, so a broken ref is reported at all of them
+		// regardless of the order of the concurrent validation
+		var err error
+		if embedded {
+			_, err = validator.schemaResolver.Resolve(schema)
+		} else {
+			_, err = o.GetSpecCached(validator.cache)
 		}
-		validator.visited[o.Ref.Ref] = true
-		spec, err := o.GetSpec(validator.spec.Spec.Components)
 		if err != nil {
 			errs = append(errs, newValidationError(location, err))
-		} else if spec != nil {
-			errs = append(errs, o.validateSpec(location, validator)...)
 		}
 	}
+	validator.checkFailFast(errs)
+	return errs
+}
+
+// Refs returns all the references of the given document by their locations, e.g. `/paths/~1pets/get/parameters/0`.
+//
+// The result includes the `$ref` values of all objects, both internal and external ones,
+// the mappings of the discriminators, where the schema names are converted to the references,
+// e.g. `/components/schemas/Pet/discriminator/mapping/dog` -> `#/components/schemas/Dog`,
+// and the `operationRef` fields of the links. The references are not resolved.
+func Refs(doc *OpenAPI) map[string]string {
+	refs := make(map[string]string)
+	_ = Walk(doc, func(location string, node any) error {
+		switch v := node.(type) {
+		case *Ref:
+			refs[location] = v.Ref
+		case *Discriminator:
+			for k, m := range v.Mapping {
+				if !strings.Contains(m, "/") {
+					m = ComponentSchemas.Ref(m)
+				}
+				refs[joinLoc(location, "mapping", k)] = m
+			}
+		case *Link:
+			if v.OperationRef != "" {
+				refs[joinLoc(location, "operationRef")] = v.OperationRef
+			}
+		}
+		return nil
+	})
+	return refs
+}
+
+// CheckRefs resolves all the internal references to the components of the given document,
+// including the mappings of the discriminators, and returns an error for each unresolved one with its location.
+//
+// The errors are SpecNotFoundError, so the missing components can be distinguished from the components of
+// wrong types by errors.Is with ErrRefTargetNotFound and ErrRefWrongType.
+// The external references and the references outside the components are not checked.
+func CheckRefs(doc *OpenAPI) []error {
+	var errs []error
+	_ = Walk(doc, func(location string, node any) error {
+		switch v := node.(type) {
+		case refResolver:
+			if err := v.resolveRef(doc.Components); err != nil {
+				errs = append(errs, newValidationError(location, err))
+			}
+		case *Discriminator:
+			for _, k := range sortedKeys(v.Mapping) {
+				ref := v.Mapping[k]
+				if !strings.Contains(ref, "/") {
+					ref = ComponentSchemas.Ref(ref)
+				}
+				if err := NewRefOrSpec[Schema](ref).resolveRef(doc.Components); err != nil {
+					errs = append(errs, newValidationError(joinLoc(location, "mapping", k), err))
+				}
+			}
+		}
+		return nil
+	})
 	return errs
 }
+
+// refResolver is implemented by RefOrSpec to resolve the references of any type.
+type refResolver interface {
+	resolveRef(c *Extendable[Components]) error
+}
+
+func (o *RefOrSpec[T]) resolveRef(c *Extendable[Components]) error {
+	if o.Ref == nil || !strings.HasPrefix(o.Ref.Ref, "#/components/") {
+		return nil
+	}
+	_, err := o.GetSpec(c)
+	return err
+}
+
+// rewriteRefs updates all the local references to the component of the given kind and old name in the given object,
+// so they point to the component with the new name.
+func rewriteRefs(root any, kind ComponentKind, oldName, newName string) {
+	r := refsRewriter{
+		kind:    kind,
+		oldName: oldName,
+		newName: newName,
+		oldRef:  kind.Ref(oldName),
+		newRef:  kind.Ref(newName),
+		visited: make(map[uintptr]bool),
+	}
+	r.walk(reflect.ValueOf(root))
+}
+
+type refsRewriter struct {
+	kind    ComponentKind
+	oldName string
+	newName string
+	oldRef  string
+	newRef  string
+	visited map[uintptr]bool
+}
+
+func (r *refsRewriter) rewrite(ref string) (string, bool) {
+	if ref == r.oldRef || strings.HasPrefix(ref, r.oldRef+"/") {
+		return r.newRef + ref[len(r.oldRef):], true
+	}
+	return ref, false
+}
+
+func (r *refsRewriter) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			r.walk(v.Elem())
+		}
+	case reflect.Pointer:
+		if v.IsNil() || r.visited[v.Pointer()] {
+			return
+		}
+		r.visited[v.Pointer()] = true
+		switch t := v.Interface().(type) {
+		case *Ref:
+			t.Ref, _ = r.rewrite(t.Ref)
+			return
+		case *Discriminator:
+			if r.kind == ComponentSchemas {
+				for k, m := range t.Mapping {
+					if m == r.oldName {
+						t.Mapping[k] = r.newName
+					} else if ref, ok := r.rewrite(m); ok {
+						t.Mapping[k] = ref
+					}
+				}
+			}
+			return
+		}
+		r.walk(v.Elem())
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				r.walk(v.Field(i))
+			}
+		}
+	case reflect.Map:
+		if s, ok := v.Interface().(SecurityRequirement); ok {
+			if scopes, found := s[r.oldName]; found && r.kind == ComponentSecuritySchemes {
+				delete(s, r.oldName)
+				s[r.newName] = scopes
+			}
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			r.walk(iter.Value())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			r.walk(v.Index(i))
+		}
+	}
+}
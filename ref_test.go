@@ -2,7 +2,10 @@ package openapi_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sv-tools/openapi"
@@ -282,6 +285,19 @@ func TestRefOrSpec_GetSpec(t *testing.T) {
 			),
 			expErr: "cycle ref",
 		},
+		{
+			name: "three refs cycle",
+			ref:  openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Pet"),
+			c: openapi.NewExtendable((&openapi.Components{}).
+				Add("Pet", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/A")).
+				Add("A", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/B")).
+				Add("B", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/C")).
+				Add("C", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/A")),
+			),
+			expErr: `cycle ref "#/components/schemas/A" detected: ` +
+				"#/components/schemas/A -> #/components/schemas/B -> #/components/schemas/C -> #/components/schemas/A; " +
+				"visited refs: #/components/schemas/Pet, #/components/schemas/A, #/components/schemas/B, #/components/schemas/C",
+		},
 		{
 			name:   "ref to unexpected component",
 			ref:    openapi.NewRefOrSpec[testRefOrSpec]("#/components/test/Pet"),
@@ -312,3 +328,159 @@ func TestRefOrSpec_GetSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestRefOrSpec_Helpers(t *testing.T) {
+	components := openapi.NewComponents()
+	components.Spec.Add("Pet", openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build())
+
+	ref := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Pet")
+	require.Equal(t, true, ref.IsRef())
+	require.Equal(t, false, ref.IsSpec())
+	require.Equal(t, "#/components/schemas/Pet", ref.RefString())
+	require.Equal(t, openapi.SingleOrArray[string]{openapi.ObjectType}, *ref.MustGetSpec(components).Type)
+
+	spec := openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+	require.Equal(t, false, spec.IsRef())
+	require.Equal(t, true, spec.IsSpec())
+	require.Equal(t, "", spec.RefString())
+	require.Equal(t, spec.Spec, spec.MustGetSpec(nil))
+
+	var empty *openapi.RefOrSpec[openapi.Schema]
+	require.Equal(t, false, empty.IsRef())
+	require.Equal(t, false, empty.IsSpec())
+	require.Equal(t, "", empty.RefString())
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			require.NotNil(t, r)
+			require.Equal(t, true, strings.Contains(fmt.Sprint(r), `component "#/components/schemas/Dog" not found`))
+		}()
+		openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Dog").MustGetSpec(components)
+	})
+}
+
+func TestSpecNotFoundError_Cycle(t *testing.T) {
+	c := openapi.NewExtendable((&openapi.Components{}).
+		Add("Pet", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/A")).
+		Add("A", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/B")).
+		Add("B", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/A")),
+	)
+
+	_, err := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Pet").GetSpec(c)
+	var e *openapi.SpecNotFoundError
+	require.Equal(t, true, errors.As(err, &e))
+	require.Equal(t, []string{"#/components/schemas/Pet", "#/components/schemas/A", "#/components/schemas/B"}, e.VisitedRefs())
+	require.Equal(t, []string{"#/components/schemas/A", "#/components/schemas/B", "#/components/schemas/A"}, e.Cycle())
+
+	_, err = openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Dog").GetSpec(c)
+	require.Equal(t, true, errors.As(err, &e))
+	require.Equal(t, []string{"#/components/schemas/Dog"}, e.VisitedRefs())
+	require.Empty(t, e.Cycle())
+}
+
+func TestRefs(t *testing.T) {
+	const data = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [{"$ref": "#/components/parameters/Limit"}],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/MyResponseType"}}},
+            "links": {"next": {"operationRef": "#/paths/~1pets/get"}}
+          },
+          "default": {"$ref": "common.json#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}},
+    "schemas": {
+      "Cat": {"type": "object"},
+      "Dog": {"type": "object"},
+      "Lizard": {"type": "object"},
+      "MyResponseType": {
+        "oneOf": [
+          {"$ref": "#/components/schemas/Cat"},
+          {"$ref": "#/components/schemas/Dog"},
+          {"$ref": "#/components/schemas/Lizard"},
+          {"$ref": "https://gigantic-server.com/schemas/Monster/schema.json"}
+        ],
+        "discriminator": {
+          "propertyName": "petType",
+          "mapping": {
+            "cat": "Cat",
+            "dog": "#/components/schemas/Dog",
+            "monster": "https://gigantic-server.com/schemas/Monster/schema.json"
+          }
+        }
+      }
+    }
+  }
+}`
+	var doc *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+	require.Equal(t, map[string]string{
+		"/paths/~1pets/get/parameters/0":                                   "#/components/parameters/Limit",
+		"/paths/~1pets/get/responses/200/content/application~1json/schema": "#/components/schemas/MyResponseType",
+		"/paths/~1pets/get/responses/200/links/next/operationRef":          "#/paths/~1pets/get",
+		"/paths/~1pets/get/responses/default":                              "common.json#/components/responses/Error",
+		"/components/schemas/MyResponseType/oneOf/0":                       "#/components/schemas/Cat",
+		"/components/schemas/MyResponseType/oneOf/1":                       "#/components/schemas/Dog",
+		"/components/schemas/MyResponseType/oneOf/2":                       "#/components/schemas/Lizard",
+		"/components/schemas/MyResponseType/oneOf/3":                       "https://gigantic-server.com/schemas/Monster/schema.json",
+		"/components/schemas/MyResponseType/discriminator/mapping/cat":     "#/components/schemas/Cat",
+		"/components/schemas/MyResponseType/discriminator/mapping/dog":     "#/components/schemas/Dog",
+		"/components/schemas/MyResponseType/discriminator/mapping/monster": "https://gigantic-server.com/schemas/Monster/schema.json",
+	}, openapi.Refs(doc.Spec))
+}
+
+func TestCheckRefs(t *testing.T) {
+	const data = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [{"$ref": "#/components/schemas/Pet"}],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pets"}}}
+          },
+          "default": {"$ref": "common.json#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "oneOf": [{"$ref": "#/components/schemas/Cat"}],
+        "discriminator": {"propertyName": "petType", "mapping": {"cat": "Cat", "dog": "Dog"}}
+      },
+      "Cat": {"type": "object"}
+    }
+  }
+}`
+	var doc *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+	errs := openapi.CheckRefs(doc.Spec)
+	require.Len(t, errs, 3)
+
+	require.ErrorContains(t, errs[0], `/components/schemas/Pet/discriminator/mapping/dog: spec not found: component "#/components/schemas/Dog" not found`)
+	require.Equal(t, true, errors.Is(errs[0], openapi.ErrRefTargetNotFound))
+	require.Equal(t, true, errors.Is(errs[0], &openapi.SpecNotFoundError{}))
+
+	require.ErrorContains(t, errs[1], `/paths/~1pets/get/responses/200/content/application~1json/schema: spec not found: component "#/components/schemas/Pets" not found`)
+	require.Equal(t, true, errors.Is(errs[1], openapi.ErrRefTargetNotFound))
+
+	require.ErrorContains(t, errs[2], `/paths/~1pets/get/parameters/0: spec not found: expected spec of type`)
+	require.Equal(t, true, errors.Is(errs[2], openapi.ErrRefWrongType))
+	require.Equal(t, false, errors.Is(errs[2], openapi.ErrRefTargetNotFound))
+}
@@ -1,5 +1,7 @@
 package openapi
 
+import "slices"
+
 // RequestBody describes a single request body.
 //
 // https://spec.openapis.org/oas/v3.1.1#request-body-object
@@ -52,13 +54,37 @@ func (o *RequestBody) validateSpec(location string, validator *Validator) []*val
 	if len(o.Content) == 0 {
 		errs = append(errs, newValidationError(joinLoc(location, "content"), ErrRequired))
 	} else {
-		for k, v := range o.Content {
-			errs = append(errs, v.validateSpec(joinLoc(location, "content", k), validator)...)
-		}
+		errs = append(errs, validateContent(joinLoc(location, "content"), o.Content, validator)...)
+	}
+	if !o.Required && o.hasOnlyRequiredProperties(validator) {
+		errs = append(errs, newValidationWarning(joinLoc(location, "required"), "is false, but all properties of the schema are required"))
 	}
 	return errs
 }
 
+// hasOnlyRequiredProperties returns true if the request body has a single media type
+// with an object schema, which requires all its properties.
+func (o *RequestBody) hasOnlyRequiredProperties(validator *Validator) bool {
+	if len(o.Content) != 1 {
+		return false
+	}
+	for _, mt := range o.Content {
+		if mt == nil || mt.Spec.Schema == nil {
+			return false
+		}
+		schema, err := mt.Spec.Schema.GetSpecCached(validator.cache)
+		if err != nil || len(schema.Properties) == 0 {
+			return false
+		}
+		for name := range schema.Properties {
+			if !slices.Contains(schema.Required, name) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 type RequestBodyBuilder struct {
 	spec *RefOrSpec[Extendable[RequestBody]]
 }
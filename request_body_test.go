@@ -0,0 +1,85 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestRequestBody_validateSpec(t *testing.T) {
+	pet := func(required ...string) *openapi.RefOrSpec[openapi.Schema] {
+		return openapi.NewSchemaBuilder().
+			Type(openapi.ObjectType).
+			AddProperty("id", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+			AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+			Required(required...).
+			Build()
+	}
+
+	for _, tt := range []struct {
+		name    string
+		body    *openapi.RefOrSpec[openapi.Extendable[openapi.RequestBody]]
+		err     string
+		warning string
+	}{
+		{
+			name: "empty content",
+			body: openapi.NewRequestBodyBuilder().Description("empty").Build(),
+			err:  "/paths/~1pets/post/requestBody/content: required",
+		},
+		{
+			name: "populated",
+			body: openapi.NewRequestBodyBuilder().
+				Description("the pet to add").
+				Required(true).
+				AddContent("application/json", openapi.NewMediaTypeBuilder().
+					Schema(pet("id", "name")).
+					Example(map[string]any{"id": 1, "name": "Fluffy"}).
+					Build()).
+				Build(),
+		},
+		{
+			name: "optional with some required properties",
+			body: openapi.NewRequestBodyBuilder().
+				AddContent("application/json", openapi.NewMediaTypeBuilder().
+					Schema(pet("id")).
+					Build()).
+				Build(),
+		},
+		{
+			name: "optional with all required properties",
+			body: openapi.NewRequestBodyBuilder().
+				AddContent("application/json", openapi.NewMediaTypeBuilder().
+					Schema(pet("id", "name")).
+					Build()).
+				Build(),
+			warning: "/paths/~1pets/post/requestBody/required: is false, but all properties of the schema are required",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddPath("/pets", openapi.NewPathItemBuilder().
+					Post(openapi.NewOperationBuilder().RequestBody(tt.body).Build()).
+					Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.DoNotValidateExamples())
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			if tt.err != "" {
+				require.Len(t, errs, 1)
+				require.ErrorContains(t, errs[0], tt.err)
+			} else {
+				require.Empty(t, errs)
+			}
+			if tt.warning != "" {
+				require.Len(t, warnings, 1)
+				require.ErrorContains(t, warnings[0], tt.warning)
+			} else {
+				require.Empty(t, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package openapi
+
+import "sync"
+
+// ResolverCache memorizes the specs resolved by the references to the given components,
+// so the repeated resolving of the same reference is a single lookup.
+//
+// Only the successfully resolved specs are cached, the errors, including the reference cycles, are detected
+// on each call as GetSpec does. The cache is safe for concurrent use and can be shared by several validators
+// of the same document, see WithResolverCache option. Call Reset method if the components are changed.
+type ResolverCache struct {
+	components *Extendable[Components]
+	specs      sync.Map
+}
+
+// NewResolverCache creates a cache to resolve the references to the given components.
+func NewResolverCache(c *Extendable[Components]) *ResolverCache {
+	return &ResolverCache{components: c}
+}
+
+// Reset removes all the cached specs.
+func (c *ResolverCache) Reset() {
+	c.specs.Range(func(key, _ any) bool {
+		c.specs.Delete(key)
+		return true
+	})
+}
+
+// GetSpecCached is like GetSpec, but uses the given cache to resolve the references.
+// The cache can be nil, then no components are used.
+func (o *RefOrSpec[T]) GetSpecCached(cache *ResolverCache) (*T, error) {
+	if o.Ref == nil || cache == nil {
+		var c *Extendable[Components]
+		if cache != nil {
+			c = cache.components
+		}
+		return o.GetSpec(c)
+	}
+	if v, ok := cache.specs.Load(o.Ref.Ref); ok {
+		// the same reference can be requested as another type, then GetSpec reports the error
+		if spec, ok := v.(*T); ok {
+			return spec, nil
+		}
+	}
+	spec, err := o.GetSpec(cache.components)
+	if err != nil {
+		return nil, err
+	}
+	cache.specs.Store(o.Ref.Ref, spec)
+	return spec, nil
+}
@@ -0,0 +1,103 @@
+package openapi_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestResolverCache(t *testing.T) {
+	components := openapi.NewComponents()
+	components.Spec.
+		Add("Pet", openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()).
+		Add("Animal", openapi.NewSchemaBuilder().Ref("#/components/schemas/Pet").Build()).
+		Add("A", openapi.NewSchemaBuilder().Ref("#/components/schemas/B").Build()).
+		Add("B", openapi.NewSchemaBuilder().Ref("#/components/schemas/A").Build())
+	cache := openapi.NewResolverCache(components)
+
+	ref := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Animal")
+	spec, err := ref.GetSpecCached(cache)
+	require.NoError(t, err)
+	require.Equal(t, components.Spec.Schemas["Pet"].Spec, spec)
+
+	// the cached spec is returned even if the component is changed
+	components.Spec.Remove("Pet", openapi.ComponentSchemas)
+	cached, err := ref.GetSpecCached(cache)
+	require.NoError(t, err)
+	require.Equal(t, true, spec == cached)
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, err := openapi.NewRefOrSpec[openapi.Extendable[openapi.Parameter]]("#/components/schemas/Animal").GetSpecCached(cache)
+		require.Equal(t, true, errors.Is(err, openapi.ErrRefWrongType))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		for range 2 {
+			_, err := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/A").GetSpecCached(cache)
+			var notFound *openapi.SpecNotFoundError
+			require.Equal(t, true, errors.As(err, &notFound))
+			require.Equal(t, []string{"#/components/schemas/A", "#/components/schemas/B", "#/components/schemas/A"}, notFound.Cycle())
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		cache.Reset()
+		_, err := ref.GetSpecCached(cache)
+		require.ErrorContains(t, err, `component "#/components/schemas/Pet" not found`)
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		spec := openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+		v, err := spec.GetSpecCached(nil)
+		require.NoError(t, err)
+		require.Equal(t, spec.Spec, v)
+	})
+}
+
+func TestWithResolverCache(t *testing.T) {
+	spec := componentsSpec(10)
+	cache := openapi.NewResolverCache(spec.Spec.Components)
+	for range 2 {
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.WithResolverCache(cache))
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+	}
+}
+
+func BenchmarkResolverCache(b *testing.B) {
+	// each schema refers to the next one via a chain of aliases
+	components := openapi.NewComponents()
+	const n = 1000
+	refs := make([]*openapi.RefOrSpec[openapi.Schema], 0, n)
+	for i := range n {
+		components.Spec.Add(fmt.Sprintf("Pet%d", i), openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build())
+		components.Spec.Add(fmt.Sprintf("Animal%d", i), openapi.NewSchemaBuilder().Ref(fmt.Sprintf("#/components/schemas/Pet%d", i)).Build())
+		components.Spec.Add(fmt.Sprintf("Creature%d", i), openapi.NewSchemaBuilder().Ref(fmt.Sprintf("#/components/schemas/Animal%d", i)).Build())
+		refs = append(refs, openapi.NewSchemaBuilder().Ref(fmt.Sprintf("#/components/schemas/Creature%d", i)).Build())
+	}
+
+	b.Run("GetSpec", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for _, ref := range refs {
+				if _, err := ref.GetSpec(components); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("GetSpecCached", func(b *testing.B) {
+		cache := openapi.NewResolverCache(components)
+		b.ReportAllocs()
+		for range b.N {
+			for _, ref := range refs {
+				if _, err := ref.GetSpecCached(cache); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
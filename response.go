@@ -37,9 +37,7 @@ func (o *Response) validateSpec(location string, validator *Validator) []*valida
 		errs = append(errs, newValidationError(joinLoc(location, "description"), ErrRequired))
 	}
 	if o.Content != nil {
-		for k, v := range o.Content {
-			errs = append(errs, v.validateSpec(joinLoc(location, "content", k), validator)...)
-		}
+		errs = append(errs, validateContent(joinLoc(location, "content"), o.Content, validator)...)
 	}
 	if o.Links != nil {
 		for k, v := range o.Links {
@@ -54,6 +52,16 @@ func (o *Response) validateSpec(location string, validator *Validator) []*valida
 	return errs
 }
 
+// SelectContent returns the media type of the response content, which matches the given Accept header best.
+//
+// The media types of the Accept header are ordered by the quality value (`q`) and by the specificity,
+// so `text/plain` is preferred to `text/*` and `*/*` with the same quality, and the media types with `q=0` are not acceptable.
+// The ties are resolved by choosing the most specific content key and then the lexically first one.
+// An empty Accept header accepts any media type.
+func (o *Response) SelectContent(accept string) (mediaType string, mt *MediaType, ok bool) {
+	return selectContent(o.Content, accept)
+}
+
 type ResponseBuilder struct {
 	spec *RefOrSpec[Extendable[Response]]
 }
@@ -104,6 +112,11 @@ func (b *ResponseBuilder) AddContent(key string, value *Extendable[MediaType]) *
 	return b
 }
 
+// JSONContent adds the `application/json` media type with the given schema.
+func (b *ResponseBuilder) JSONContent(schema *RefOrSpec[Schema]) *ResponseBuilder {
+	return b.AddContent("application/json", NewMediaTypeBuilder().Schema(schema).Build())
+}
+
 func (b *ResponseBuilder) Links(v map[string]*RefOrSpec[Extendable[Link]]) *ResponseBuilder {
 	b.spec.Spec.Spec.Links = v
 	return b
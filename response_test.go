@@ -0,0 +1,67 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestResponse_SelectContent(t *testing.T) {
+	response := openapi.NewResponseBuilder().Description("test")
+	for _, k := range []string{"application/json", "text/plain", "text/html", "image/*"} {
+		response.AddContent(k, openapi.NewMediaTypeBuilder().Example(k).Build())
+	}
+	r := response.Build().Spec.Spec
+
+	for _, tt := range []struct {
+		accept    string
+		mediaType string
+	}{
+		{accept: "application/json;q=0.9, text/*;q=1", mediaType: "text/html"},
+		{accept: "application/json", mediaType: "application/json"},
+		{accept: "text/plain;q=0.5, text/*;q=0.9", mediaType: "text/html"},
+		{accept: "text/*, text/plain;q=0", mediaType: "text/html"},
+		{accept: "*/*", mediaType: "application/json"},
+		{accept: "*/*;q=0.1, application/json;q=0.5", mediaType: "application/json"},
+		{accept: "", mediaType: "application/json"},
+		{accept: "image/png", mediaType: "image/*"},
+		{accept: "image/*, image/png;q=0.8", mediaType: "image/*"},
+		{accept: "application/xml"},
+		{accept: "application/json;q=0"},
+		{accept: "invalid, text/plain", mediaType: "text/plain"},
+	} {
+		t.Run(tt.accept, func(t *testing.T) {
+			mediaType, mt, ok := r.SelectContent(tt.accept)
+			require.Equal(t, tt.mediaType != "", ok)
+			require.Equal(t, tt.mediaType, mediaType)
+			if ok {
+				require.Equal(t, tt.mediaType, mt.Example)
+			} else {
+				require.Nil(t, mt)
+			}
+		})
+	}
+}
+
+func TestResponseBuilder(t *testing.T) {
+	response := openapi.NewResponseBuilder().
+		Description("ok").
+		JSONContent(openapi.NewSchemaBuilder().Ref("#/components/schemas/Pet").Build()).
+		AddHeader("X-Rate-Limit", openapi.NewHeaderBuilder().Required(true).Schema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).Build()).
+		AddLink("owner", openapi.NewLinkBuilder().OperationID("getOwner").Build()).
+		Build()
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "description": "ok",
+  "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}},
+  "headers": {"X-Rate-Limit": {"required": true, "schema": {"type": "integer"}}},
+  "links": {"owner": {"operationId": "getOwner"}}
+}`, string(data))
+
+	responses := openapi.NewResponsesBuilder().AddResponse("200", response).Build()
+	require.Equal(t, response, responses.Spec.Spec.Response["200"])
+}
@@ -3,6 +3,7 @@ package openapi
 import (
 	"encoding/json"
 	"regexp"
+	"strconv"
 )
 
 var ResponseCodePattern = regexp.MustCompile(`^[1-5](?:\d{2}|XX)$`)
@@ -47,26 +48,15 @@ type Responses struct {
 
 // MarshalJSON implements json.Marshaler interface.
 func (o *Responses) MarshalJSON() ([]byte, error) {
-	var raw map[string]json.RawMessage
-	data, err := json.Marshal(&o.Response)
-	if err != nil {
-		return nil, err
-	}
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return nil, err
+	if o.Default == nil {
+		return json.Marshal(&o.Response)
 	}
-
-	if o.Default != nil {
-		data, err = json.Marshal(&o.Default)
-		if err != nil {
-			return nil, err
-		}
-		if raw == nil {
-			raw = make(map[string]json.RawMessage, 1)
-		}
-		raw["default"] = data
+	all := make(map[string]*RefOrSpec[Extendable[Response]], len(o.Response)+1)
+	for k, v := range o.Response {
+		all[k] = v
 	}
-	return json.Marshal(&raw)
+	all["default"] = o.Default
+	return json.Marshal(&all)
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface.
@@ -75,17 +65,21 @@ func (o *Responses) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	if v, ok := raw["default"]; ok {
-		if err := json.Unmarshal(v, &o.Default); err != nil {
+	o.Response = make(map[string]*RefOrSpec[Extendable[Response]], len(raw))
+	for k, v := range raw {
+		if k == "default" {
+			if err := json.Unmarshal(v, &o.Default); err != nil {
+				return err
+			}
+			continue
+		}
+		var response *RefOrSpec[Extendable[Response]]
+		if err := json.Unmarshal(v, &response); err != nil {
 			return err
 		}
-		delete(raw, "default")
-	}
-	data, err := json.Marshal(&raw)
-	if err != nil {
-		return err
+		o.Response[k] = response
 	}
-	return json.Unmarshal(data, &o.Response)
+	return nil
 }
 
 func (o *Responses) validateSpec(location string, validator *Validator) []*validationError {
@@ -99,9 +93,48 @@ func (o *Responses) validateSpec(location string, validator *Validator) []*valid
 		}
 		errs = append(errs, v.validateSpec(joinLoc(location, k), validator)...)
 	}
+	if o.Default == nil {
+		if len(o.Response) == 0 {
+			errs = append(errs, newValidationError(location, "must contain at least one response code: %w", ErrRequired))
+		} else if !o.hasSuccessResponse() {
+			errs = append(errs, newValidationWarning(location, "should contain the response for a successful operation call"))
+		}
+	}
 	return errs
 }
 
+// hasSuccessResponse returns true if there is any response code except the error ones (4XX and 5XX).
+func (o *Responses) hasSuccessResponse() bool {
+	for k := range o.Response {
+		if k != "" && k[0] != '4' && k[0] != '5' {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the response for the given HTTP status code.
+//
+// The explicit code takes precedence over the range definition, e.g. `2XX`, and the Default response is
+// returned if neither is defined. The result is nil if there is no response for the code.
+func (o *Responses) Get(statusCode int) *RefOrSpec[Extendable[Response]] {
+	_, v := o.lookup(statusCode)
+	return v
+}
+
+// lookup returns the response for the given HTTP status code together with its key, see Get method for the details.
+func (o *Responses) lookup(statusCode int) (string, *RefOrSpec[Extendable[Response]]) {
+	if v, ok := o.Response[strconv.Itoa(statusCode)]; ok {
+		return strconv.Itoa(statusCode), v
+	}
+	if statusCode >= 100 && statusCode < 600 {
+		if v, ok := o.Response[strconv.Itoa(statusCode/100)+"XX"]; ok {
+			return strconv.Itoa(statusCode/100) + "XX", v
+		}
+	}
+	return "default", o.Default
+}
+
 type ResponsesBuilder struct {
 	spec *RefOrSpec[Extendable[Responses]]
 }
@@ -116,6 +149,15 @@ func (b *ResponsesBuilder) Build() *RefOrSpec[Extendable[Responses]] {
 	return b.spec
 }
 
+// BuildValid validates and returns the built object, or the validation errors if the object is invalid.
+// The object is validated standalone, so the references and the examples are not checked.
+func (b *ResponsesBuilder) BuildValid() (*RefOrSpec[Extendable[Responses]], error) {
+	if err := validateDetached("", b.spec); err != nil {
+		return nil, err
+	}
+	return b.spec, nil
+}
+
 func (b *ResponsesBuilder) Extensions(v map[string]any) *ResponsesBuilder {
 	b.spec.Spec.Extensions = v
 	return b
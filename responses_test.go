@@ -0,0 +1,140 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestResponses_Get(t *testing.T) {
+	ok := openapi.NewResponseBuilder().Description("OK").Build()
+	success := openapi.NewResponseBuilder().Description("success").Build()
+	unexpected := openapi.NewResponseBuilder().Description("unexpected").Build()
+	responses := openapi.NewResponsesBuilder().
+		AddResponse("200", ok).
+		AddResponse("2XX", success).
+		Default(unexpected).
+		Build()
+
+	for _, tt := range []struct {
+		name       string
+		statusCode int
+		expected   *openapi.RefOrSpec[openapi.Extendable[openapi.Response]]
+	}{
+		{name: "exact", statusCode: 200, expected: ok},
+		{name: "range", statusCode: 204, expected: success},
+		{name: "default", statusCode: 418, expected: unexpected},
+		{name: "invalid code", statusCode: 42, expected: unexpected},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, responses.Spec.Spec.Get(tt.statusCode))
+		})
+	}
+
+	t.Run("no default", func(t *testing.T) {
+		responses := openapi.NewResponsesBuilder().AddResponse("200", ok).Build()
+		require.Nil(t, responses.Spec.Spec.Get(500))
+	})
+}
+
+func TestResponses_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		responses *openapi.RefOrSpec[openapi.Extendable[openapi.Responses]]
+		err       string
+		warning   string
+	}{
+		{
+			name:      "success",
+			responses: openapi.NewResponsesBuilder().AddResponse("200", openapi.NewResponseBuilder().Description("OK").Build()).Build(),
+		},
+		{
+			name:      "default only",
+			responses: openapi.NewResponsesBuilder().Default(openapi.NewResponseBuilder().Description("OK").Build()).Build(),
+		},
+		{
+			name:      "empty",
+			responses: openapi.NewResponsesBuilder().Build(),
+			err:       "/paths/~1pets/get/responses: must contain at least one response code: required",
+		},
+		{
+			name: "errors only",
+			responses: openapi.NewResponsesBuilder().
+				AddResponse("404", openapi.NewResponseBuilder().Description("not found").Build()).
+				AddResponse("5XX", openapi.NewResponseBuilder().Description("server error").Build()).
+				Build(),
+			warning: "/paths/~1pets/get/responses: should contain the response for a successful operation call",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := openapi.NewOperationBuilder().Build()
+			operation.Spec.Responses = tt.responses.Spec
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddPath("/pets", openapi.NewPathItemBuilder().Get(operation).Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			if tt.err != "" {
+				require.Len(t, errs, 1)
+				require.ErrorContains(t, errs[0], tt.err)
+			} else {
+				require.Empty(t, errs)
+			}
+			if tt.warning != "" {
+				require.Len(t, warnings, 1)
+				require.ErrorContains(t, warnings[0], tt.warning)
+			} else {
+				require.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestResponses_Marshal_Unmarshal(t *testing.T) {
+	for _, data := range []string{
+		`{"200": {"description": "ok"}, "4XX": {"$ref": "#/components/responses/Error"}, "default": {"description": "error"}, "x-internal": true}`,
+		`{"default": {"description": "error"}}`,
+		`{"204": {"description": "no content"}}`,
+		`{}`,
+	} {
+		var responses *openapi.Extendable[openapi.Responses]
+		require.NoError(t, json.Unmarshal([]byte(data), &responses))
+		newData, err := json.Marshal(responses)
+		require.NoError(t, err)
+		require.JSONEq(t, data, string(newData))
+	}
+}
+
+func BenchmarkResponses_Marshal_Unmarshal(b *testing.B) {
+	builder := openapi.NewResponsesBuilder().Default(openapi.NewResponseBuilder().Description("error").Build())
+	for i := range 50 {
+		builder.AddResponse(fmt.Sprintf("%d", 200+i), openapi.NewResponseBuilder().Description("ok").Build())
+	}
+	responses := builder.Build()
+	data, err := json.Marshal(responses)
+	require.NoError(b, err)
+
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := json.Marshal(responses); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var v *openapi.RefOrSpec[openapi.Extendable[openapi.Responses]]
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
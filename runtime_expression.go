@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ExpressionURL is the full request URL.
+	ExpressionURL = "$url"
+	// ExpressionMethod is the HTTP method of the request.
+	ExpressionMethod = "$method"
+	// ExpressionStatusCode is the HTTP status code of the response.
+	ExpressionStatusCode = "$statusCode"
+	// ExpressionRequest is a value from the request.
+	ExpressionRequest = "$request"
+	// ExpressionResponse is a value from the response.
+	ExpressionResponse = "$response"
+
+	// SourceHeader is a header-reference source, e.g. `$request.header.accept`.
+	SourceHeader = "header"
+	// SourceQuery is a query-reference source, e.g. `$request.query.queryUrl`.
+	SourceQuery = "query"
+	// SourcePath is a path-reference source, e.g. `$request.path.id`.
+	SourcePath = "path"
+	// SourceBody is a body-reference source, e.g. `$response.body#/status`.
+	SourceBody = "body"
+)
+
+// RuntimeExpression is a parsed runtime expression.
+// The runtime expressions allow defining values based on information that will only be available within
+// the HTTP message in an actual API call.
+// This mechanism is used by Link Objects and Callback Objects.
+//
+// https://spec.openapis.org/oas/v3.1.1#runtime-expressions
+//
+// ABNF syntax:
+//
+//	expression = "$url" / "$method" / "$statusCode" / "$request." source / "$response." source
+//	source = header-reference / query-reference / path-reference / body-reference
+//	header-reference = "header." token
+//	query-reference = "query." name
+//	path-reference = "path." name
+//	body-reference = "body" ["#" json-pointer ]
+type RuntimeExpression struct {
+	// The type of the expression: one of `$url`, `$method`, `$statusCode`, `$request` or `$response`.
+	Type string
+	// The source of the value for `$request` and `$response` expressions: one of `header`, `query`, `path` or `body`.
+	Source string
+	// The name of the header, query or path parameter.
+	Name string
+	// The JSON Pointer to the value in the body, it is set for `body` source only.
+	// If empty, the entire body is referenced.
+	Pointer string
+}
+
+const invalidRuntimeExpressionPrefix = "invalid runtime expression: "
+
+type InvalidRuntimeExpressionError string
+
+func (e InvalidRuntimeExpressionError) Error() string {
+	return invalidRuntimeExpressionPrefix + string(e)
+}
+
+func (e InvalidRuntimeExpressionError) Is(target error) bool {
+	return strings.HasPrefix(target.Error(), invalidRuntimeExpressionPrefix)
+}
+
+func NewInvalidRuntimeExpressionError(expression, reason string) error {
+	return InvalidRuntimeExpressionError(fmt.Sprintf("%q: %s", expression, reason))
+}
+
+// ParseRuntimeExpression parses the given runtime expression, e.g. `$request.path.id` or `$response.body#/uuid`.
+func ParseRuntimeExpression(expression string) (*RuntimeExpression, error) {
+	switch expression {
+	case ExpressionURL, ExpressionMethod, ExpressionStatusCode:
+		return &RuntimeExpression{Type: expression}, nil
+	}
+
+	typ, source, found := strings.Cut(expression, ".")
+	switch {
+	case typ != ExpressionRequest && typ != ExpressionResponse:
+		return nil, NewInvalidRuntimeExpressionError(expression, fmt.Sprintf("expected one of [%s, %s, %s, %s, %s]", ExpressionURL, ExpressionMethod, ExpressionStatusCode, ExpressionRequest, ExpressionResponse))
+	case !found || source == "":
+		return nil, NewInvalidRuntimeExpressionError(expression, "source is required")
+	}
+
+	e := RuntimeExpression{Type: typ}
+	if body, pointer, hasPointer := strings.Cut(source, "#"); body == SourceBody {
+		e.Source = SourceBody
+		if hasPointer {
+			if err := checkJSONPointer(pointer); err != nil {
+				return nil, NewInvalidRuntimeExpressionError(expression, err.Error())
+			}
+			e.Pointer = pointer
+		}
+		return &e, nil
+	}
+
+	e.Source, e.Name, found = strings.Cut(source, ".")
+	if !found || e.Name == "" {
+		return nil, NewInvalidRuntimeExpressionError(expression, "name is required")
+	}
+	switch e.Source {
+	case SourceHeader:
+		if i := strings.IndexFunc(e.Name, func(r rune) bool { return !isTokenChar(r) }); i >= 0 {
+			return nil, NewInvalidRuntimeExpressionError(expression, fmt.Sprintf("invalid character %q in header name", e.Name[i]))
+		}
+	case SourceQuery, SourcePath:
+	default:
+		return nil, NewInvalidRuntimeExpressionError(expression, fmt.Sprintf("invalid source %q, expected one of [%s, %s, %s, %s]", e.Source, SourceHeader, SourceQuery, SourcePath, SourceBody))
+	}
+	return &e, nil
+}
+
+// String returns the runtime expression in the text form.
+func (e *RuntimeExpression) String() string {
+	switch {
+	case e.Source == "":
+		return e.Type
+	case e.Source == SourceBody && e.Pointer != "":
+		return e.Type + "." + e.Source + "#" + e.Pointer
+	case e.Source == SourceBody:
+		return e.Type + "." + e.Source
+	default:
+		return e.Type + "." + e.Source + "." + e.Name
+	}
+}
+
+// ErrValueNotFound is returned by RuntimeExpression.Evaluate when the referenced value does not exist in the HTTP message.
+var ErrValueNotFound = errors.New("value not found")
+
+// Evaluate computes the value of the runtime expression using the given HTTP request and response.
+//
+// The body is the raw body of the message the expression refers to: the request body for `$request.body`
+// and the response body for `$response.body` expressions,
+// because the bodies of http.Request and http.Response can be read only once.
+// The body is decoded as JSON, and the JSON Pointer, if any, is resolved into the decoded value.
+//
+// The path parameters are taken from http.Request.PathValue function,
+// so the request must be matched by a http.ServeMux pattern or the values must be set by http.Request.SetPathValue.
+func (e *RuntimeExpression) Evaluate(req *http.Request, resp *http.Response, body []byte) (any, error) {
+	if e.Type == ExpressionStatusCode || e.Type == ExpressionResponse {
+		if resp == nil {
+			return nil, fmt.Errorf("%q: response is nil: %w", e, ErrValueNotFound)
+		}
+	} else if req == nil {
+		return nil, fmt.Errorf("%q: request is nil: %w", e, ErrValueNotFound)
+	}
+
+	switch e.Type {
+	case ExpressionURL:
+		if req.URL == nil {
+			return nil, fmt.Errorf("%q: %w", e, ErrValueNotFound)
+		}
+		return req.URL.String(), nil
+	case ExpressionMethod:
+		return req.Method, nil
+	case ExpressionStatusCode:
+		return resp.StatusCode, nil
+	}
+
+	var header http.Header
+	if e.Type == ExpressionRequest {
+		header = req.Header
+	} else {
+		header = resp.Header
+	}
+
+	switch e.Source {
+	case SourceHeader:
+		values := header.Values(e.Name)
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%q: %w", e, ErrValueNotFound)
+		}
+		return strings.Join(values, ", "), nil
+	case SourceQuery:
+		if e.Type != ExpressionRequest || req.URL == nil {
+			return nil, fmt.Errorf("%q: %w", e, ErrValueNotFound)
+		}
+		query := req.URL.Query()
+		if !query.Has(e.Name) {
+			return nil, fmt.Errorf("%q: %w", e, ErrValueNotFound)
+		}
+		return query.Get(e.Name), nil
+	case SourcePath:
+		if e.Type != ExpressionRequest {
+			return nil, fmt.Errorf("%q: %w", e, ErrValueNotFound)
+		}
+		v := req.PathValue(e.Name)
+		if v == "" {
+			return nil, fmt.Errorf("%q: %w", e, ErrValueNotFound)
+		}
+		return v, nil
+	case SourceBody:
+		if len(body) == 0 {
+			return nil, fmt.Errorf("%q: empty body: %w", e, ErrValueNotFound)
+		}
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			return nil, fmt.Errorf("%q: decoding body failed: %w", e, err)
+		}
+		if e.Pointer == "" {
+			return v, nil
+		}
+		v, err := ResolvePointer(v, e.Pointer)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w: %w", e, ErrValueNotFound, err)
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%q: unsupported source %q", e, e.Source)
+	}
+}
+
+// isTokenChar checks if the given rune is allowed in the `token` as defined in RFC7230.
+//
+//	tchar = "!" / "#" / "$" / "%" / "&" / "'" / "*" / "+" / "-" / "." / "^" / "_" / "`" / "|" / "~" / DIGIT / ALPHA
+func isTokenChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	default:
+		return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+	}
+}
+
+// checkJSONPointer checks if the given value is a valid JSON Pointer (RFC6901).
+func checkJSONPointer(pointer string) error {
+	if pointer == "" {
+		return nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("json pointer %q must start with a forward slash (`/`)", pointer)
+	}
+	for i := 0; i < len(pointer); i++ {
+		if pointer[i] != '~' {
+			continue
+		}
+		if i+1 >= len(pointer) || (pointer[i+1] != '0' && pointer[i+1] != '1') {
+			return fmt.Errorf("json pointer %q contains invalid escape sequence at position %d", pointer, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestParseRuntimeExpression(t *testing.T) {
+	for _, tt := range []struct {
+		expression string
+		expected   *openapi.RuntimeExpression
+		err        string
+	}{
+		{expression: "$url", expected: &openapi.RuntimeExpression{Type: openapi.ExpressionURL}},
+		{expression: "$method", expected: &openapi.RuntimeExpression{Type: openapi.ExpressionMethod}},
+		{expression: "$statusCode", expected: &openapi.RuntimeExpression{Type: openapi.ExpressionStatusCode}},
+		{
+			expression: "$request.path.id",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionRequest, Source: openapi.SourcePath, Name: "id"},
+		},
+		{
+			expression: "$request.query.queryUrl",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionRequest, Source: openapi.SourceQuery, Name: "queryUrl"},
+		},
+		{
+			expression: "$request.header.accept",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionRequest, Source: openapi.SourceHeader, Name: "accept"},
+		},
+		{
+			expression: "$request.body",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionRequest, Source: openapi.SourceBody},
+		},
+		{
+			expression: "$request.body#/user/uuid",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionRequest, Source: openapi.SourceBody, Pointer: "/user/uuid"},
+		},
+		{
+			expression: "$response.body#/uuid",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionResponse, Source: openapi.SourceBody, Pointer: "/uuid"},
+		},
+		{
+			expression: "$response.header.Location",
+			expected:   &openapi.RuntimeExpression{Type: openapi.ExpressionResponse, Source: openapi.SourceHeader, Name: "Location"},
+		},
+		{expression: "$foo", err: "expected one of"},
+		{expression: "request.path.id", err: "expected one of"},
+		{expression: "$request", err: "source is required"},
+		{expression: "$request.path", err: "name is required"},
+		{expression: "$request.cookie.id", err: "invalid source \"cookie\""},
+		{expression: "$request.header.a b", err: "invalid character ' ' in header name"},
+		{expression: "$response.body#uuid", err: "must start with a forward slash"},
+		{expression: "$response.body#/a~2b", err: "invalid escape sequence"},
+	} {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := openapi.ParseRuntimeExpression(tt.expression)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, e)
+			require.Equal(t, tt.expression, e.String())
+		})
+	}
+}
+
+func TestRuntimeExpression_Evaluate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/users/42?active=true", nil)
+	req.SetPathValue("id", "42")
+	req.Header.Set("X-Request-ID", "abc")
+	resp := &http.Response{StatusCode: http.StatusCreated, Header: http.Header{"Location": {"/users/42"}}}
+	reqBody := []byte(`{"user": {"name": "John", "tags": ["a", "b"]}}`)
+	respBody := []byte(`{"uuid": "0b5c8c3e", "a/b": 1}`)
+
+	for _, tt := range []struct {
+		expression string
+		req        *http.Request
+		resp       *http.Response
+		body       []byte
+		expected   any
+		err        string
+	}{
+		{expression: "$url", req: req, expected: "https://example.com/users/42?active=true"},
+		{expression: "$method", req: req, expected: http.MethodPost},
+		{expression: "$statusCode", resp: resp, expected: http.StatusCreated},
+		{expression: "$request.path.id", req: req, expected: "42"},
+		{expression: "$request.query.active", req: req, expected: "true"},
+		{expression: "$request.header.x-request-id", req: req, expected: "abc"},
+		{expression: "$response.header.Location", req: req, resp: resp, expected: "/users/42"},
+		{expression: "$request.body", req: req, body: reqBody, expected: map[string]any{"user": map[string]any{"name": "John", "tags": []any{"a", "b"}}}},
+		{expression: "$request.body#/user/name", req: req, body: reqBody, expected: "John"},
+		{expression: "$request.body#/user/tags/1", req: req, body: reqBody, expected: "b"},
+		{expression: "$response.body#/uuid", req: req, resp: resp, body: respBody, expected: "0b5c8c3e"},
+		{expression: "$response.body#/a~1b", req: req, resp: resp, body: respBody, expected: float64(1)},
+		{expression: "$url", err: "request is nil"},
+		{expression: "$statusCode", req: req, err: "response is nil"},
+		{expression: "$request.path.name", req: req, err: "value not found"},
+		{expression: "$request.query.page", req: req, err: "value not found"},
+		{expression: "$request.header.Accept", req: req, err: "value not found"},
+		{expression: "$response.query.active", req: req, resp: resp, err: "value not found"},
+		{expression: "$request.body", req: req, err: "empty body"},
+		{expression: "$request.body", req: req, body: []byte("{"), err: "decoding body failed"},
+		{expression: "$request.body#/user/age", req: req, body: reqBody, err: "pointer not found"},
+		{expression: "$request.body#/user/tags/2", req: req, body: reqBody, err: "pointer not found"},
+	} {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := openapi.ParseRuntimeExpression(tt.expression)
+			require.NoError(t, err)
+			v, err := e.Evaluate(tt.req, tt.resp, tt.body)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package openapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
-	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -72,7 +74,7 @@ type Schema struct {
 	// The const keyword is used to restrict a value to a single value.
 	//
 	// https://json-schema.org/understanding-json-schema/reference/const
-	Const string `json:"const,omitempty"`
+	Const any `json:"const,omitempty"`
 	// The $comment keyword is strictly intended for adding comments to a schema.
 	// Its value must always be a string.
 	// Unlike the annotations title, description, and examples, JSON schema implementations aren’t allowed
@@ -178,10 +180,13 @@ type Schema struct {
 	// https://json-schema.org/understanding-json-schema/reference/numeric.html#numeric-types
 
 	// MultipleOf restricts the numbers to a multiple of a given number, using the multipleOf keyword.
-	// It may be set to any positive number.
+	// It may be set to any positive number, including the decimals like `0.01`,
+	// the values are compared as the exact decimals, so `0.3` is a multiple of `0.1`.
 	//
 	// https://json-schema.org/understanding-json-schema/reference/numeric.html#multiples
-	MultipleOf *int `json:"multipleOf,omitempty"`
+	MultipleOf *float64 `json:"multipleOf,omitempty"`
+	// The range keywords are integers, so a decimal bound, e.g. `"minimum": 0.5`, cannot be decoded.
+	//
 	// x ≥ minimum
 	Minimum *int `json:"minimum,omitempty"`
 	// x > exclusiveMinimum
@@ -414,7 +419,12 @@ func (o *Schema) UnmarshalJSON(data []byte) error {
 	exts := make(map[string]any)
 	keys := getFields(reflect.TypeOf(o), "json")
 	for name, value := range raw {
-		if _, ok := keys[name]; !ok {
+		// the boolean form of the OpenAPI 3.0 is kept in the extensions, see Upgrade function
+		_, ok := keys[name]
+		if (name == "exclusiveMinimum" || name == "exclusiveMaximum") && (string(value) == "true" || string(value) == "false") {
+			ok = false
+		}
+		if !ok {
 			var v any
 			if err := json.Unmarshal(value, &v); err != nil {
 				return fmt.Errorf("%T.Extensions.%s: %w", o, name, err)
@@ -436,6 +446,44 @@ func (o *Schema) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// enumContains checks if the value is one of the enum values.
+// The values are compared by their JSON representation, so the numbers of different types and
+// the objects and arrays with the same content are equal.
+func enumContains(enum []any, value any) bool {
+	want, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	for _, v := range enum {
+		if reflect.DeepEqual(value, v) {
+			return true
+		}
+		if data, err := json.Marshal(v); err == nil && bytes.Equal(want, data) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueType returns the JSON Schema type of the value, which matches one of the given types,
+// so the integral numbers are integers if the integer type is expected, and false if no type matches.
+// The values of the unsupported types are not checked.
+func valueType(types []string, value any) (string, bool) {
+	t, err := GetType(value)
+	if err != nil {
+		return "", true
+	}
+	if t == NumberType && slices.Contains(types, IntegerType) {
+		if f, ok := value.(float64); ok && f == math.Trunc(f) {
+			t = IntegerType
+		}
+	}
+	if slices.Contains(types, t) || t == IntegerType && slices.Contains(types, NumberType) {
+		return t, true
+	}
+	return t, false
+}
+
 func (o *Schema) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 
@@ -449,8 +497,11 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 		errs = append(errs, o.ExternalDocs.validateSpec(joinLoc(location, "externalDocs"), validator)...)
 	}
 	if o.Example != nil {
+		errs = append(errs, newValidationWarning(joinLoc(location, "example"), "%w in favor of `examples`", ErrDeprecated))
 		if !validator.opts.doNotValidateExamples {
-			if e := validator.ValidateData(location, o.Example); e != nil {
+			if len(o.Enum) > 0 && !enumContains(o.Enum, o.Example) {
+				errs = append(errs, newValidationError(joinLoc(location, "example"), "invalid value, expected one of enum values: %v", o.Enum))
+			} else if e := validator.ValidateData(location, o.Example); e != nil {
 				errs = append(errs, newValidationError(joinLoc(location, "example"), e))
 			}
 		}
@@ -486,10 +537,10 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 		}
 	}
 	if o.Type != nil {
-		switch len(*o.Type) {
+		switch o.Type.Len() {
 		case 0: // not type or any type
 		case 1:
-			switch v := (*o.Type)[0]; v {
+			switch v, _ := o.Type.One(); v {
 			case StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType:
 			default:
 				errs = append(errs, newValidationError(joinLoc(location, "type"), "invalid value, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType, v))
@@ -505,6 +556,20 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 		}
 	}
 
+	if o.Type != nil && o.Type.Len() > 0 {
+		types := []string(*o.Type)
+		for i, v := range o.Enum {
+			if t, ok := valueType(types, v); !ok {
+				errs = append(errs, newValidationError(joinLoc(location, "enum", i), "invalid value type, expected one of [%s], but got '%s'", strings.Join(types, ", "), t))
+			}
+		}
+		if o.Const != nil {
+			if t, ok := valueType(types, o.Const); !ok {
+				errs = append(errs, newValidationError(joinLoc(location, "const"), "invalid value type, expected one of [%s], but got '%s'", strings.Join(types, ", "), t))
+			}
+		}
+	}
+
 	// JsonSchemaMedia
 	if o.ContentSchema != nil {
 		errs = append(errs, o.ContentSchema.validateSpec(joinLoc(location, "contentSchema"), validator)...)
@@ -524,17 +589,8 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 				errs = append(errs, newValidationError(joinLoc(location, "default"), e))
 			}
 		}
-		if len(o.Enum) > 0 {
-			var found bool
-			for _, v := range o.Enum {
-				if reflect.DeepEqual(o.Default, v) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				errs = append(errs, newValidationError(joinLoc(location, "default"), "invalid value, expected one of enum values: %v", o.Enum))
-			}
+		if len(o.Enum) > 0 && !enumContains(o.Enum, o.Default) {
+			errs = append(errs, newValidationError(joinLoc(location, "default"), "invalid value, expected one of enum values: %v", o.Enum))
 		}
 	}
 
@@ -546,6 +602,13 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 		}
 	}
 
+	// the pattern applies to the strings even without the type
+	if o.Pattern != "" {
+		if e := validator.validatePattern(joinLoc(location, "pattern"), o.Pattern); e != nil {
+			errs = append(errs, e)
+		}
+	}
+
 	if o.Type == nil {
 		return errs
 	}
@@ -593,8 +656,8 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 			if o.PatternProperties != nil {
 				for k, v := range o.PatternProperties {
 					errs = append(errs, v.validateSpec(joinLoc(location, "patternProperties", k), validator)...)
-					if _, err := regexp.Compile(k); err != nil {
-						errs = append(errs, newValidationError(joinLoc(location, "patternProperties", k), err))
+					if e := validator.validatePattern(joinLoc(location, "patternProperties", k), k); e != nil {
+						errs = append(errs, e)
 					}
 				}
 			}
@@ -661,11 +724,6 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 					errs = append(errs, newValidationError(joinLoc(location, "maxLength"), "must be greater than or equal to minLength"))
 				}
 			}
-			if o.Pattern != "" {
-				if _, err := regexp.Compile(o.Pattern); err != nil {
-					errs = append(errs, newValidationError(joinLoc(location, "pattern"), err))
-				}
-			}
 		}
 	}
 	return errs
@@ -836,7 +894,7 @@ func (b *SchemaBuilder) Description(v string) *SchemaBuilder {
 	return b
 }
 
-func (b *SchemaBuilder) Const(v string) *SchemaBuilder {
+func (b *SchemaBuilder) Const(v any) *SchemaBuilder {
 	if b.spec.Ref != nil {
 		return b
 	}
@@ -1050,7 +1108,7 @@ func (b *SchemaBuilder) Else(v *RefOrSpec[Schema]) *SchemaBuilder {
 	return b
 }
 
-func (b *SchemaBuilder) MultipleOf(v int) *SchemaBuilder {
+func (b *SchemaBuilder) MultipleOf(v float64) *SchemaBuilder {
 	if b.spec.Ref != nil {
 		return b
 	}
@@ -1343,3 +1401,46 @@ func (b *SchemaBuilder) GoPackage(v string) *SchemaBuilder {
 	b.spec.Spec.GoPackage = v
 	return b
 }
+
+// ArrayOf sets the `array` type and the schema of the items, e.g. `{"type": "array", "items": {"type": "string"}}`.
+func (b *SchemaBuilder) ArrayOf(items *RefOrSpec[Schema]) *SchemaBuilder {
+	if b.spec.Ref != nil {
+		return b
+	}
+	b.spec.Spec.Type = NewSingleOrArray(ArrayType)
+	b.spec.Spec.Items = NewBoolOrSchema(items)
+	return b
+}
+
+// ObjectWith sets the `object` type, the properties and the required properties.
+func (b *SchemaBuilder) ObjectWith(props map[string]*RefOrSpec[Schema], required ...string) *SchemaBuilder {
+	if b.spec.Ref != nil {
+		return b
+	}
+	b.spec.Spec.Type = NewSingleOrArray(ObjectType)
+	b.spec.Spec.Properties = props
+	b.spec.Spec.Required = required
+	return b
+}
+
+// EnumOf sets the enum values and the types of the values, e.g. `["string", "null"]` for `"cat", nil`;
+// the integers are covered by the `number` type if both are used.
+func (b *SchemaBuilder) EnumOf(values ...any) *SchemaBuilder {
+	if b.spec.Ref != nil {
+		return b
+	}
+	var types []string
+	for _, v := range values {
+		if t, err := GetType(v); err == nil && !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	if slices.Contains(types, NumberType) {
+		types = slices.DeleteFunc(types, func(t string) bool { return t == IntegerType })
+	}
+	b.spec.Spec.Enum = values
+	if len(types) > 0 {
+		b.spec.Spec.Type = NewSingleOrArray(types...)
+	}
+	return b
+}
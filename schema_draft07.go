@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Draft07 is the identifier of JSON Schema draft-07 used by ToDraft07 function.
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+// ToDraft07 converts the given schema to the form of JSON Schema draft-07.
+//
+// The conversion rules:
+//   - the nullable unions, like `type: [string, "null"]`, are rewritten to the single type and `nullable: true`,
+//     in the way of OpenAPI 3.0, the draft-07 validators which do not support `nullable` reject the null values;
+//   - the first item of `examples` is moved to `example`, the other items are lost;
+//   - `$defs` are moved to `definitions` and the references `#/$defs/...` are updated;
+//   - `prefixItems` are converted to the array form of `items`, `items` to `additionalItems`;
+//   - `dependentRequired` and `dependentSchemas` are merged into `dependencies`;
+//   - `$schema` is set to draft-07, if it is set;
+//   - `const` is kept as is, since it is supported by draft-07.
+//
+// The keywords which cannot be expressed in draft-07 are dropped, so the result is less strict:
+// `$dynamicRef`, `$dynamicAnchor`, `$vocabulary`, `unevaluatedItems`, `unevaluatedProperties`,
+// `minContains`, `maxContains` and `contentSchema`.
+// The draft-07 keywords, which are missing in the Schema object, are stored as the extensions.
+//
+// An error is returned if a keyword conflicts with an existing extension of the same name.
+// The given schema is not modified.
+func ToDraft07(s *Schema) (*Schema, error) {
+	converted := DeepCopy(s)
+	c := draft07Converter{visited: make(map[*Schema]bool)}
+	if err := c.convert(converted, "#"); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+type draft07Converter struct {
+	visited map[*Schema]bool
+}
+
+func (c *draft07Converter) convertRef(o *RefOrSpec[Schema], location string) error {
+	if o == nil {
+		return nil
+	}
+	if o.Ref != nil {
+		if strings.HasPrefix(o.Ref.Ref, "#/$defs/") {
+			o.Ref.Ref = "#/definitions/" + o.Ref.Ref[len("#/$defs/"):]
+		}
+		return nil
+	}
+	return c.convert(o.Spec, location)
+}
+
+func (c *draft07Converter) convertMap(m map[string]*RefOrSpec[Schema], location string) error {
+	for k, v := range m {
+		if err := c.convertRef(v, joinLoc(location, k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *draft07Converter) convertList(l []*RefOrSpec[Schema], location string) error {
+	for i, v := range l {
+		if err := c.convertRef(v, joinLoc(location, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *draft07Converter) setExt(s *Schema, location, name string, value any) error {
+	if _, found := s.Extensions[name]; found {
+		return fmt.Errorf("%s: unable to set %q, the extension with the same name already exists", location, name)
+	}
+	s.AddExt(name, value)
+	return nil
+}
+
+func (c *draft07Converter) convert(s *Schema, location string) error {
+	if s == nil || c.visited[s] {
+		return nil
+	}
+	c.visited[s] = true
+
+	if s.Schema != "" {
+		s.Schema = Draft07
+	}
+	s.DynamicRef = ""
+	s.DynamicAnchor = ""
+	s.Vocabulary = nil
+	s.UnevaluatedItems = nil
+	s.UnevaluatedProperties = nil
+	s.MinContains = nil
+	s.MaxContains = nil
+	s.ContentSchema = nil
+
+	if s.Type != nil && s.Type.Len() > 1 && SingleOrArrayContains(s.Type, NullType) {
+		types := slices.DeleteFunc(slices.Clone(*s.Type), func(t string) bool { return t == NullType })
+		s.Type = NewSingleOrArray(types...)
+		if err := c.setExt(s, location, "nullable", true); err != nil {
+			return err
+		}
+	}
+	if len(s.Examples) > 0 {
+		if s.Example == nil {
+			s.Example = s.Examples[0]
+		}
+		s.Examples = nil
+	}
+
+	// the subschemas are converted before moving them into the extensions
+	for _, v := range []struct {
+		name string
+		m    map[string]*RefOrSpec[Schema]
+	}{
+		{"$defs", s.Defs},
+		{"properties", s.Properties},
+		{"patternProperties", s.PatternProperties},
+		{"dependentSchemas", s.DependentSchemas},
+	} {
+		if err := c.convertMap(v.m, joinLoc(location, v.name)); err != nil {
+			return err
+		}
+	}
+	for _, v := range []struct {
+		name string
+		l    []*RefOrSpec[Schema]
+	}{
+		{"prefixItems", s.PrefixItems},
+		{"allOf", s.AllOf},
+		{"anyOf", s.AnyOf},
+		{"oneOf", s.OneOf},
+	} {
+		if err := c.convertList(v.l, joinLoc(location, v.name)); err != nil {
+			return err
+		}
+	}
+	for _, v := range []struct {
+		name string
+		o    *RefOrSpec[Schema]
+	}{
+		{"not", s.Not},
+		{"if", s.If},
+		{"then", s.Then},
+		{"else", s.Else},
+		{"contains", s.Contains},
+		{"propertyNames", s.PropertyNames},
+	} {
+		if err := c.convertRef(v.o, joinLoc(location, v.name)); err != nil {
+			return err
+		}
+	}
+	for _, v := range []struct {
+		name string
+		o    *BoolOrSchema
+	}{
+		{"items", s.Items},
+		{"additionalProperties", s.AdditionalProperties},
+	} {
+		if v.o != nil {
+			if err := c.convertRef(v.o.Schema, joinLoc(location, v.name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(s.Defs) > 0 {
+		if err := c.setExt(s, location, "definitions", s.Defs); err != nil {
+			return err
+		}
+		s.Defs = nil
+	}
+	if len(s.PrefixItems) > 0 {
+		if s.Items != nil {
+			if err := c.setExt(s, location, "additionalItems", s.Items); err != nil {
+				return err
+			}
+		}
+		// the extension is used, because the Items field does not support the array form
+		s.Items = nil
+		if err := c.setExt(s, location, "items", s.PrefixItems); err != nil {
+			return err
+		}
+		s.PrefixItems = nil
+	}
+	if len(s.DependentRequired) > 0 || len(s.DependentSchemas) > 0 {
+		dependencies := make(map[string]any, len(s.DependentRequired)+len(s.DependentSchemas))
+		for k, v := range s.DependentRequired {
+			dependencies[k] = v
+		}
+		for k, v := range s.DependentSchemas {
+			if _, found := dependencies[k]; found {
+				return fmt.Errorf("%s: unable to merge dependentRequired and dependentSchemas of %q", joinLoc(location, "dependencies"), k)
+			}
+			dependencies[k] = v
+		}
+		if err := c.setExt(s, location, "dependencies", dependencies); err != nil {
+			return err
+		}
+		s.DependentRequired = nil
+		s.DependentSchemas = nil
+	}
+	return nil
+}
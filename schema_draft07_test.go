@@ -0,0 +1,108 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestToDraft07(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		schema   string
+		expected string
+		err      string
+	}{
+		{
+			name:     "nullable",
+			schema:   `{"type": ["string", "null"]}`,
+			expected: `{"type": "string", "nullable": true}`,
+		},
+		{
+			name:     "nullable union",
+			schema:   `{"type": ["string", "integer", "null"]}`,
+			expected: `{"type": ["string", "integer"], "nullable": true}`,
+		},
+		{
+			name:     "not nullable union",
+			schema:   `{"type": ["string", "integer"]}`,
+			expected: `{"type": ["string", "integer"]}`,
+		},
+		{
+			name:     "only null",
+			schema:   `{"type": "null"}`,
+			expected: `{"type": "null"}`,
+		},
+		{
+			name:     "nested nullable",
+			schema:   `{"type": "object", "properties": {"name": {"type": ["null", "string"]}}}`,
+			expected: `{"type": "object", "properties": {"name": {"type": "string", "nullable": true}}}`,
+		},
+		{
+			name:     "const",
+			schema:   `{"const": "cat"}`,
+			expected: `{"const": "cat"}`,
+		},
+		{
+			name:     "nullable const",
+			schema:   `{"type": ["string", "null"], "const": "cat"}`,
+			expected: `{"type": "string", "nullable": true, "const": "cat"}`,
+		},
+		{
+			name:     "examples",
+			schema:   `{"type": "string", "examples": ["cat", "dog"]}`,
+			expected: `{"type": "string", "example": "cat"}`,
+		},
+		{
+			name:     "example is preferred",
+			schema:   `{"type": "string", "example": "fish", "examples": ["cat"]}`,
+			expected: `{"type": "string", "example": "fish"}`,
+		},
+		{
+			name:     "dynamic ref",
+			schema:   `{"$schema": "https://json-schema.org/draft/2020-12/schema", "$dynamicAnchor": "node", "items": {"$dynamicRef": "#node"}, "unevaluatedProperties": false}`,
+			expected: `{"$schema": "http://json-schema.org/draft-07/schema#", "items": {}}`,
+		},
+		{
+			name:     "defs",
+			schema:   `{"$defs": {"name": {"type": ["string", "null"]}}, "properties": {"name": {"$ref": "#/$defs/name"}}}`,
+			expected: `{"definitions": {"name": {"type": "string", "nullable": true}}, "properties": {"name": {"$ref": "#/definitions/name"}}}`,
+		},
+		{
+			name:     "prefix items",
+			schema:   `{"type": "array", "prefixItems": [{"type": "string"}, {"type": "integer"}], "items": false}`,
+			expected: `{"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false}`,
+		},
+		{
+			name:     "dependencies",
+			schema:   `{"dependentRequired": {"card": ["address"]}, "dependentSchemas": {"name": {"required": ["age"]}}}`,
+			expected: `{"dependencies": {"card": ["address"], "name": {"required": ["age"]}}}`,
+		},
+		{
+			name:   "conflict",
+			schema: `{"type": ["string", "null"], "nullable": false}`,
+			err:    `#: unable to set "nullable", the extension with the same name already exists`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema openapi.Schema
+			require.NoError(t, json.Unmarshal([]byte(tt.schema), &schema))
+			converted, err := openapi.ToDraft07(&schema)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(converted)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, string(data))
+
+			// the original schema is not modified
+			data, err = json.Marshal(&schema)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.schema, string(data))
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultExampleMaxDepth is the default max depth of the nested objects and arrays generated by GenerateExample.
+const DefaultExampleMaxDepth = 10
+
+type exampleOptions struct {
+	maxDepth int
+}
+
+// ExampleOption is a type for the options of GenerateExample function.
+type ExampleOption func(*exampleOptions)
+
+// ErrExampleMaxDepth is returned by GenerateExample function if the required properties are nested deeper
+// than the max depth, e.g. the schema requires a property referencing the schema itself.
+var ErrExampleMaxDepth = errors.New("max depth of the example is exceeded")
+
+// ExampleMaxDepth is an option to set the max depth of the nested objects and arrays.
+// When the depth is reached, only the required properties are generated and the arrays are empty,
+// the objects are not expanded beyond the depth, so the recursive schemas always terminate.
+func ExampleMaxDepth(depth int) ExampleOption {
+	return func(o *exampleOptions) {
+		o.maxDepth = depth
+	}
+}
+
+// formatExamples is the list of the example values for the known string formats.
+var formatExamples = map[string]string{
+	DateTimeFormat:            "2018-11-13T20:20:39Z",
+	TimeFormat:                "20:20:39Z",
+	DateFormat:                "2018-11-13",
+	DurationFormat:            "P3D",
+	EmailFormat:               "user@example.com",
+	IDNEmailFormat:            "user@example.com",
+	HostnameFormat:            "example.com",
+	IDNHostnameFormat:         "example.com",
+	IPv4Format:                "192.0.2.1",
+	IPv6Format:                "2001:db8::1",
+	UUIDFormat:                "3e4666bf-d5e5-4aa7-b8ce-cefe41c7568a",
+	URIFormat:                 "https://example.com",
+	URIReferenceFormat:        "/example",
+	IRIFormat:                 "https://example.com",
+	IRIReferenceFormat:        "/example",
+	URITemplateFormat:         "https://example.com/{id}",
+	JsonPointerFormat:         "/example",
+	RelativeJsonPointerFormat: "0",
+	RegexFormat:               ".*",
+	PasswordFormat:            "password",
+}
+
+// GenerateExample creates an example value for the given schema.
+//
+// The value is taken from `const`, `default`, `examples` or `enum` (the first item) keywords, if they are set,
+// otherwise it is generated according to `type`, `format`, `properties`, `items` and the numeric and the length limits.
+// The first branch of `oneOf` and `anyOf` is used, the objects generated for `allOf` are merged.
+// The references are resolved using the given components.
+//
+// The `pattern` keyword is not supported, so the generated strings can be invalid for such schemas.
+func GenerateExample(s *RefOrSpec[Schema], c *Extendable[Components], opts ...ExampleOption) (any, error) {
+	options := exampleOptions{maxDepth: DefaultExampleMaxDepth}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g := exampleGenerator{components: c, maxDepth: options.maxDepth}
+	return g.generate(s, 0)
+}
+
+type exampleGenerator struct {
+	components *Extendable[Components]
+	maxDepth   int
+	// expanding are the references currently being expanded with their depths
+	expanding []expandingRef
+}
+
+type expandingRef struct {
+	ref   string
+	depth int
+}
+
+func (g *exampleGenerator) generate(s *RefOrSpec[Schema], depth int) (any, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Ref != nil {
+		current := expandingRef{ref: s.Ref.Ref, depth: depth}
+		// the compositions do not increase the depth, so the same reference at the same depth is a cycle,
+		// e.g. `allOf: [{$ref: self}]`, which adds nothing to the example
+		if slices.Contains(g.expanding, current) {
+			return nil, nil
+		}
+		g.expanding = append(g.expanding, current)
+		defer func() { g.expanding = g.expanding[:len(g.expanding)-1] }()
+	}
+	schema, err := s.GetSpec(g.components)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case schema.Const != nil:
+		return schema.Const, nil
+	case schema.Default != nil:
+		return schema.Default, nil
+	case len(schema.Examples) > 0:
+		return schema.Examples[0], nil
+	case schema.Example != nil:
+		return schema.Example, nil
+	case len(schema.Enum) > 0:
+		return schema.Enum[0], nil
+	}
+
+	var value any
+	for _, sub := range schema.AllOf {
+		v, err := g.generate(sub, depth)
+		if err != nil {
+			return nil, err
+		}
+		value = mergeExamples(value, v)
+	}
+	if len(schema.OneOf) > 0 {
+		v, err := g.generate(schema.OneOf[0], depth)
+		if err != nil {
+			return nil, err
+		}
+		value = mergeExamples(value, v)
+	}
+	if len(schema.AnyOf) > 0 {
+		v, err := g.generate(schema.AnyOf[0], depth)
+		if err != nil {
+			return nil, err
+		}
+		value = mergeExamples(value, v)
+	}
+
+	var typ string
+	switch {
+	case schema.Type != nil:
+		// prefer a non-null type
+		for _, t := range *schema.Type {
+			if typ == "" || typ == NullType {
+				typ = t
+			}
+		}
+	case len(schema.Properties) > 0 || len(schema.Required) > 0 || schema.AdditionalProperties != nil:
+		typ = ObjectType
+	case schema.Items != nil || len(schema.PrefixItems) > 0:
+		typ = ArrayType
+	case value != nil:
+		// the composition defines the type
+		return value, nil
+	}
+
+	switch typ {
+	case ObjectType:
+		v, err := g.object(schema, depth)
+		if err != nil {
+			return nil, err
+		}
+		return mergeExamples(value, v), nil
+	case ArrayType:
+		return g.array(schema, depth)
+	case StringType:
+		return exampleString(schema), nil
+	case IntegerType:
+		return exampleInteger(schema), nil
+	case NumberType:
+		return float64(exampleInteger(schema)), nil
+	case BooleanType:
+		return true, nil
+	case NullType:
+		return nil, nil
+	case "":
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unable to generate example: unsupported type %q", typ)
+	}
+}
+
+func (g *exampleGenerator) object(schema *Schema, depth int) (any, error) {
+	if depth > g.maxDepth {
+		// only the required properties lead here
+		if len(schema.Required) > 0 {
+			return nil, ErrExampleMaxDepth
+		}
+		return map[string]any{}, nil
+	}
+	obj := make(map[string]any, len(schema.Properties))
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		if depth < g.maxDepth || slices.Contains(schema.Required, name) {
+			names = append(names, name)
+		}
+	}
+	for _, name := range schema.Required {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		prop, found := schema.Properties[name]
+		if !found && schema.AdditionalProperties != nil {
+			prop = schema.AdditionalProperties.Schema
+		}
+		if prop == nil {
+			obj[name] = ""
+			continue
+		}
+		v, err := g.generate(prop, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		obj[name] = v
+	}
+	return obj, nil
+}
+
+func (g *exampleGenerator) array(schema *Schema, depth int) (any, error) {
+	arr := make([]any, 0, len(schema.PrefixItems)+1)
+	if depth >= g.maxDepth {
+		return arr, nil
+	}
+	for i, item := range schema.PrefixItems {
+		v, err := g.generate(item, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+		arr = append(arr, v)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		n := 1
+		if schema.MinItems != nil && *schema.MinItems > n {
+			n = *schema.MinItems
+		}
+		for i := len(arr); i < n; i++ {
+			v, err := g.generate(schema.Items.Schema, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			arr = append(arr, v)
+		}
+	}
+	if schema.MaxItems != nil && len(arr) > *schema.MaxItems {
+		arr = arr[:*schema.MaxItems]
+	}
+	return arr, nil
+}
+
+// mergeExamples merges the properties of the objects, otherwise the new value is returned if it is set.
+func mergeExamples(current, value any) any {
+	if value == nil {
+		return current
+	}
+	c, ok1 := current.(map[string]any)
+	v, ok2 := value.(map[string]any)
+	if !ok1 || !ok2 {
+		return value
+	}
+	for k, val := range v {
+		c[k] = mergeExamples(c[k], val)
+	}
+	return c
+}
+
+func exampleString(schema *Schema) string {
+	if v, found := formatExamples[schema.Format]; found {
+		return v
+	}
+	v := "string"
+	if schema.MinLength != nil && len(v) < *schema.MinLength {
+		v += strings.Repeat("x", *schema.MinLength-len(v))
+	}
+	if schema.MaxLength != nil && len(v) > *schema.MaxLength {
+		v = v[:*schema.MaxLength]
+	}
+	return v
+}
+
+func exampleInteger(schema *Schema) int {
+	var v int
+	if schema.Minimum != nil && v < *schema.Minimum {
+		v = *schema.Minimum
+	}
+	if schema.ExclusiveMinimum != nil && v <= *schema.ExclusiveMinimum {
+		v = *schema.ExclusiveMinimum + 1
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 && !isMultipleOf(float64(v), *schema.MultipleOf) {
+		// the next multiple, which is an integer too
+		if step := int(lcmOf(*schema.MultipleOf, 1)); step > 0 {
+			v += step - ((v%step)+step)%step
+		}
+	}
+	if schema.Maximum != nil && v > *schema.Maximum {
+		v = *schema.Maximum
+	}
+	if schema.ExclusiveMaximum != nil && v >= *schema.ExclusiveMaximum {
+		v = *schema.ExclusiveMaximum - 1
+	}
+	return v
+}
@@ -0,0 +1,128 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const exampleSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Examples", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["id", "name", "kind"],
+        "properties": {
+          "id": {"type": "integer", "format": "int64", "minimum": 1},
+          "name": {"type": "string", "minLength": 10},
+          "kind": {"type": "string", "enum": ["cat", "dog"]},
+          "status": {"type": "string", "default": "available"},
+          "born": {"type": "string", "format": "date"},
+          "uid": {"type": "string", "format": "uuid"},
+          "weight": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 5},
+          "tags": {"type": "array", "items": {"type": "string"}, "minItems": 2, "maxItems": 3},
+          "owner": {"$ref": "#/components/schemas/Owner"}
+        }
+      },
+      "Owner": {"type": ["null", "object"], "properties": {"email": {"type": "string", "format": "email"}}},
+      "Cat": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Pet"},
+          {"type": "object", "required": ["meow"], "properties": {"meow": {"type": "boolean"}}}
+        ]
+      },
+      "Shape": {
+        "oneOf": [
+          {"type": "object", "required": ["radius"], "properties": {"radius": {"type": "number"}}, "additionalProperties": false},
+          {"type": "object", "required": ["side"], "properties": {"side": {"type": "number"}}, "additionalProperties": false}
+        ]
+      },
+      "Node": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"type": "string", "examples": ["root"]},
+          "parent": {"$ref": "#/components/schemas/Node"},
+          "children": {"type": "array", "items": {"$ref": "#/components/schemas/Node"}}
+        }
+      },
+      "Chain": {
+        "type": "object",
+        "required": ["next"],
+        "properties": {"next": {"$ref": "#/components/schemas/Chain"}}
+      },
+      "Self": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Self"},
+          {"$ref": "#/components/schemas/Other"},
+          {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}
+        ]
+      },
+      "Other": {"allOf": [{"$ref": "#/components/schemas/Self"}]}
+    }
+  }
+}`
+
+func TestGenerateExample(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(exampleSpec), &spec))
+	validator, err := openapi.NewValidator(&spec, openapi.ValidateFormats(), openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+
+	for _, name := range []string{"Pet", "Owner", "Cat", "Shape", "Node"} {
+		t.Run(name, func(t *testing.T) {
+			ref := openapi.NewSchemaBuilder().Ref("#/components/schemas/" + name).Build()
+			example, err := openapi.GenerateExample(ref, spec.Spec.Components)
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateData("#/components/schemas/"+name, example))
+		})
+	}
+
+	t.Run("values", func(t *testing.T) {
+		example, err := openapi.GenerateExample(spec.Spec.Components.Spec.Schemas["Pet"], spec.Spec.Components)
+		require.NoError(t, err)
+		data, err := json.Marshal(example)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "id": 1,
+  "name": "stringxxxx",
+  "kind": "cat",
+  "status": "available",
+  "born": "2018-11-13",
+  "uid": "3e4666bf-d5e5-4aa7-b8ce-cefe41c7568a",
+  "weight": 5,
+  "tags": ["string", "string"],
+  "owner": {"email": "user@example.com"}
+}`, string(data))
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		example, err := openapi.GenerateExample(spec.Spec.Components.Spec.Schemas["Node"], spec.Spec.Components, openapi.ExampleMaxDepth(1))
+		require.NoError(t, err)
+		data, err := json.Marshal(example)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name": "root", "parent": {"name": "root"}, "children": []}`, string(data))
+	})
+
+	t.Run("required recursion", func(t *testing.T) {
+		_, err := openapi.GenerateExample(spec.Spec.Components.Spec.Schemas["Chain"], spec.Spec.Components, openapi.ExampleMaxDepth(3))
+		require.Equal(t, true, errors.Is(err, openapi.ErrExampleMaxDepth))
+		require.ErrorContains(t, err, "next: next: next: next: max depth of the example is exceeded")
+	})
+
+	t.Run("allOf cycle", func(t *testing.T) {
+		example, err := openapi.GenerateExample(spec.Spec.Components.Spec.Schemas["Self"], spec.Spec.Components)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"id": 0}, example)
+	})
+
+	t.Run("missing ref", func(t *testing.T) {
+		_, err := openapi.GenerateExample(openapi.NewSchemaBuilder().Ref("#/components/schemas/Missing").Build(), spec.Spec.Components)
+		require.ErrorContains(t, err, "not found")
+	})
+}
@@ -0,0 +1,238 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FlattenAllOf merges the subschemas of `allOf` into a single effective schema.
+//
+// The `properties` are merged, the colliding properties are merged recursively,
+// the `required` lists are united and the constraints like `minimum` or `maxLength` are intersected.
+// The nested `allOf` are flattened too, the references are resolved using the given components.
+// The annotations, like `title` or `description`, are taken from the first schema which has them.
+//
+// An error is returned if the schemas cannot be merged, e.g. the types or the patterns are in conflict,
+// or if the schema reaches itself through `allOf`, e.g. `A: {allOf: [{$ref: A}]}`, see SpecNotFoundError.Cycle.
+// The given schema is not modified.
+func FlattenAllOf(s *Schema, c *Extendable[Components]) (*Schema, error) {
+	return flattenAllOf(s, c, nil)
+}
+
+// flattenAllOf flattens the schema, the chain is the list of the refs being flattened to detect the cycles.
+func flattenAllOf(s *Schema, c *Extendable[Components], chain []string) (*Schema, error) {
+	flat := DeepCopy(s)
+	flat.AllOf = nil
+	for i, sub := range s.AllOf {
+		spec, err := sub.getSpec(c, chain)
+		if err != nil {
+			return nil, fmt.Errorf("allOf/%d: %w", i, err)
+		}
+		subChain := chain
+		if sub.Ref != nil {
+			subChain = append(slices.Clone(chain), sub.Ref.Ref)
+		}
+		spec, err = flattenAllOf(spec, c, subChain)
+		if err != nil {
+			return nil, fmt.Errorf("allOf/%d: %w", i, err)
+		}
+		if err := mergeSchema(flat, spec, c, subChain); err != nil {
+			return nil, fmt.Errorf("allOf/%d: %w", i, err)
+		}
+	}
+	return flat, nil
+}
+
+// annotationFields is the list of the fields, which do not affect validation,
+// so the value of the first schema is used in case of conflict.
+var annotationFields = []string{
+	"$schema", "$id", "$comment", "title", "description", "default", "examples", "example",
+	"externalDocs", "xml", "deprecated", "readOnly", "writeOnly",
+}
+
+// mergeSchema merges the src schema into the dst one, both schemas must be the copies.
+func mergeSchema(dst, src *Schema, c *Extendable[Components], chain []string) error {
+	var err error
+	if dst.Type, err = mergeTypes(dst.Type, src.Type); err != nil {
+		return err
+	}
+	if dst.Enum, err = mergeEnums(dst.Enum, src.Enum); err != nil {
+		return err
+	}
+	if dst.Properties, err = mergeProperties(dst.Properties, src.Properties, c, chain); err != nil {
+		return err
+	}
+	if dst.Items, err = mergeBoolOrSchema(dst.Items, src.Items, c, chain); err != nil {
+		return fmt.Errorf("items: %w", err)
+	}
+	if dst.AdditionalProperties, err = mergeBoolOrSchema(dst.AdditionalProperties, src.AdditionalProperties, c, chain); err != nil {
+		return fmt.Errorf("additionalProperties: %w", err)
+	}
+	for _, name := range src.Required {
+		if !slices.Contains(dst.Required, name) {
+			dst.Required = append(dst.Required, name)
+		}
+	}
+
+	dst.Minimum = maxOf(dst.Minimum, src.Minimum)
+	dst.ExclusiveMinimum = maxOf(dst.ExclusiveMinimum, src.ExclusiveMinimum)
+	dst.Maximum = minOf(dst.Maximum, src.Maximum)
+	dst.ExclusiveMaximum = minOf(dst.ExclusiveMaximum, src.ExclusiveMaximum)
+	dst.MinLength = maxOf(dst.MinLength, src.MinLength)
+	dst.MaxLength = minOf(dst.MaxLength, src.MaxLength)
+	dst.MinItems = maxOf(dst.MinItems, src.MinItems)
+	dst.MaxItems = minOf(dst.MaxItems, src.MaxItems)
+	dst.MinContains = maxOf(dst.MinContains, src.MinContains)
+	dst.MaxContains = minOf(dst.MaxContains, src.MaxContains)
+	dst.MinProperties = maxOf(dst.MinProperties, src.MinProperties)
+	dst.MaxProperties = minOf(dst.MaxProperties, src.MaxProperties)
+	if dst.MultipleOf != nil && src.MultipleOf != nil {
+		v := lcmOf(*dst.MultipleOf, *src.MultipleOf)
+		dst.MultipleOf = &v
+	}
+	if src.UniqueItems != nil && *src.UniqueItems {
+		dst.UniqueItems = src.UniqueItems
+	}
+	dst.ReadOnly = dst.ReadOnly || src.ReadOnly
+	dst.WriteOnly = dst.WriteOnly || src.WriteOnly
+	dst.Deprecated = dst.Deprecated || src.Deprecated
+
+	for k, v := range src.Extensions {
+		if _, found := dst.Extensions[k]; !found {
+			dst.AddExt(k, v)
+		}
+	}
+
+	// copy or check all other fields
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src).Elem()
+	for i := range dv.NumField() {
+		field := dv.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-", "", "type", "enum", "properties", "items", "additionalProperties", "required", "allOf",
+			"minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum", "minLength", "maxLength",
+			"minItems", "maxItems", "minContains", "maxContains", "minProperties", "maxProperties", "multipleOf",
+			"uniqueItems", "readOnly", "writeOnly", "deprecated":
+			continue
+		}
+		d, s := dv.Field(i), sv.Field(i)
+		switch {
+		case s.IsZero():
+		case d.IsZero():
+			// the src schema is a copy created by FlattenAllOf, so the values can be shared
+			d.Set(s)
+		case !reflect.DeepEqual(d.Interface(), s.Interface()) && !slices.Contains(annotationFields, name):
+			return fmt.Errorf("unable to merge conflicting %q", name)
+		}
+	}
+	return nil
+}
+
+func mergeTypes(dst, src *SingleOrArray[string]) (*SingleOrArray[string], error) {
+	switch {
+	case src == nil:
+		return dst, nil
+	case dst == nil:
+		return NewSingleOrArray(*src...), nil
+	}
+	var types SingleOrArray[string]
+	for _, t := range *dst {
+		// an integer is a number as well
+		if SingleOrArrayContains(src, t) || t == IntegerType && SingleOrArrayContains(src, NumberType) {
+			types = append(types, t)
+		} else if t == NumberType && SingleOrArrayContains(src, IntegerType) {
+			types = append(types, IntegerType)
+		}
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("unable to merge conflicting types %v and %v", *dst, *src)
+	}
+	return &types, nil
+}
+
+func mergeEnums(dst, src []any) ([]any, error) {
+	switch {
+	case src == nil:
+		return dst, nil
+	case dst == nil:
+		return slices.Clone(src), nil
+	}
+	var enum []any
+	for _, v := range dst {
+		if slices.ContainsFunc(src, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+			enum = append(enum, v)
+		}
+	}
+	if len(enum) == 0 {
+		return nil, fmt.Errorf("unable to merge conflicting enums %v and %v", dst, src)
+	}
+	return enum, nil
+}
+
+func mergeProperties(dst, src map[string]*RefOrSpec[Schema], c *Extendable[Components], chain []string) (map[string]*RefOrSpec[Schema], error) {
+	for name, prop := range src {
+		if dst == nil {
+			dst = make(map[string]*RefOrSpec[Schema], len(src))
+		}
+		existing, found := dst[name]
+		if !found {
+			dst[name] = prop
+			continue
+		}
+		merged, err := mergeRefOrSchema(existing, prop, c, chain)
+		if err != nil {
+			return nil, fmt.Errorf("properties/%s: %w", name, err)
+		}
+		dst[name] = merged
+	}
+	return dst, nil
+}
+
+// mergeRefOrSchema merges two schemas by flattening the allOf of both.
+func mergeRefOrSchema(a, b *RefOrSpec[Schema], c *Extendable[Components], chain []string) (*RefOrSpec[Schema], error) {
+	if reflect.DeepEqual(a, b) {
+		return a, nil
+	}
+	merged, err := flattenAllOf(&Schema{AllOf: []*RefOrSpec[Schema]{a, b}}, c, chain)
+	if err != nil {
+		return nil, err
+	}
+	return NewRefOrSpec[Schema](merged), nil
+}
+
+func mergeBoolOrSchema(dst, src *BoolOrSchema, c *Extendable[Components], chain []string) (*BoolOrSchema, error) {
+	switch {
+	case src == nil:
+		return dst, nil
+	case dst == nil:
+		return src, nil
+	case dst.Schema == nil && !dst.Allowed || src.Schema == nil && !src.Allowed:
+		return &BoolOrSchema{Allowed: false}, nil
+	case src.Schema == nil:
+		return dst, nil
+	case dst.Schema == nil:
+		return src, nil
+	}
+	merged, err := mergeRefOrSchema(dst.Schema, src.Schema, c, chain)
+	if err != nil {
+		return nil, err
+	}
+	return &BoolOrSchema{Schema: merged, Allowed: true}, nil
+}
+
+func maxOf(a, b *int) *int {
+	if a == nil || b != nil && *b > *a {
+		return b
+	}
+	return a
+}
+
+func minOf(a, b *int) *int {
+	if a == nil || b != nil && *b < *a {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,132 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+const flattenSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Flatten", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Named": {
+        "type": "object",
+        "title": "Named",
+        "required": ["name"],
+        "properties": {"name": {"type": "string", "maxLength": 100}}
+      },
+      "Pet": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Named"},
+          {
+            "type": "object",
+            "required": ["id", "name"],
+            "properties": {"id": {"type": "integer", "minimum": 1}, "name": {"minLength": 1, "maxLength": 50}}
+          }
+        ]
+      },
+      "Cat": {
+        "title": "Cat",
+        "description": "A cat",
+        "allOf": [
+          {"$ref": "#/components/schemas/Pet"},
+          {"properties": {"id": {"maximum": 100, "minimum": 10}, "lives": {"type": "integer"}}, "required": ["lives"]}
+        ]
+      },
+      "Conflict": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Named"},
+          {"type": "array"}
+        ]
+      },
+      "Self": {"allOf": [{"$ref": "#/components/schemas/Self"}]},
+      "A": {"allOf": [{"$ref": "#/components/schemas/B"}, {"type": "object"}]},
+      "B": {"allOf": [{"$ref": "#/components/schemas/A"}]},
+      "Tree": {
+        "allOf": [
+          {"properties": {"child": {"$ref": "#/components/schemas/Tree"}}},
+          {"properties": {"child": {"type": "object"}}}
+        ]
+      },
+      "PropertyConflict": {
+        "allOf": [
+          {"properties": {"id": {"type": "string", "pattern": "^a"}}},
+          {"properties": {"id": {"type": "string", "pattern": "^b"}}}
+        ]
+      }
+    }
+  }
+}`
+
+func TestFlattenAllOf(t *testing.T) {
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(flattenSpec), &spec))
+	schemas := spec.Spec.Components.Spec.Schemas
+
+	t.Run("nested", func(t *testing.T) {
+		flat, err := openapi.FlattenAllOf(schemas["Cat"].Spec, spec.Spec.Components)
+		require.NoError(t, err)
+		data, err := json.Marshal(flat)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "title": "Cat",
+  "description": "A cat",
+  "type": "object",
+  "required": ["name", "id", "lives"],
+  "properties": {
+    "id": {"type": "integer", "minimum": 10, "maximum": 100},
+    "name": {"type": "string", "minLength": 1, "maxLength": 50},
+    "lives": {"type": "integer"}
+  }
+}`, string(data))
+
+		// the original schemas are not changed
+		require.Len(t, schemas["Cat"].Spec.AllOf, 2)
+		require.Equal(t, 100, *schemas["Named"].Spec.Properties["name"].Spec.MaxLength)
+	})
+
+	t.Run("no allOf", func(t *testing.T) {
+		flat, err := openapi.FlattenAllOf(schemas["Named"].Spec, spec.Spec.Components)
+		require.NoError(t, err)
+		require.Equal(t, schemas["Named"].Spec, flat)
+	})
+
+	t.Run("conflicting types", func(t *testing.T) {
+		_, err := openapi.FlattenAllOf(schemas["Conflict"].Spec, spec.Spec.Components)
+		require.ErrorContains(t, err, "allOf/1: unable to merge conflicting types [object] and [array]")
+	})
+
+	t.Run("conflicting properties", func(t *testing.T) {
+		_, err := openapi.FlattenAllOf(schemas["PropertyConflict"].Spec, spec.Spec.Components)
+		require.ErrorContains(t, err, `allOf/1: properties/id: allOf/1: unable to merge conflicting "pattern"`)
+	})
+
+	for _, tt := range []struct {
+		name  string
+		err   string
+		cycle []string
+	}{
+		{name: "Self", err: `allOf/0: allOf/0: spec not found: cycle ref "#/components/schemas/Self" detected`, cycle: []string{"#/components/schemas/Self", "#/components/schemas/Self"}},
+		{name: "A", err: `allOf/0: allOf/0: allOf/0: spec not found: cycle ref "#/components/schemas/B" detected`, cycle: []string{"#/components/schemas/B", "#/components/schemas/A", "#/components/schemas/B"}},
+		{name: "Tree", err: "allOf/1: properties/child: allOf/0: allOf/1: properties/child: allOf/0: spec not found: cycle ref", cycle: []string{"#/components/schemas/Tree", "#/components/schemas/Tree"}},
+	} {
+		t.Run("cycle "+tt.name, func(t *testing.T) {
+			_, err := openapi.FlattenAllOf(schemas[tt.name].Spec, spec.Spec.Components)
+			require.ErrorContains(t, err, tt.err)
+			var notFound *openapi.SpecNotFoundError
+			require.Equal(t, true, errors.As(err, &notFound))
+			require.Equal(t, tt.cycle, notFound.Cycle())
+		})
+	}
+
+	t.Run("missing ref", func(t *testing.T) {
+		schema := openapi.NewSchemaBuilder().AllOf(openapi.NewSchemaBuilder().Ref("#/components/schemas/Missing").Build()).Build()
+		_, err := openapi.FlattenAllOf(schema.Spec, spec.Spec.Components)
+		require.ErrorContains(t, err, "allOf/0: spec not found")
+	})
+}
@@ -0,0 +1,159 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SchemaResolver resolves the references of the schemas embedded into the schema resources,
+// which are the schemas with `$id`, e.g. `$ref: tag` inside the schema with `$id: https://example.com/pet`
+// refers to the schema with `$id: https://example.com/tag`.
+//
+// The relative references are resolved against the base URI established by the nearest enclosing `$id`,
+// the fragments are the JSON Pointers within the referenced resource, e.g. `#/$defs/owner`.
+// The references outside of the schema resources are resolved by GetSpec method as usual.
+//
+// The resolver is built for the current state of the document, create a new one if the document is changed.
+type SchemaResolver struct {
+	components *Extendable[Components]
+	// bases are the base URIs of the references inside the schema resources
+	bases map[*RefOrSpec[Schema]]*url.URL
+	// resources are the schemas with `$id` by their absolute URIs without the fragments
+	resources map[string]*RefOrSpec[Schema]
+}
+
+// schemaScope is an entry of the stack of the base URIs, the location is the location of the schema with `$id`.
+type schemaScope struct {
+	location string
+	base     *url.URL
+}
+
+// NewSchemaResolver creates a resolver for the schemas of the given document.
+func NewSchemaResolver(doc *OpenAPI) *SchemaResolver {
+	r := &SchemaResolver{
+		components: doc.Components,
+		bases:      make(map[*RefOrSpec[Schema]]*url.URL),
+		resources:  make(map[string]*RefOrSpec[Schema]),
+	}
+	// the document has no retrieval URI, so the base URI of the top-level schemas is empty
+	stack := []schemaScope{{base: &url.URL{}}}
+	_ = Walk(doc, func(location string, node any) error {
+		schema, ok := node.(*RefOrSpec[Schema])
+		if !ok {
+			return nil
+		}
+		// the depth-first traversal leaves the resource when the location is not nested anymore
+		for len(stack) > 1 {
+			top := stack[len(stack)-1].location
+			if location == top || strings.HasPrefix(location, top+"/") {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		base := stack[len(stack)-1].base
+		switch {
+		case schema.Ref != nil:
+			if len(stack) > 1 {
+				r.bases[schema] = base
+			}
+		case schema.Spec != nil && schema.Spec.ID != "":
+			id, err := url.Parse(schema.Spec.ID)
+			if err != nil {
+				return nil
+			}
+			u := base.ResolveReference(id)
+			u.Fragment = ""
+			r.resources[u.String()] = schema
+			stack = append(stack, schemaScope{location: location, base: u})
+		}
+		return nil
+	})
+	return r
+}
+
+// Resolve returns the schema referenced by the given object, or the spec of the object if it is not a reference.
+func (r *SchemaResolver) Resolve(o *RefOrSpec[Schema]) (*Schema, error) {
+	return r.resolve(o, nil)
+}
+
+// BaseURI returns the base URI to resolve the reference of the given object, which is embedded into a schema resource,
+// or an empty string otherwise.
+func (r *SchemaResolver) BaseURI(o *RefOrSpec[Schema]) string {
+	if base, found := r.bases[o]; found {
+		return base.String()
+	}
+	return ""
+}
+
+// isEmbedded returns true if the given reference is inside a schema resource.
+func (r *SchemaResolver) isEmbedded(o *RefOrSpec[Schema]) bool {
+	if r == nil || o.Ref == nil {
+		return false
+	}
+	_, found := r.bases[o]
+	return found
+}
+
+// absoluteRef returns the reference resolved against the base URI of the enclosing schema resource.
+func (r *SchemaResolver) absoluteRef(o *RefOrSpec[Schema]) (*url.URL, error) {
+	ref, err := url.Parse(o.Ref.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ref %q: %w", o.Ref.Ref, err)
+	}
+	return r.bases[o].ResolveReference(ref), nil
+}
+
+func (r *SchemaResolver) resolve(o *RefOrSpec[Schema], chain []string) (*Schema, error) {
+	if !r.isEmbedded(o) {
+		return o.getSpec(r.components, chain)
+	}
+	u, err := r.absoluteRef(o)
+	if err != nil {
+		return nil, newRefReasonError(ErrRefTargetNotFound, err.Error(), chain)
+	}
+	target := u.String()
+	if slices.Contains(chain, target) {
+		cycle := append(slices.Clone(chain[slices.Index(chain, target):]), target)
+		return nil, &SpecNotFoundError{
+			message: fmt.Sprintf("cycle ref %q detected: %s", target, strings.Join(cycle, " -> ")),
+			visited: chain,
+			cycle:   cycle,
+		}
+	}
+	chain = append(chain, target)
+
+	fragment := u.Fragment
+	u.Fragment = ""
+	obj, found := r.resources[u.String()]
+	if !found {
+		return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("schema resource %q not found", u.String()), chain)
+	}
+	if fragment != "" {
+		if !strings.HasPrefix(fragment, "/") {
+			return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("anchor %q is not supported, use JSON Pointer", fragment), chain)
+		}
+		v, err := ResolvePointer(obj, fragment)
+		if err != nil {
+			return nil, newRefReasonError(ErrRefTargetNotFound, fmt.Sprintf("%q: %v", target, err), chain)
+		}
+		switch t := v.(type) {
+		case *RefOrSpec[Schema]:
+			obj = t
+		case *BoolOrSchema:
+			obj = t.Schema
+		case *Schema:
+			return t, nil
+		default:
+			obj = nil
+		}
+		if obj == nil {
+			return nil, newRefReasonError(ErrRefWrongType, fmt.Sprintf("expected schema at %q, but got %T", target, v), chain)
+		}
+	}
+	if obj.Ref != nil {
+		return r.resolve(obj, chain)
+	}
+	return obj.Spec, nil
+}
@@ -0,0 +1,95 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestSchemaResolver(t *testing.T) {
+	data := `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Pet": {
+        "$id": "https://example.com/schemas/pet",
+        "type": "object",
+        "properties": {
+          "tag": {"$ref": "tag"},
+          "owner": {"$ref": "#/$defs/owner"},
+          "alias": {"$ref": "https://example.com/schemas/tag"}
+        },
+        "$defs": {
+          "tag": {"$id": "tag", "type": "string"},
+          "owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+        }
+      },
+      "Tag": {"$ref": "#/components/schemas/Pet"}
+    }
+  }
+}`
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+	pet := doc.Spec.Components.Spec.Schemas["Pet"].Spec
+
+	t.Run("resolve", func(t *testing.T) {
+		resolver := openapi.NewSchemaResolver(doc.Spec)
+
+		tag := pet.Properties["tag"]
+		require.Equal(t, "https://example.com/schemas/pet", resolver.BaseURI(tag))
+		schema, err := resolver.Resolve(tag)
+		require.NoError(t, err)
+		require.Equal(t, pet.Defs["tag"].Spec, schema)
+
+		schema, err = resolver.Resolve(pet.Properties["alias"])
+		require.NoError(t, err)
+		require.Equal(t, pet.Defs["tag"].Spec, schema)
+
+		schema, err = resolver.Resolve(pet.Properties["owner"])
+		require.NoError(t, err)
+		require.Equal(t, pet.Defs["owner"].Spec, schema)
+
+		// the refs outside of the schema resources are resolved by the components
+		ref := doc.Spec.Components.Spec.Schemas["Tag"]
+		require.Equal(t, "", resolver.BaseURI(ref))
+		schema, err = resolver.Resolve(ref)
+		require.NoError(t, err)
+		require.Equal(t, pet, schema)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		validator, err := openapi.NewValidator(&doc, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+		require.NoError(t, validator.ValidateData("#/components/schemas/Pet", map[string]any{"tag": "cat", "owner": map[string]any{"name": "Tom"}}))
+		require.Error(t, validator.ValidateData("#/components/schemas/Pet", map[string]any{"tag": 1}))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		spec := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddComponent("Pet", openapi.NewSchemaBuilder().
+				ID("https://example.com/schemas/pet").
+				Type(openapi.ObjectType).
+				AddProperty("tag", openapi.NewSchemaBuilder().Ref("tag").Build()).
+				AddProperty("owner", openapi.NewSchemaBuilder().Ref("#/$defs/owner").Build()).
+				Build()).
+			Build()
+		resolver := openapi.NewSchemaResolver(spec.Spec)
+		_, err := resolver.Resolve(spec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties["tag"])
+		require.ErrorContains(t, err, `schema resource "https://example.com/schemas/tag" not found`)
+		require.Equal(t, true, errors.Is(err, openapi.ErrRefTargetNotFound))
+
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		errs, _ := validator.Validate()
+		require.Len(t, errs, 2)
+		require.ErrorContains(t, errs[0], `/components/schemas/Pet/properties/owner: spec not found: "https://example.com/schemas/pet#/$defs/owner"`)
+		require.ErrorContains(t, errs[1], `/components/schemas/Pet/properties/tag: spec not found: schema resource "https://example.com/schemas/tag" not found`)
+	})
+}
@@ -2,6 +2,7 @@ package openapi_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/sv-tools/openapi"
@@ -77,3 +78,299 @@ func TestSchema_AddExt(t *testing.T) {
 		})
 	}
 }
+
+func TestSchema_Examples(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		examples []any
+		opts     []openapi.ValidationOption
+		err      string
+	}{
+		{name: "valid", examples: []any{1, 5, 10}},
+		{name: "second violates maximum", examples: []any{1, 11, 10}, err: "/components/schemas/Count/examples/1: "},
+		{name: "not validated", examples: []any{1, 11}, opts: []openapi.ValidationOption{openapi.DoNotValidateExamples()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("Count", openapi.NewSchemaBuilder().
+					Type(openapi.IntegerType).
+					Maximum(10).
+					Examples(tt.examples...).
+					Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, append(tt.opts, openapi.AllowUnusedComponents())...)
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			if tt.err == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.ErrorContains(t, errs[0], tt.err)
+			require.ErrorContains(t, errs[0], "maximum")
+		})
+	}
+}
+
+func TestSchema_EnumMembership(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		schema *openapi.SchemaBuilder
+		opts   []openapi.ValidationOption
+		errs   []string
+	}{
+		{
+			name:   "default in enum",
+			schema: openapi.NewSchemaBuilder().Type(openapi.StringType).Enum("cat", "dog").Default("dog"),
+		},
+		{
+			name:   "default outside enum",
+			schema: openapi.NewSchemaBuilder().Type(openapi.StringType).Enum("cat", "dog").Default("bird"),
+			opts:   []openapi.ValidationOption{openapi.DoNotValidateDefaultValues()},
+			errs:   []string{"/components/schemas/Pet/default: invalid value, expected one of enum values: [cat dog]"},
+		},
+		{
+			name: "object default in enum",
+			schema: openapi.NewSchemaBuilder().
+				Type(openapi.ObjectType).
+				Enum(map[string]any{"id": 1.0, "tags": []any{"a"}}).
+				Default(map[string]any{"id": 1, "tags": []string{"a"}}),
+			opts: []openapi.ValidationOption{openapi.DoNotValidateDefaultValues()},
+		},
+		{
+			name:   "example outside enum",
+			schema: openapi.NewSchemaBuilder().Type(openapi.StringType).Enum("cat", "dog").Example("bird"),
+			errs:   []string{"/components/schemas/Pet/example: invalid value, expected one of enum values: [cat dog]"},
+		},
+		{
+			name:   "example not validated",
+			schema: openapi.NewSchemaBuilder().Type(openapi.StringType).Enum("cat", "dog").Example("bird"),
+			opts:   []openapi.ValidationOption{openapi.DoNotValidateExamples()},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("Pet", tt.schema.Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, append(tt.opts, openapi.AllowUnusedComponents())...)
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+		})
+	}
+}
+
+func TestSchema_Pattern(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		pattern string
+		err     string
+		warning string
+		valid   string
+		invalid string
+	}{
+		{name: "re2", pattern: `^[a-z]+\d*$`, valid: "abc1", invalid: "1abc"},
+		{name: "lookahead", pattern: `^(?=.*\d)[a-z\d]+$`, warning: "uses ECMA-262 features unsupported by Go regexp and is not enforced", valid: "any"},
+		{name: "negative lookbehind", pattern: `(?<!\$)\d+`, warning: "uses ECMA-262 features unsupported by Go regexp and is not enforced", valid: "any"},
+		{name: "backreference", pattern: `^(a)\1$`, warning: "uses ECMA-262 features unsupported by Go regexp and is not enforced", valid: "any"},
+		{name: "invalid", pattern: `^[a-z`, err: "error parsing regexp: missing closing ]"},
+		{name: "invalid with escaped parenthesis", pattern: `\(?=[a-z`, err: "error parsing regexp: missing closing ]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("Code", openapi.NewSchemaBuilder().Type(openapi.StringType).Pattern(tt.pattern).Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			if tt.err != "" {
+				require.Len(t, errs, 1)
+				require.ErrorContains(t, errs[0], "/components/schemas/Code/pattern: "+tt.err)
+				return
+			}
+			require.Empty(t, errs)
+			if tt.warning != "" {
+				require.Len(t, warnings, 1)
+				require.ErrorContains(t, warnings[0], "/components/schemas/Code/pattern: "+tt.warning)
+			} else {
+				require.Empty(t, warnings)
+			}
+			require.NoError(t, validator.ValidateData("#/components/schemas/Code", tt.valid))
+			if tt.invalid != "" {
+				require.Error(t, validator.ValidateData("#/components/schemas/Code", tt.invalid))
+			}
+		})
+	}
+}
+
+func TestSchema_PatternConcurrency(t *testing.T) {
+	builder := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build())
+	for i := range 50 {
+		builder.AddComponent(fmt.Sprintf("Code%d", i), openapi.NewSchemaBuilder().
+			Type(openapi.StringType).
+			Pattern(`^[A-Z]{3}-\d{4}$`).
+			Examples("ABC-1234", "XYZ-0000").
+			Build())
+	}
+	builder.AddComponent("Invalid", openapi.NewSchemaBuilder().Type(openapi.StringType).Pattern(`^[A-Z]{3}-\d{4}$`).Examples("abc").Build())
+	validator, err := openapi.NewValidator(builder.Build(), openapi.AllowUnusedComponents(), openapi.Concurrency(8))
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "/components/schemas/Invalid/examples/0")
+}
+
+func BenchmarkValidateData_Pattern(b *testing.B) {
+	// the same pattern is used by many schemas, so it is compiled once per validator
+	builder := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build())
+	const schemas = 100
+	for i := range schemas {
+		builder.AddComponent(fmt.Sprintf("Code%d", i), openapi.NewSchemaBuilder().Type(openapi.StringType).Pattern(`^[A-Z]{3}-\d{4}$`).Build())
+	}
+	spec := builder.Build()
+	const n = 10000
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("ABC-%04d", i)
+	}
+
+	b.ReportAllocs()
+	for range b.N {
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		if err != nil {
+			b.Fatal(err)
+		}
+		for i, v := range values {
+			if err := validator.ValidateData(fmt.Sprintf("#/components/schemas/Code%d", i%schemas), v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestSchema_MultipleOf(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddComponent("Ratio", openapi.NewSchemaBuilder().Type(openapi.NumberType).MultipleOf(0.1).Build()).
+		AddComponent("Price", openapi.NewSchemaBuilder().Type(openapi.NumberType).MultipleOf(0.01).Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		location string
+		value    any
+		err      string
+	}{
+		// the float modulo of these values is not 0
+		{location: "#/components/schemas/Ratio", value: 0.3},
+		{location: "#/components/schemas/Ratio", value: 0.7},
+		{location: "#/components/schemas/Ratio", value: 1.1},
+		{location: "#/components/schemas/Ratio", value: 3},
+		{location: "#/components/schemas/Price", value: 19.99},
+		{location: "#/components/schemas/Price", value: 0.07},
+		{location: "#/components/schemas/Price", value: "1.15"},
+		{location: "#/components/schemas/Ratio", value: 0.35, err: "multipleOf: got 0.35, want 0.1"},
+		{location: "#/components/schemas/Price", value: 19.999, err: "multipleOf: got 19.999, want 0.01"},
+	} {
+		t.Run(fmt.Sprintf("%s %v", tt.location, tt.value), func(t *testing.T) {
+			err := validator.ValidateDataAsJSON(tt.location, tt.value)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSchema_EnumType(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		schema string
+		errs   []string
+	}{
+		{
+			name:   "valid",
+			schema: `{"type": "integer", "enum": [1, 2.0, 3]}`,
+		},
+		{
+			name:   "integer for number",
+			schema: `{"type": "number", "enum": [1, 2.5], "const": 1}`,
+		},
+		{
+			name:   "type array",
+			schema: `{"type": ["string", "null"], "enum": ["cat", null]}`,
+		},
+		{
+			name:   "string under integer",
+			schema: `{"type": "integer", "enum": [1, "2"]}`,
+			errs:   []string{"/components/schemas/Test/enum/1: invalid value type, expected one of [integer], but got 'string'"},
+		},
+		{
+			name:   "number under integer",
+			schema: `{"type": "integer", "const": 1.5}`,
+			errs:   []string{"/components/schemas/Test/const: invalid value type, expected one of [integer], but got 'number'"},
+		},
+		{
+			name:   "not in type array",
+			schema: `{"type": ["string", "null"], "enum": ["cat", true]}`,
+			errs:   []string{"/components/schemas/Test/enum/1: invalid value type, expected one of [string, null], but got 'boolean'"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"openapi": "3.1.1", "info": {"title": "test", "version": "1.0.0"}, "components": {"schemas": {"Test": ` + tt.schema + `}}}`
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, json.Unmarshal([]byte(data), &spec))
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.DoNotValidateExamples())
+			require.NoError(t, err)
+			err = validator.ValidateSpec()
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, e := range tt.errs {
+				require.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
+
+func TestSchemaBuilder_Composition(t *testing.T) {
+	schema := openapi.NewSchemaBuilder().ArrayOf(
+		openapi.NewSchemaBuilder().ObjectWith(map[string]*openapi.RefOrSpec[openapi.Schema]{
+			"id":     openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build(),
+			"kind":   openapi.NewSchemaBuilder().EnumOf("cat", "dog", nil).Build(),
+			"weight": openapi.NewSchemaBuilder().EnumOf(1, 2.5).Build(),
+			"tags":   openapi.NewSchemaBuilder().ArrayOf(openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Tag")).Build(),
+		}, "id", "kind").Build(),
+	).Build()
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "array",
+  "items": {
+    "type": "object",
+    "properties": {
+      "id": {"type": "integer"},
+      "kind": {"type": ["string", "null"], "enum": ["cat", "dog", null]},
+      "weight": {"type": "number", "enum": [1, 2.5]},
+      "tags": {"type": "array", "items": {"$ref": "#/components/schemas/Tag"}}
+    },
+    "required": ["id", "kind"]
+  }
+}`, string(data))
+}
@@ -14,11 +14,31 @@ package openapi
 //	api_key: []
 type SecurityRequirement map[string][]string
 
-func (o *SecurityRequirement) validateSpec(_ string, validator *Validator) []*validationError { //nolint: unparam // by design
-	for k := range *o {
-		validator.visited[joinLoc("#", "components", "securitySchemes", k)] = true
+func (o *SecurityRequirement) validateSpec(location string, validator *Validator) []*validationError {
+	var errs []*validationError
+	for k, scopes := range *o {
+		validator.markVisited(joinLoc("#", "components", "securitySchemes", k))
+
+		var ref *RefOrSpec[Extendable[SecurityScheme]]
+		if c := validator.spec.Spec.Components; c != nil {
+			ref = c.Spec.SecuritySchemes[k]
+		}
+		if ref == nil {
+			errs = append(errs, newValidationError(joinLoc(location, k), "security scheme '%s' not found", k))
+			continue
+		}
+		scheme, err := ref.GetSpecCached(validator.cache)
+		// the scopes of openIdConnect are discovered by the URL, so only oauth2 can be checked
+		if err != nil || scheme.Spec.Type != TypeOAuth2 || scheme.Spec.Flows == nil {
+			continue
+		}
+		for i, scope := range scopes {
+			if !scheme.Spec.Flows.Spec.hasScope(scope) {
+				errs = append(errs, newValidationError(joinLoc(location, k, i), "scope '%s' is not declared in the flows of '%s'", scope, k))
+			}
+		}
 	}
-	return nil // nothing to validate
+	return errs
 }
 
 type SecurityRequirementBuilder struct {
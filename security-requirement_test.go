@@ -0,0 +1,49 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestSecurityRequirement_Scopes(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddComponent("petstore_auth", openapi.NewSecuritySchemeBuilder().
+			Type(openapi.TypeOAuth2).
+			Flows(openapi.NewOAuthFlowsBuilder().
+				Implicit(openapi.NewOAuthFlowBuilder().
+					AuthorizationURL("https://example.com/api/oauth/dialog").
+					AddScope("read:pets", "read your pets").
+					Build()).
+				ClientCredentials(openapi.NewOAuthFlowBuilder().
+					TokenURL("https://example.com/api/oauth/token").
+					AddScope("write:pets", "modify pets in your account").
+					Build()).
+				Build()).
+			Build()).
+		AddComponent("oidc", openapi.NewSecuritySchemeBuilder().
+			Type(openapi.TypeOpenIDConnect).
+			OpenIDConnectURL("https://example.com/.well-known/openid-configuration").
+			Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().
+				Security(
+					*openapi.NewSecurityRequirementBuilder().Add("petstore_auth", "read:pets", "write:pets").Build(),
+					*openapi.NewSecurityRequirementBuilder().Add("petstore_auth", "read:pet").Build(),
+					*openapi.NewSecurityRequirementBuilder().Add("oidc", "any").Build(),
+					*openapi.NewSecurityRequirementBuilder().Add("missing").Build(),
+				).
+				Build()).
+			Build()).
+		Build()
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 2)
+	require.ErrorContains(t, errs[0], "/paths/~1pets/get/security/1/petstore_auth/0: scope 'read:pet' is not declared in the flows of 'petstore_auth'")
+	require.ErrorContains(t, errs[1], "/paths/~1pets/get/security/3/missing: security scheme 'missing' not found")
+}
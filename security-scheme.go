@@ -1,5 +1,7 @@
 package openapi
 
+import "strings"
+
 const (
 	TypeApiKey        = "apiKey"
 	TypeHTTP          = "http"
@@ -97,11 +99,15 @@ func (o *SecurityScheme) validateSpec(location string, validator *Validator) []*
 			if o.Scheme == "" {
 				errs = append(errs, newValidationError(joinLoc(location, "scheme"), ErrRequired))
 			}
+			// the scheme name is case-insensitive, see RFC7235
+			if o.BearerFormat != "" && !strings.EqualFold(o.Scheme, "bearer") {
+				errs = append(errs, newValidationError(joinLoc(location, "bearerFormat"), "only allowed when `scheme` is 'bearer'"))
+			}
 		case TypeOAuth2:
 			if o.Flows == nil {
 				errs = append(errs, newValidationError(joinLoc(location, "flows"), ErrRequired))
 			} else {
-				errs = o.Flows.validateSpec(joinLoc(location, "flows"), validator)
+				errs = append(errs, o.Flows.validateSpec(joinLoc(location, "flows"), validator)...)
 			}
 		case TypeOpenIDConnect:
 			if o.OpenIDConnectURL == "" {
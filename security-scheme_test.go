@@ -0,0 +1,73 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestSecurityScheme_validateSpec(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		scheme string
+		errs   []string
+	}{
+		{name: "valid apiKey", scheme: `{"type": "apiKey", "name": "api_key", "in": "header"}`},
+		{
+			name:   "apiKey without name",
+			scheme: `{"type": "apiKey", "in": "header"}`,
+			errs:   []string{"/components/securitySchemes/auth/name: required"},
+		},
+		{
+			name:   "apiKey without in",
+			scheme: `{"type": "apiKey", "name": "api_key"}`,
+			errs:   []string{"/components/securitySchemes/auth/in: required"},
+		},
+		{name: "valid http", scheme: `{"type": "http", "scheme": "Bearer", "bearerFormat": "JWT"}`},
+		{
+			name:   "http without scheme",
+			scheme: `{"type": "http"}`,
+			errs:   []string{"/components/securitySchemes/auth/scheme: required"},
+		},
+		{
+			name:   "bearerFormat of basic scheme",
+			scheme: `{"type": "http", "scheme": "basic", "bearerFormat": "JWT"}`,
+			errs:   []string{"/components/securitySchemes/auth/bearerFormat: only allowed when `scheme` is 'bearer'"},
+		},
+		{
+			name:   "valid oauth2",
+			scheme: `{"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {}}}}`,
+		},
+		{
+			name:   "oauth2 without flows",
+			scheme: `{"type": "oauth2"}`,
+			errs:   []string{"/components/securitySchemes/auth/flows: required"},
+		},
+		{name: "valid openIdConnect", scheme: `{"type": "openIdConnect", "openIdConnectUrl": "https://example.com/.well-known/openid-configuration"}`},
+		{
+			name:   "openIdConnect without url",
+			scheme: `{"type": "openIdConnect"}`,
+			errs:   []string{"/components/securitySchemes/auth/openIdConnectUrl: required"},
+		},
+		{name: "mutualTLS", scheme: `{"type": "mutualTLS"}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var scheme *openapi.RefOrSpec[openapi.Extendable[openapi.SecurityScheme]]
+			require.NoError(t, json.Unmarshal([]byte(tt.scheme), &scheme))
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("auth", scheme).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			errs, _ := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package openapi
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // Server is an object representing a Server.
 //
@@ -35,24 +40,143 @@ func (o *Server) validateSpec(location string, validator *Validator) []*validati
 	if o.URL == "" {
 		errs = append(errs, newValidationError(joinLoc(location, "url"), ErrRequired))
 	}
+	used := make(map[string]bool)
+	for _, name := range urlVariables(o.URL) {
+		used[name] = true
+		if _, found := o.Variables[name]; !found {
+			errs = append(errs, newValidationError(joinLoc(location, "url"), "variable '%s' is not defined", name))
+		}
+	}
+	// the url with the undefined variables cannot be checked
+	checkable := len(errs) == 0
 	if l := len(o.Variables); l == 0 {
-		if err := checkURL(o.URL); err != nil {
+		if err := validator.checkURL(o.URL); checkable && err != nil {
 			errs = append(errs, newValidationError(joinLoc(location, "url"), err))
 		}
 	} else {
 		oldnew := make([]string, 0, l*2)
 		for k, v := range o.Variables {
 			errs = append(errs, v.validateSpec(joinLoc(location, "variables", k), validator)...)
+			if len(v.Spec.Enum) > 0 && v.Spec.Default != "" && !slices.Contains(v.Spec.Enum, v.Spec.Default) {
+				errs = append(errs, newValidationError(joinLoc(location, "variables", k, "default"), "'%s' of variable '%s' is not one of %v", v.Spec.Default, k, v.Spec.Enum))
+			}
+			if !used[k] {
+				errs = append(errs, newValidationWarning(joinLoc(location, "variables", k), "variable '%s' is not used in the url", k))
+			}
 			oldnew = append(oldnew, "{"+k+"}", v.Spec.Default)
 		}
 		u := strings.NewReplacer(oldnew...).Replace(o.URL)
-		if err := checkURL(u); err != nil {
+		if err := validator.checkURL(u); checkable && err != nil {
 			errs = append(errs, newValidationError(joinLoc(location, "url"), err))
 		}
 	}
 	return errs
 }
 
+// validateServers validates the list of the servers and reports the duplicated URLs.
+func validateServers(location string, servers []*Extendable[Server], validator *Validator) []*validationError {
+	var errs []*validationError
+	declared := make(map[string]int, len(servers))
+	for i, server := range servers {
+		errs = append(errs, server.validateSpec(joinLoc(location, i), validator)...)
+		if server.Spec.URL == "" {
+			continue
+		}
+		if j, found := declared[server.Spec.URL]; found {
+			errs = append(errs, newValidationError(joinLoc(location, i, "url"), "'%s' is not unique, also used at %s", server.Spec.URL, joinLoc(location, j, "url")))
+			continue
+		}
+		declared[server.Spec.URL] = i
+	}
+	return errs
+}
+
+// EffectiveServers returns the servers to be used for the given operation of the path item.
+// The servers of the operation take precedence over the servers of the path item, which override the root servers.
+// The operation and the path item can be nil to get the servers of a higher level.
+// If no servers are declared at all, the default server with `/` url is returned as the specification defines.
+func EffectiveServers(doc *OpenAPI, pathItem *PathItem, op *Operation) []*Server {
+	var servers []*Extendable[Server]
+	switch {
+	case op != nil && len(op.Servers) > 0:
+		servers = op.Servers
+	case pathItem != nil && len(pathItem.Servers) > 0:
+		servers = pathItem.Servers
+	case doc != nil:
+		servers = doc.Servers
+	}
+	if len(servers) == 0 {
+		return []*Server{{URL: "/"}}
+	}
+	result := make([]*Server, 0, len(servers))
+	for _, server := range servers {
+		result = append(result, server.Spec)
+	}
+	return result
+}
+
+// urlVariables returns the names of the variables used in the given URL template in order of appearance.
+func urlVariables(u string) []string {
+	var names []string
+	for {
+		start := strings.IndexByte(u, '{')
+		if start < 0 {
+			return names
+		}
+		end := strings.IndexByte(u[start:], '}')
+		if end < 0 {
+			return names
+		}
+		if name := u[start+1 : start+end]; !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+		u = u[start+end+1:]
+	}
+}
+
+// ErrServerVariableNotDefined is returned by BuildURL if a variable is not defined by the server.
+var ErrServerVariableNotDefined = errors.New("server variable is not defined")
+
+// BuildURL returns the URL of the server with the variables substituted.
+//
+// The values of the variables are taken from the given overrides or the default values are used.
+// An error is returned if an override is given for an unknown variable, if a value is not in the enum of the variable
+// or if the URL contains a variable, which is not defined.
+//
+// Example:
+//
+//	u, err := server.BuildURL(map[string]string{"environment": "staging"})
+func (o *Server) BuildURL(overrides map[string]string) (string, error) {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if _, found := o.Variables[name]; !found {
+			return "", fmt.Errorf("%w: %q", ErrServerVariableNotDefined, name)
+		}
+	}
+
+	variables := urlVariables(o.URL)
+	oldnew := make([]string, 0, len(variables)*2)
+	for _, name := range variables {
+		v, found := o.Variables[name]
+		if !found || v == nil || v.Spec == nil {
+			return "", fmt.Errorf("%w: %q", ErrServerVariableNotDefined, name)
+		}
+		value, found := overrides[name]
+		if !found {
+			value = v.Spec.Default
+		}
+		if len(v.Spec.Enum) > 0 && !slices.Contains(v.Spec.Enum, value) {
+			return "", fmt.Errorf("%w: value %q of server variable %q is not one of %v", ErrInvalidValue, value, name, v.Spec.Enum)
+		}
+		oldnew = append(oldnew, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(o.URL), nil
+}
+
 type ServerBuilder struct {
 	spec *Extendable[Server]
 }
@@ -0,0 +1,169 @@
+package openapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestServer_BuildURL(t *testing.T) {
+	server := openapi.NewServerBuilder().
+		URL("https://{environment}.example.com:{port}/v1").
+		AddVariable("environment", openapi.NewServerVariableBuilder().
+			Default("api").
+			Enum("api", "staging", "dev").
+			Build()).
+		AddVariable("port", openapi.NewServerVariableBuilder().
+			Default("443").
+			Build()).
+		Build()
+
+	for _, tt := range []struct {
+		name      string
+		overrides map[string]string
+		url       string
+		err       string
+		sentinel  error
+	}{
+		{name: "defaults", url: "https://api.example.com:443/v1"},
+		{name: "enum", overrides: map[string]string{"environment": "staging"}, url: "https://staging.example.com:443/v1"},
+		{name: "free form", overrides: map[string]string{"port": "8443"}, url: "https://api.example.com:8443/v1"},
+		{
+			name:      "all",
+			overrides: map[string]string{"environment": "dev", "port": "8080"},
+			url:       "https://dev.example.com:8080/v1",
+		},
+		{
+			name:      "not in enum",
+			overrides: map[string]string{"environment": "prod"},
+			err:       `value "prod" of server variable "environment" is not one of [api staging dev]`,
+			sentinel:  openapi.ErrInvalidValue,
+		},
+		{
+			name:      "unknown variable",
+			overrides: map[string]string{"region": "eu"},
+			err:       `server variable is not defined: "region"`,
+			sentinel:  openapi.ErrServerVariableNotDefined,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := server.Spec.BuildURL(tt.overrides)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				require.Equal(t, true, errors.Is(err, tt.sentinel))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.url, u)
+		})
+	}
+
+	t.Run("undefined variable in url", func(t *testing.T) {
+		s := openapi.NewServerBuilder().URL("https://{region}.example.com").Build()
+		_, err := s.Spec.BuildURL(nil)
+		require.ErrorContains(t, err, `server variable is not defined: "region"`)
+		require.Equal(t, true, errors.Is(err, openapi.ErrServerVariableNotDefined))
+	})
+}
+
+func TestServer_Variables(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		server   *openapi.Extendable[openapi.Server]
+		errs     []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			server: openapi.NewServerBuilder().
+				URL("https://{environment}.example.com/v1").
+				AddVariable("environment", openapi.NewServerVariableBuilder().Default("api").Enum("api", "dev").Build()).
+				Build(),
+		},
+		{
+			name:   "not defined",
+			server: openapi.NewServerBuilder().URL("https://{environment}.example.com/v1").Build(),
+			errs:   []string{"/servers/0/url: variable 'environment' is not defined"},
+		},
+		{
+			name: "not used",
+			server: openapi.NewServerBuilder().
+				URL("https://api.example.com/v1").
+				AddVariable("port", openapi.NewServerVariableBuilder().Default("443").Build()).
+				Build(),
+			warnings: []string{"/servers/0/variables/port: variable 'port' is not used in the url"},
+		},
+		{
+			name: "default not in enum",
+			server: openapi.NewServerBuilder().
+				URL("https://{environment}.example.com/v1").
+				AddVariable("environment", openapi.NewServerVariableBuilder().Default("prod").Enum("api", "dev").Build()).
+				Build(),
+			errs: []string{"/servers/0/variables/environment/default: 'prod' of variable 'environment' is not one of [api dev]"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+				Servers(tt.server).
+				Build()
+			validator, err := openapi.NewValidator(spec)
+			require.NoError(t, err)
+			errs, warnings := validator.Validate()
+			require.Len(t, errs, len(tt.errs))
+			for i, e := range tt.errs {
+				require.ErrorContains(t, errs[i], e)
+			}
+			require.Len(t, warnings, len(tt.warnings))
+			for i, w := range tt.warnings {
+				require.ErrorContains(t, warnings[i], w)
+			}
+		})
+	}
+}
+
+func TestEffectiveServers(t *testing.T) {
+	newServer := func(url string) *openapi.Extendable[openapi.Server] {
+		return openapi.NewServerBuilder().URL(url).Build()
+	}
+	doc := openapi.NewOpenAPIBuilder().Servers(newServer("https://api.example.com"), newServer("https://eu.api.example.com")).Build().Spec
+	pathItem := openapi.NewPathItemBuilder().Servers(newServer("https://pets.example.com")).Build().Spec.Spec
+	op := openapi.NewOperationBuilder().Servers(newServer("https://upload.example.com")).Build().Spec
+
+	urls := func(servers []*openapi.Server) []string {
+		var result []string
+		for _, s := range servers {
+			result = append(result, s.URL)
+		}
+		return result
+	}
+	require.Equal(t, []string{"https://upload.example.com"}, urls(openapi.EffectiveServers(doc, pathItem, op)))
+	require.Equal(t, []string{"https://pets.example.com"}, urls(openapi.EffectiveServers(doc, pathItem, openapi.NewOperationBuilder().Build().Spec)))
+	require.Equal(t, []string{"https://pets.example.com"}, urls(openapi.EffectiveServers(doc, pathItem, nil)))
+	require.Equal(t, []string{"https://api.example.com", "https://eu.api.example.com"}, urls(openapi.EffectiveServers(doc, nil, nil)))
+	require.Equal(t, []string{"/"}, urls(openapi.EffectiveServers(&openapi.OpenAPI{}, &openapi.PathItem{}, &openapi.Operation{})))
+	require.Equal(t, []string{"/"}, urls(openapi.EffectiveServers(nil, nil, nil)))
+}
+
+func TestServer_UniqueURLs(t *testing.T) {
+	op := openapi.NewOperationBuilder().
+		Servers(openapi.NewServerBuilder().URL("https://upload.example.com").Build(), openapi.NewServerBuilder().URL("https://upload.example.com").Build()).
+		Build()
+	op.Spec.Responses = openapi.NewResponsesBuilder().AddResponse("200", openapi.NewResponseBuilder().Description("ok").Build()).Build().Spec
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		Servers(openapi.NewServerBuilder().URL("https://api.example.com").Build(), openapi.NewServerBuilder().URL("https://api.example.com").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().Post(op).Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 2)
+	require.ErrorContains(t, errs[0], "/paths/~1pets/post/servers/1/url: 'https://upload.example.com' is not unique, also used at /paths/~1pets/post/servers/0/url")
+	require.ErrorContains(t, errs[1], "/servers/1/url: 'https://api.example.com' is not unique, also used at /servers/0/url")
+}
@@ -23,6 +23,9 @@ func (o *ServerVariable) validateSpec(location string, _ *Validator) []*validati
 	if o.Default == "" {
 		errs = append(errs, newValidationError(joinLoc(location, "default"), ErrRequired))
 	}
+	if o.Enum != nil && len(o.Enum) == 0 {
+		errs = append(errs, newValidationError(joinLoc(location, "enum"), "must not be empty"))
+	}
 	return errs
 }
 
@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"encoding/json"
+	"slices"
 )
 
 // SingleOrArray holds list or single value
@@ -40,3 +41,35 @@ func (o *SingleOrArray[T]) Add(v ...T) *SingleOrArray[T] {
 	*o = append(*o, v...)
 	return o
 }
+
+// One returns the value if the object holds exactly one value.
+func (o *SingleOrArray[T]) One() (T, bool) {
+	if o == nil || len(*o) != 1 {
+		var zero T
+		return zero, false
+	}
+	return (*o)[0], true
+}
+
+// Len returns the number of the values, a nil object has no values.
+func (o *SingleOrArray[T]) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(*o)
+}
+
+// Each calls the given function for each value in order.
+func (o *SingleOrArray[T]) Each(fn func(T)) {
+	if o == nil {
+		return
+	}
+	for _, v := range *o {
+		fn(v)
+	}
+}
+
+// SingleOrArrayContains checks if the given object holds the value.
+func SingleOrArrayContains[T comparable](o *SingleOrArray[T], v T) bool {
+	return o != nil && slices.Contains(*o, v)
+}
@@ -147,3 +147,61 @@ func TestSingleOrArrayJSON(t *testing.T) {
 		})
 	})
 }
+
+func TestSingleOrArrayAccessors(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		v        *openapi.SingleOrArray[string]
+		length   int
+		one      string
+		isOne    bool
+		contains bool
+		data     string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "zero",
+			v:    &openapi.SingleOrArray[string]{},
+			data: `[]`,
+		},
+		{
+			name:     "one",
+			v:        openapi.NewSingleOrArray("foo"),
+			length:   1,
+			one:      "foo",
+			isOne:    true,
+			contains: true,
+			data:     `"foo"`,
+		},
+		{
+			name:     "many",
+			v:        openapi.NewSingleOrArray("bar", "foo"),
+			length:   2,
+			contains: true,
+			data:     `["bar", "foo"]`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.length, tt.v.Len())
+			one, ok := tt.v.One()
+			require.Equal(t, tt.isOne, ok)
+			require.Equal(t, tt.one, one)
+			require.Equal(t, tt.contains, openapi.SingleOrArrayContains(tt.v, "foo"))
+			require.Equal(t, false, openapi.SingleOrArrayContains(tt.v, "baz"))
+
+			var values []string
+			tt.v.Each(func(v string) {
+				values = append(values, v)
+			})
+			require.Len(t, values, tt.length)
+
+			if tt.v != nil {
+				data, err := json.Marshal(tt.v)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.data, string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownField is returned by DecodeStrict function for the fields, which are not defined by the specification.
+var ErrUnknownField = errors.New("unknown field")
+
+// DecodeStrict decodes the given JSON data into v like json.Unmarshal does, but fails if the data contains the fields,
+// which are not defined by the specification, e.g. `descriptoin` instead of `description`.
+// The extensions with `x-` prefix are allowed everywhere.
+//
+// All the unknown fields are reported by their locations, e.g. `/info/descriptoin: unknown field`,
+// the joined errors can be checked by errors.Is with ErrUnknownField.
+//
+// Example:
+//
+//	var doc *openapi.Extendable[openapi.OpenAPI]
+//	err := openapi.DecodeStrict(data, &doc)
+func DecodeStrict(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range unknownFields(reflect.TypeOf(v), data, "") {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// strictDecodable is implemented by the types with custom JSON decoding to report their unknown fields.
+type strictDecodable interface {
+	unknownFields(data []byte, location string) []*validationError
+}
+
+// unknownFields returns the unknown fields of the given JSON data decoded into a value of the given type.
+func unknownFields(t reflect.Type, data []byte, location string) []*validationError {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if v, ok := reflect.New(t).Interface().(strictDecodable); ok {
+		return v.unknownFields(data, location)
+	}
+
+	var errs []*validationError
+	switch t.Kind() {
+	case reflect.Struct:
+		var raw map[string]json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return nil
+		}
+		fields := jsonFieldTypes(t)
+		for _, k := range sortedKeys(raw) {
+			if ft, ok := fields[k]; ok {
+				errs = append(errs, unknownFields(ft, raw[k], joinLoc(location, k))...)
+			} else if !strings.HasPrefix(k, ExtensionPrefix) {
+				errs = append(errs, newValidationError(joinLoc(location, k), ErrUnknownField))
+			}
+		}
+	case reflect.Map:
+		var raw map[string]json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return nil
+		}
+		for _, k := range sortedKeys(raw) {
+			errs = append(errs, unknownFields(t.Elem(), raw[k], joinLoc(location, k))...)
+		}
+	case reflect.Slice, reflect.Array:
+		var raw []json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return nil
+		}
+		for i, v := range raw {
+			errs = append(errs, unknownFields(t.Elem(), v, joinLoc(location, i))...)
+		}
+	}
+	return errs
+}
+
+// unknownMapFields reports the unknown fields of the values of the object, except the extensions.
+func unknownMapFields[T any](data []byte, location string) []*validationError {
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(data, &raw) != nil {
+		return nil
+	}
+	var errs []*validationError
+	for _, k := range sortedKeys(raw) {
+		if !strings.HasPrefix(k, ExtensionPrefix) {
+			errs = append(errs, unknownFields(reflect.TypeFor[T](), raw[k], joinLoc(location, k))...)
+		}
+	}
+	return errs
+}
+
+// jsonFieldTypes returns the types of the public fields by their json names, see getFields function.
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	ret := make(map[string]reflect.Type)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n, v := range jsonFieldTypes(ft) {
+					ret[n] = v
+				}
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		ret[name] = f.Type
+	}
+	return ret
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (o *Extendable[T]) unknownFields(data []byte, location string) []*validationError {
+	return unknownFields(reflect.TypeFor[T](), data, location)
+}
+
+func (o *RefOrSpec[T]) unknownFields(data []byte, location string) []*validationError {
+	var ref Ref
+	if json.Unmarshal(data, &ref) == nil && ref.Ref != "" {
+		if _, ok := any(o).(*RefOrSpec[Extendable[PathItem]]); !ok || !hasRefSiblings(data) {
+			return unknownFields(reflect.TypeFor[Ref](), data, location)
+		}
+		// the path item allows the fields next to `$ref`
+		var raw map[string]json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return nil
+		}
+		delete(raw, "$ref")
+		data, _ = json.Marshal(raw)
+	}
+	return unknownFields(reflect.TypeFor[T](), data, location)
+}
+
+func (o *SingleOrArray[T]) unknownFields(data []byte, location string) []*validationError {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return unknownFields(reflect.TypeFor[[]T](), data, location)
+	}
+	return unknownFields(reflect.TypeFor[T](), data, location)
+}
+
+func (o *BoolOrSchema) unknownFields(data []byte, location string) []*validationError {
+	var b bool
+	if json.Unmarshal(data, &b) == nil {
+		return nil
+	}
+	return unknownFields(reflect.TypeFor[RefOrSpec[Schema]](), data, location)
+}
+
+func (o *Paths) unknownFields(data []byte, location string) []*validationError {
+	return unknownMapFields[RefOrSpec[Extendable[PathItem]]](data, location)
+}
+
+func (o *Callback) unknownFields(data []byte, location string) []*validationError {
+	return unknownMapFields[RefOrSpec[Extendable[PathItem]]](data, location)
+}
+
+func (o *Responses) unknownFields(data []byte, location string) []*validationError {
+	return unknownMapFields[RefOrSpec[Extendable[Response]]](data, location)
+}
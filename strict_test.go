@@ -0,0 +1,91 @@
+package openapi_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+		errs []string
+	}{
+		{
+			name: "valid with extensions",
+			data: `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0", "x-audience": "public"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [{"$ref": "#/components/parameters/Limit"}],
+        "responses": {"200": {"description": "ok"}, "x-rate-limited": true}
+      }
+    },
+    "x-internal": true
+  },
+  "components": {
+    "parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": ["integer", "null"], "additionalProperties": false}}}
+  }
+}`,
+		},
+		{
+			name: "typo",
+			data: `{"openapi": "3.1.1", "info": {"title": "test", "version": "1.0.0", "descriptoin": "pets"}}`,
+			errs: []string{"/info/descriptoin: unknown field"},
+		},
+		{
+			name: "nested typos",
+			data: `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [{"$ref": "#/components/parameters/Limit", "summry": "limit"}],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"type": "object", "propeties": {}}}}}}
+      }
+    }
+  }
+}`,
+			errs: []string{
+				"/paths/~1pets/get/parameters/0/summry: unknown field",
+				"/paths/~1pets/get/responses/200/content/application~1json/schema/propeties: unknown field",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc *openapi.Extendable[openapi.OpenAPI]
+			err := openapi.DecodeStrict([]byte(tt.data), &doc)
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, doc)
+				return
+			}
+			require.Equal(t, true, errors.Is(err, openapi.ErrUnknownField))
+			for _, e := range tt.errs {
+				require.ErrorContains(t, err, e)
+			}
+		})
+	}
+
+	t.Run("testdata", func(t *testing.T) {
+		files, err := os.ReadDir("testdata")
+		require.NoError(t, err)
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			data, err := os.ReadFile(path.Join("testdata", f.Name()))
+			require.NoError(t, err)
+			var doc *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, openapi.DecodeStrict(data, &doc))
+		}
+	})
+}
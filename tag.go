@@ -28,7 +28,7 @@ func (o *Tag) validateSpec(location string, validator *Validator) []*validationE
 	if o.ExternalDocs != nil {
 		errs = append(errs, o.ExternalDocs.validateSpec(joinLoc(location, "externalDocs"), validator)...)
 	}
-	validator.visited[joinLoc("tags", o.Name)] = true
+	validator.markVisited(joinLoc("tags", o.Name))
 	return errs
 }
 
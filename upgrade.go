@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"slices"
+	"strings"
+)
+
+// Version311 is the version of the documents created by Upgrade function.
+const Version311 = "3.1.1"
+
+// Upgrade converts the given OpenAPI 3.0 document to OpenAPI 3.1.1.
+//
+// The schemas are converted in the following way:
+//   - `nullable: true` is merged into the `type` union, e.g. `type: [string, "null"]`;
+//   - the boolean `exclusiveMinimum` and `exclusiveMaximum` are merged with `minimum` and `maximum`
+//     into the numeric `exclusiveMinimum` and `exclusiveMaximum`;
+//   - the deprecated `example` is moved to `examples`.
+//
+// The 3.0 keywords are parsed into the extensions of the Schema object, so the document must be decoded
+// using the json.Unmarshal function or the UnmarshalJSON methods.
+// The version is set to 3.1.1.
+//
+// The given document is not modified.
+func Upgrade(doc *OpenAPI) (*OpenAPI, error) {
+	if !strings.HasPrefix(doc.OpenAPI, "3.0.") {
+		return nil, NewUnsupportedVersionError(doc.OpenAPI)
+	}
+	upgraded := doc.Clone()
+	upgraded.OpenAPI = Version311
+	if err := Walk(upgraded, func(_ string, node any) error {
+		if s, ok := node.(*Schema); ok {
+			upgradeSchema(s)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return upgraded, nil
+}
+
+func upgradeSchema(s *Schema) {
+	if nullable, ok := s.Extensions["nullable"].(bool); ok {
+		delete(s.Extensions, "nullable")
+		// `nullable` has no effect without `type` in OpenAPI 3.0
+		if nullable && s.Type != nil && !SingleOrArrayContains(s.Type, NullType) {
+			s.Type = NewSingleOrArray(append(*s.Type, NullType)...)
+			if len(s.Enum) > 0 && !slices.Contains(s.Enum, nil) {
+				s.Enum = append(s.Enum, nil)
+			}
+		}
+	}
+
+	if exclusive, ok := s.Extensions["exclusiveMinimum"].(bool); ok {
+		delete(s.Extensions, "exclusiveMinimum")
+		if exclusive && s.Minimum != nil {
+			s.ExclusiveMinimum = s.Minimum
+			s.Minimum = nil
+		}
+	}
+	if exclusive, ok := s.Extensions["exclusiveMaximum"].(bool); ok {
+		delete(s.Extensions, "exclusiveMaximum")
+		if exclusive && s.Maximum != nil {
+			s.ExclusiveMaximum = s.Maximum
+			s.Maximum = nil
+		}
+	}
+
+	if s.Example != nil && len(s.Examples) == 0 {
+		s.Examples = []any{s.Example}
+		s.Example = nil
+	}
+	if len(s.Extensions) == 0 {
+		s.Extensions = nil
+	}
+}
@@ -0,0 +1,61 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestUpgrade(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "v3.0", "petstore.json"))
+	require.NoError(t, err)
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	// the 3.0 keywords are parsed into the extensions
+	schema := doc.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Parameters[0].Spec.Spec.Schema.Spec
+	require.Equal(t, true, schema.Extensions["exclusiveMinimum"])
+	require.Equal(t, 0, *schema.Minimum)
+
+	upgraded, err := openapi.Upgrade(doc.Spec)
+	require.NoError(t, err)
+	require.Equal(t, openapi.Version311, upgraded.OpenAPI)
+
+	pet := upgraded.Components.Spec.Schemas["Pet"].Spec
+	require.Equal(t, openapi.NewSingleOrArray(openapi.StringType, openapi.NullType), pet.Properties["tag"].Spec.Type)
+	require.Empty(t, pet.Properties["tag"].Spec.Extensions)
+	require.Nil(t, pet.Example)
+	require.Equal(t, []any{map[string]any{"id": float64(1), "name": "Tom"}}, pet.Examples)
+	limit := upgraded.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Parameters[0].Spec.Spec.Schema.Spec
+	require.Nil(t, limit.Minimum)
+	require.Equal(t, 0, *limit.ExclusiveMinimum)
+
+	validator, err := openapi.NewValidator(openapi.NewExtendable(upgraded), openapi.AllowUndefinedTagsInOperation())
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	// the original document is not modified
+	require.Equal(t, "3.0.0", doc.Spec.OpenAPI)
+
+	t.Run("round trip", func(t *testing.T) {
+		downgraded, warnings, err := openapi.Downgrade(upgraded)
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+		againUpgraded, err := openapi.Upgrade(downgraded)
+		require.NoError(t, err)
+		expected, err := json.Marshal(upgraded)
+		require.NoError(t, err)
+		actual, err := json.Marshal(againUpgraded)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(actual))
+	})
+}
+
+func TestUpgrade_UnsupportedVersion(t *testing.T) {
+	_, err := openapi.Upgrade(&openapi.OpenAPI{OpenAPI: "3.1.1"})
+	require.ErrorContains(t, err, "unsupported version: 3.1.1")
+}
@@ -2,14 +2,18 @@ package openapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/mail"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 )
@@ -22,17 +26,11 @@ type validatable interface {
 
 type visitedObjects map[string]bool
 
-func (o visitedObjects) String() string {
-	keys := make([]string, 0, len(o))
-	for k := range o {
-		keys = append(keys, k)
-	}
-	return strings.Join(keys, ", ")
-}
-
 type validationError struct {
 	location string
 	err      error
+	// warning is true for the non-fatal issues, e.g. usage of the deprecated fields
+	warning bool
 }
 
 func newValidationError(location string, err any, args ...any) *validationError {
@@ -47,6 +45,13 @@ func newValidationError(location string, err any, args ...any) *validationError
 	}
 }
 
+// newValidationWarning creates a validation error for a non-fatal issue.
+func newValidationWarning(location string, err any, args ...any) *validationError {
+	e := newValidationError(location, err, args...)
+	e.warning = true
+	return e
+}
+
 var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
 
 func joinLoc(base string, parts ...any) string {
@@ -62,6 +67,36 @@ func joinLoc(base string, parts ...any) string {
 	return strings.Join(elems, "/")
 }
 
+// compareLocations compares two locations segment by segment,
+// the numeric segments (indexes of arrays) are compared as numbers.
+func compareLocations(a, b string) int {
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		ai, aErr := strconv.Atoi(as[i])
+		bi, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return ai - bi
+		}
+		return strings.Compare(as[i], bs[i])
+	}
+	return len(as) - len(bs)
+}
+
+// sortValidationErrors sorts the errors by location and then by message to get a stable order,
+// because the validation walks the maps in random order.
+func sortValidationErrors(errs []*validationError) {
+	slices.SortStableFunc(errs, func(a, b *validationError) int {
+		if c := compareLocations(a.location, b.location); c != 0 {
+			return c
+		}
+		return strings.Compare(a.err.Error(), b.err.Error())
+	})
+}
+
 func (e *validationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.location, e.err)
 }
@@ -74,28 +109,62 @@ var (
 	ErrRequired          = errors.New("required")
 	ErrMutuallyExclusive = errors.New("mutually exclusive")
 	ErrUnused            = errors.New("unused")
+	ErrDeprecated        = errors.New("deprecated")
+	ErrInvalidEmail      = errors.New("invalid email")
 )
 
-func checkURL(value string) error {
+// checkURL checks the value of an URL field according to the validation options.
+func (v *Validator) checkURL(value string) error {
 	if value == "" {
 		return nil
 	}
-	if _, err := url.Parse(value); err != nil {
+	if v.opts.urlChecker != nil {
+		return v.opts.urlChecker(value)
+	}
+	u, err := url.Parse(value)
+	if err != nil {
 		return fmt.Errorf("invalid URL: %w", err)
 	}
+	if !v.opts.requireHTTPS {
+		return nil
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("'%s' is not an absolute URL", value)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("'%s' must use https scheme", value)
+	}
 	return nil
 }
 
+// checkEmail checks that the value is a bare email address (addr-spec of RFC 5322), e.g. `support@example.com`,
+// the forms with a display name or angle brackets, like `Support <support@example.com>`, are rejected.
 func checkEmail(value string) error {
 	if value == "" {
 		return nil
 	}
-	if _, err := mail.ParseAddress(value); err != nil {
-		return fmt.Errorf("invalid email: %w", err)
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEmail, err)
+	}
+	if addr.Name != "" || addr.Address != value {
+		return fmt.Errorf("%w: '%s' must be a bare address", ErrInvalidEmail, value)
 	}
 	return nil
 }
 
+// emailFormat validates the string values of the `email` format by checkEmail function.
+func emailFormat(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	if s == "" {
+		return fmt.Errorf("%w: empty value", ErrInvalidEmail)
+	}
+	return checkEmail(s)
+}
+
 // Validator is a struct for validating the OpenAPI specification and a data.
 type Validator struct {
 	spec *Extendable[OpenAPI]
@@ -103,10 +172,39 @@ type Validator struct {
 	compiler *jsonschema.Compiler
 	schemas  sync.Map
 	mu       sync.Mutex
+	// patterns holds the compiled regular expressions by the patterns, see compilePattern method
+	patterns sync.Map
 
-	opts              *validationOptions
-	visited           visitedObjects
-	linkToOperationID map[string]string
+	opts *validationOptions
+	// stateMu guards the visited and operationIDs maps, because the components can be validated concurrently
+	stateMu sync.Mutex
+	visited visitedObjects
+	// operationIDs holds the locations of the operations by id to find the duplicates
+	operationIDs map[string][]string
+	// operations is the index of the operations by operationId to resolve the links, built by ValidateSpecResult
+	operations map[string][]operationEntry
+	// failed is set in the fail fast mode when the first error is found
+	// or when the context of ValidateContext is done to stop the validation
+	failed atomic.Bool
+	// runMu serializes the validations of the specification, because they share the state of the validator
+	runMu sync.Mutex
+	// run is the state of the current validation of the specification, nil out of ValidateSpecResult
+	run *validationRun
+	// cache is used to resolve the references
+	cache *ResolverCache
+	// schemaResolver resolves the references of the schemas inside the schema resources with `$id`
+	schemaResolver *SchemaResolver
+	// detached is set for the validation of a standalone object without the document,
+	// so the references and the operations cannot be resolved
+	detached bool
+}
+
+// validationRun is the state of a single validation of the specification.
+type validationRun struct {
+	// done is the Done channel of the context of ValidateContext, nil for the other methods
+	done <-chan struct{}
+	// nodes is the number of the validated nodes to check the context periodically
+	nodes atomic.Int64
 }
 
 const specPrefix = "http://spec"
@@ -123,6 +221,10 @@ func NewValidator(spec *Extendable[OpenAPI], opts ...ValidationOption) (*Validat
 		spec:    spec,
 		schemas: sync.Map{},
 		opts:    options,
+		cache:   options.resolverCache,
+	}
+	if validator.cache == nil && spec != nil && spec.Spec != nil {
+		validator.cache = NewResolverCache(spec.Spec.Components)
 	}
 	data, err := json.Marshal(spec)
 	if err != nil {
@@ -133,10 +235,16 @@ func NewValidator(spec *Extendable[OpenAPI], opts ...ValidationOption) (*Validat
 		return nil, fmt.Errorf("unmarshaling spec failed: %w", err)
 	}
 	compiler := jsonschema.NewCompiler()
-	compiler.DefaultDraft(jsonschema.Draft2020)
+	draft := jsonschema.Draft2020
+	if spec != nil && spec.Spec != nil {
+		draft = dialectDraft(spec.Spec.SchemaDialect())
+	}
+	compiler.DefaultDraft(draft)
+	compiler.UseRegexpEngine(validator.compilePattern)
 	if err := compiler.AddResource(specPrefix, doc); err != nil {
 		return nil, fmt.Errorf("adding spec to compiler failed: %w", err)
 	}
+	registerFormats(compiler, validator.opts)
 	for _, f := range validator.opts.updateCompiler {
 		f(compiler)
 	}
@@ -144,21 +252,229 @@ func NewValidator(spec *Extendable[OpenAPI], opts ...ValidationOption) (*Validat
 	return validator, nil
 }
 
+// dialectDraft returns the JSON Schema draft for the given dialect.
+//
+// The Draft 2020-12 is used for the OpenAPI base dialect and for the custom dialects, which are unknown to the jsonschema package.
+func dialectDraft(dialect string) *jsonschema.Draft {
+	dialect = strings.TrimSuffix(dialect, "#")
+	for _, d := range []*jsonschema.Draft{jsonschema.Draft4, jsonschema.Draft6, jsonschema.Draft7, jsonschema.Draft2019} {
+		if d.String() == dialect {
+			return d
+		}
+	}
+	return jsonschema.Draft2020
+}
+
+// builtInFormats is the list of the formats supported by the jsonschema package, except the `regex` one.
+var builtInFormats = []string{
+	DateFormat,
+	DateTimeFormat,
+	TimeFormat,
+	DurationFormat,
+	"period",
+	UUIDFormat,
+	EmailFormat,
+	HostnameFormat,
+	IPv4Format,
+	IPv6Format,
+	URIFormat,
+	URIReferenceFormat,
+	IRIFormat,
+	IRIReferenceFormat,
+	URITemplateFormat,
+	JsonPointerFormat,
+	RelativeJsonPointerFormat,
+	"semver",
+}
+
+func registerFormats(compiler *jsonschema.Compiler, opts *validationOptions) {
+	if !opts.validateFormats && len(opts.formats) == 0 {
+		return
+	}
+	compiler.AssertFormat()
+	if !opts.validateFormats {
+		// the assertion enables all the built-in validators, so disable them to enforce only the registered formats
+		for _, name := range builtInFormats {
+			compiler.RegisterFormat(&jsonschema.Format{Name: name, Validate: func(any) error { return nil }})
+		}
+	} else {
+		// the same check as for the email of the contact
+		compiler.RegisterFormat(&jsonschema.Format{Name: EmailFormat, Validate: emailFormat})
+	}
+	for name, fn := range opts.formats {
+		compiler.RegisterFormat(&jsonschema.Format{Name: name, Validate: fn})
+	}
+}
+
+// contextCheckInterval is the number of the validated nodes between the checks of the context.
+const contextCheckInterval = 64
+
+// ValidateContext validates the specification like ValidateSpec, but stops the validation as soon as
+// the given context is done and returns the error of the context, e.g. context.Canceled.
+// The context is checked periodically during the traversal of the document, so the check is cheap.
+// The concurrent validations of the specification by the same validator are serialized.
+//
+// The result is the list of the errors sorted by location, including the warnings if the
+// TreatWarningsAsErrors option is used.
+func (v *Validator) ValidateContext(ctx context.Context) ([]error, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result := v.validateSpecResult(&validationRun{done: ctx.Done()})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return v.fatalIssues(result), nil
+}
+
 // ValidateSpec validates the specification.
+//
+// The errors are sorted by location and then by message, so the result is stable between runs.
+// The warnings are not returned unless the TreatWarningsAsErrors option is used, see Validate method.
 func (v *Validator) ValidateSpec() error {
+	return errors.Join(v.fatalIssues(v.ValidateSpecResult())...)
+}
+
+// fatalIssues returns the errors of the result and the warnings if the TreatWarningsAsErrors option is used.
+func (v *Validator) fatalIssues(result *ValidationResult) []error {
+	if v.opts.treatWarningsAsErrors {
+		issues := make([]error, len(result.issues))
+		for i := range result.issues {
+			issues[i] = result.issues[i]
+		}
+		return issues
+	}
+	return result.Errors()
+}
+
+// Validate validates the specification and returns the errors and the warnings separately.
+//
+// The warnings are the non-fatal issues, like the usage of the deprecated fields or not recommended features.
+// Both lists are sorted by location and then by message.
+func (v *Validator) Validate() (errs []error, warnings []error) {
+	result := v.ValidateSpecResult()
+	return result.Errors(), result.Warnings()
+}
+
+// ValidateSpecResult validates the specification and returns the structured result,
+// which allows to group the issues by location or to generate a machine-readable report.
+func (v *Validator) ValidateSpecResult() *ValidationResult {
+	return v.validateSpecResult(&validationRun{})
+}
+
+// validateSpecResult validates the specification with the given state of the run,
+// the concurrent calls are serialized.
+func (v *Validator) validateSpecResult(run *validationRun) *ValidationResult {
+	v.runMu.Lock()
+	defer v.runMu.Unlock()
+	v.run = run
+	defer func() {
+		v.run = nil
+	}()
+
 	// clear visited objects
 	v.visited = make(visitedObjects)
-	v.linkToOperationID = make(map[string]string)
+	v.operationIDs = make(map[string][]string)
+	v.failed.Store(false)
+	if v.opts.resolverCache == nil {
+		// the components could be changed since the previous validation
+		v.cache = NewResolverCache(v.spec.Spec.Components)
+	}
+	v.schemaResolver = NewSchemaResolver(v.spec.Spec)
+	// rebuild the index of the operations before the concurrent validation of the links, the spec could be changed
+	v.operations = v.spec.Spec.operationIndex(true)
 
-	if errs := v.spec.validateSpec("", v); len(errs) > 0 {
-		joinErrors := make([]error, len(errs))
-		for i := range errs {
-			joinErrors[i] = errs[i]
+	errs := v.spec.validateSpec("", v)
+	if v.opts.failFast {
+		// the sibling nodes of the failed one could be already validated, so keep only the first error
+		if i := slices.IndexFunc(errs, v.isFatal); i >= 0 {
+			errs = errs[i : i+1]
 		}
-		return errors.Join(joinErrors...)
 	}
+	return newValidationResult(errs)
+}
 
-	return nil
+// validateDetached validates the given standalone object, e.g. built by a builder, and returns the joined errors.
+// The references, the operations of the links and the examples are not checked, because there is no document,
+// the warnings are ignored.
+func validateDetached(location string, spec validatable) error {
+	validator, err := NewValidator(&Extendable[OpenAPI]{Spec: &OpenAPI{}}, DoNotValidateExamples(), DoNotValidateDefaultValues())
+	if err != nil {
+		return err
+	}
+	validator.detached = true
+	validator.visited = make(visitedObjects)
+	validator.operationIDs = make(map[string][]string)
+	result := newValidationResult(spec.validateSpec(location, validator))
+	return errors.Join(result.Errors()...)
+}
+
+// isFatal returns true if the given error fails the validation.
+func (v *Validator) isFatal(e *validationError) bool {
+	return !e.warning || v.opts.treatWarningsAsErrors
+}
+
+// stopped returns true if the validation must be stopped, because of the fail fast mode or the done context.
+func (v *Validator) stopped() bool {
+	if run := v.run; run != nil && run.done != nil && run.nodes.Add(1)%contextCheckInterval == 0 {
+		select {
+		case <-run.done:
+			v.failed.Store(true)
+		default:
+		}
+	}
+	return v.failed.Load()
+}
+
+// checkFailFast marks the validation as failed if the fail fast mode is enabled and the given list contains an error.
+// It returns true if the validation must be stopped.
+func (v *Validator) checkFailFast(errs []*validationError) bool {
+	if v.opts.failFast && !v.failed.Load() && slices.ContainsFunc(errs, v.isFatal) {
+		v.failed.Store(true)
+	}
+	return v.failed.Load()
+}
+
+// isVisited returns true if the object with the given id is already visited.
+func (v *Validator) isVisited(id string) bool {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	return v.visited[id]
+}
+
+// markVisited marks the object with the given id as visited and returns true if it has been visited before.
+func (v *Validator) markVisited(id string) bool {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	if v.visited[id] {
+		return true
+	}
+	v.visited[id] = true
+	return false
+}
+
+// addOperationID marks the operation with the given id as visited and memorizes its location to check the uniqueness at the end.
+func (v *Validator) addOperationID(location, operationID string) {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+	v.visited[joinLoc("operations", operationID)] = true
+	v.operationIDs[operationID] = append(v.operationIDs[operationID], location)
+}
+
+// checkOperationIDs returns an error for each location of the operationId, which is used by several operations.
+func (v *Validator) checkOperationIDs() []*validationError {
+	var errs []*validationError
+	for id, locations := range v.operationIDs {
+		if len(locations) < 2 {
+			continue
+		}
+		slices.SortFunc(locations, compareLocations)
+		for i, location := range locations {
+			others := slices.Delete(slices.Clone(locations), i, i+1)
+			errs = append(errs, newValidationError(location, "'%s' is not unique, also used at %s", id, strings.Join(others, ", ")))
+		}
+	}
+	return errs
 }
 
 // ValidateData validates the given value against the schema located at the given location.
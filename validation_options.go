@@ -7,11 +7,23 @@ type validationOptions struct {
 	allowRequestBodyForGet          bool
 	allowRequestBodyForHead         bool
 	allowRequestBodyForDelete       bool
+	disallowUnexpectedRequestBody   bool
 	allowUndefinedTagsInOperation   bool
 	allowUnusedComponents           bool
+	allowUnknownLicenseIdentifiers  bool
 	doNotValidateExamples           bool
 	doNotValidateDefaultValues      bool
 	validateDataAsJSON              bool
+	validateFormats                 bool
+	treatWarningsAsErrors           bool
+	failFast                        bool
+	concurrency                     int
+	exactVersion                    string
+	warnMissingOperationID          bool
+	formats                         map[string]func(any) error
+	resolverCache                   *ResolverCache
+	requireHTTPS                    bool
+	urlChecker                      func(string) error
 	updateCompiler                  []func(*jsonschema.Compiler)
 }
 
@@ -25,7 +37,17 @@ func AllowExtensionNameWithoutPrefix() ValidationOption {
 	}
 }
 
+// DisallowUnexpectedRequestBody is a validation option to report the request body of GET, HEAD, DELETE and
+// TRACE operations as an error instead of a warning. The request bodies allowed by AllowRequestBodyForGet,
+// AllowRequestBodyForHead and AllowRequestBodyForDelete options are not reported at all.
+func DisallowUnexpectedRequestBody() ValidationOption {
+	return func(v *validationOptions) {
+		v.disallowUnexpectedRequestBody = true
+	}
+}
+
 // AllowRequestBodyForGet is a validation option to allow request body for GET operation.
+// By default, the request body of GET operation is reported as a warning, see DisallowUnexpectedRequestBody option.
 func AllowRequestBodyForGet() ValidationOption {
 	return func(v *validationOptions) {
 		v.allowRequestBodyForGet = true
@@ -33,6 +55,7 @@ func AllowRequestBodyForGet() ValidationOption {
 }
 
 // AllowRequestBodyForHead is a validation option to allow request body for HEAD operation.
+// By default, the request body of HEAD operation is reported as a warning, see DisallowUnexpectedRequestBody option.
 func AllowRequestBodyForHead() ValidationOption {
 	return func(v *validationOptions) {
 		v.allowRequestBodyForHead = true
@@ -40,13 +63,15 @@ func AllowRequestBodyForHead() ValidationOption {
 }
 
 // AllowRequestBodyForDelete is a validation option to allow request body for DELETE operation.
+// By default, the request body of DELETE operation is reported as a warning, see DisallowUnexpectedRequestBody option.
 func AllowRequestBodyForDelete() ValidationOption {
 	return func(v *validationOptions) {
 		v.allowRequestBodyForDelete = true
 	}
 }
 
-// AllowUndefinedTagsInOperation is a validation option to allow undefined tags in operation.
+// AllowUndefinedTagsInOperation is a validation option to allow undefined tags in operation,
+// otherwise a warning is reported for each tag of an operation, which is not declared in the root tags.
 func AllowUndefinedTagsInOperation() ValidationOption {
 	return func(v *validationOptions) {
 		v.allowUndefinedTagsInOperation = true
@@ -60,6 +85,14 @@ func AllowUnusedComponents() ValidationOption {
 	}
 }
 
+// AllowUnknownLicenseIdentifiers is a validation option to allow license identifiers, which are not in the embedded SPDX list,
+// e.g. the identifiers added in the newer SPDX releases. The syntax of the license expression is validated anyway.
+func AllowUnknownLicenseIdentifiers() ValidationOption {
+	return func(v *validationOptions) {
+		v.allowUnknownLicenseIdentifiers = true
+	}
+}
+
 // DoNotValidateExamples is a validation option to skip examples validation.
 func DoNotValidateExamples() ValidationOption {
 	return func(v *validationOptions) {
@@ -80,6 +113,105 @@ func ValidateStringDataAsJSON() ValidationOption {
 	}
 }
 
+// ValidateFormats is a validation option to enforce the `format` keyword when validating the data,
+// using the built-in validators for the standard formats: date, date-time, time, duration, uuid, email, hostname,
+// ipv4, ipv6, uri, uri-reference, iri, iri-reference, uri-template, json-pointer, relative-json-pointer and regex.
+// By default, the `format` keyword is an annotation only.
+func ValidateFormats() ValidationOption {
+	return func(v *validationOptions) {
+		v.validateFormats = true
+	}
+}
+
+// RegisterFormat is a validation option to add a validator for a custom format or to override a built-in one.
+// The function is called for the values of any type, so it should ignore the unsupported types.
+//
+// The registered formats are always enforced, the built-in validators only if ValidateFormats option is used,
+// except the `regex` format, which cannot be overridden and is enforced together with the registered formats.
+func RegisterFormat(name string, fn func(any) error) ValidationOption {
+	return func(v *validationOptions) {
+		if v.formats == nil {
+			v.formats = make(map[string]func(any) error, 1)
+		}
+		v.formats[name] = fn
+	}
+}
+
+// TreatWarningsAsErrors is a validation option to return the warnings by ValidateSpec method together with the errors.
+func TreatWarningsAsErrors() ValidationOption {
+	return func(v *validationOptions) {
+		v.treatWarningsAsErrors = true
+	}
+}
+
+// FailFast is a validation option to stop the validation of the specification as soon as the first error is found.
+// The result contains only that error, the warnings are ignored unless TreatWarningsAsErrors option is used.
+//
+// It is useful for a quick pass/fail check of the big specifications.
+// The maps are validated in random order, so the found error can differ between runs.
+// The traversal of the document is stopped at the first error, so an early error, e.g. in the info object,
+// makes the validation short, see BenchmarkValidator_ValidateSpec.
+func FailFast() ValidationOption {
+	return func(v *validationOptions) {
+		v.failFast = true
+	}
+}
+
+// Concurrency is a validation option to validate the components of each kind concurrently
+// using up to the given number of goroutines.
+// The result is the same as for the sequential validation, because the errors are sorted anyway.
+//
+// The speedup depends on the number of available CPUs, see BenchmarkValidator_ValidateSpec_Concurrency.
+// With a single CPU the overhead of synchronization makes the concurrent validation slower than the sequential one.
+func Concurrency(n int) ValidationOption {
+	return func(v *validationOptions) {
+		v.concurrency = n
+	}
+}
+
+// WarnMissingOperationID is a validation option to report a warning for each operation without operationId.
+// The operationId is optional, but it is recommended, because the tools, like code generators, use it to identify the operations.
+func WarnMissingOperationID() ValidationOption {
+	return func(v *validationOptions) {
+		v.warnMissingOperationID = true
+	}
+}
+
+// RequireVersion is a validation option to accept the documents of the given OpenAPI version only, e.g. `3.1.1`.
+// By default, any 3.1.x version is accepted.
+func RequireVersion(version string) ValidationOption {
+	return func(v *validationOptions) {
+		v.exactVersion = version
+	}
+}
+
+// WithResolverCache is a validation option to resolve the references using the given cache,
+// so the cache is reused by several validations of the same document.
+// By default, the validator creates a new cache for each validation of the specification.
+func WithResolverCache(cache *ResolverCache) ValidationOption {
+	return func(v *validationOptions) {
+		v.resolverCache = cache
+	}
+}
+
+// RequireHTTPS is a validation option to require the absolute URLs with `https` scheme for all the URL fields,
+// e.g. `termsOfService`, `url` of the license or `tokenUrl` of the OAuth flow.
+// By default, any URL, including the relative references like `../LICENSE`, is accepted,
+// use WithURLChecker option for a custom policy, e.g. to require https for the absolute URLs only.
+func RequireHTTPS() ValidationOption {
+	return func(v *validationOptions) {
+		v.requireHTTPS = true
+	}
+}
+
+// WithURLChecker is a validation option to check the URL fields by the given function instead of the default check,
+// the non-empty values are passed only. RequireHTTPS option is ignored.
+func WithURLChecker(f func(string) error) ValidationOption {
+	return func(v *validationOptions) {
+		v.urlChecker = f
+	}
+}
+
 // UpdateCompiler is a type to modify the jsonschema.Compiler.
 func UpdateCompiler(f func(*jsonschema.Compiler)) ValidationOption {
 	return func(v *validationOptions) {
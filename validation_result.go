@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// ValidationResult is the structured result of the specification validation.
+//
+// The locations of the issues are JSON Pointers to the offending nodes of the specification,
+// e.g. `/paths/~1pets/get/parameters/0/name`, the root of the document is an empty string.
+type ValidationResult struct {
+	// issues is the sorted list of the errors and the warnings
+	issues []*validationError
+}
+
+func newValidationResult(issues []*validationError) *ValidationResult {
+	sortValidationErrors(issues)
+	return &ValidationResult{issues: issues}
+}
+
+// Errors returns the list of the errors sorted by location and then by message.
+func (r *ValidationResult) Errors() []error {
+	return r.filter(false)
+}
+
+// Warnings returns the list of the warnings sorted by location and then by message.
+func (r *ValidationResult) Warnings() []error {
+	return r.filter(true)
+}
+
+func (r *ValidationResult) filter(warning bool) []error {
+	var errs []error
+	for _, e := range r.issues {
+		if e.warning == warning {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// HasErrors returns true if there is at least one error, the warnings are not counted.
+func (r *ValidationResult) HasErrors() bool {
+	return slices.ContainsFunc(r.issues, func(e *validationError) bool { return !e.warning })
+}
+
+// ByLocation returns the errors and the warnings grouped by JSON Pointer of the offending node.
+//
+// The returned errors do not contain the location in the message.
+func (r *ValidationResult) ByLocation() map[string][]error {
+	m := make(map[string][]error)
+	for _, e := range r.issues {
+		m[e.location] = append(m[e.location], e.err)
+	}
+	return m
+}
+
+// ValidationIssue is an item of the machine-readable validation report.
+type ValidationIssue struct {
+	// Location is the JSON Pointer of the offending node.
+	Location string `json:"location"`
+	// Message is the description of the issue.
+	Message string `json:"message"`
+	// Severity is either `error` or `warning`.
+	Severity string `json:"severity"`
+}
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issues returns all the errors and the warnings sorted by location and then by message.
+func (r *ValidationResult) Issues() []ValidationIssue {
+	issues := make([]ValidationIssue, len(r.issues))
+	for i, e := range r.issues {
+		issues[i] = ValidationIssue{
+			Location: e.location,
+			Message:  e.err.Error(),
+			Severity: SeverityError,
+		}
+		if e.warning {
+			issues[i].Severity = SeverityWarning
+		}
+	}
+	return issues
+}
+
+// JSON returns the machine-readable report as a JSON array of the issues, see ValidationIssue.
+func (r *ValidationResult) JSON() ([]byte, error) {
+	return json.Marshal(r.Issues())
+}
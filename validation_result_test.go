@@ -0,0 +1,70 @@
+package openapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestValidator_ValidateSpecResult(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddPath("/pets/{id}", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().
+				AddParameters(
+					openapi.NewParameterBuilder().Name("id").In(openapi.InPath).Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build(),
+					openapi.NewParameterBuilder().Name("tags[]").In(openapi.InQuery).Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build(),
+				).
+				Build()).
+			Build()).
+		Build()
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	result := validator.ValidateSpecResult()
+	require.Truef(t, result.HasErrors(), "expected errors")
+	require.Len(t, result.Errors(), 1)
+	require.Len(t, result.Warnings(), 1)
+
+	byLocation := result.ByLocation()
+	require.Len(t, byLocation, 2)
+	require.Len(t, byLocation["/paths/~1pets~1{id}/get/parameters/0/required"], 1)
+	require.Equal(t, "must be `true` when `in` is 'path'", byLocation["/paths/~1pets~1{id}/get/parameters/0/required"][0].Error())
+	require.Len(t, byLocation["/paths/~1pets~1{id}/get/parameters/1/name"], 1)
+
+	data, err := result.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+  {"location": "/paths/~1pets~1{id}/get/parameters/0/required", "message": "must be `+"`true`"+` when `+"`in`"+` is 'path'", "severity": "error"},
+  {"location": "/paths/~1pets~1{id}/get/parameters/1/name", "message": "'tags[]' contains reserved characters: ':/?#[]@!$&'()*+,;='", "severity": "warning"}
+]`, string(data))
+
+	t.Run("no issues", func(t *testing.T) {
+		validator, err := openapi.NewValidator(openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddPath("/ping", openapi.NewPathItemBuilder().Build()).
+			Build())
+		require.NoError(t, err)
+		result := validator.ValidateSpecResult()
+		require.Truef(t, !result.HasErrors(), "expected no errors")
+		require.Empty(t, result.ByLocation())
+		data, err := result.JSON()
+		require.NoError(t, err)
+		require.JSONEq(t, `[]`, string(data))
+	})
+
+	t.Run("wrapped errors", func(t *testing.T) {
+		spec := openapi.NewOpenAPIBuilder().OpenAPI("3.1.1").Build()
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		for _, errs := range validator.ValidateSpecResult().ByLocation() {
+			for _, e := range errs {
+				require.Truef(t, errors.Is(e, openapi.ErrRequired), "expected ErrRequired, got %v", e)
+			}
+		}
+	})
+}
@@ -1,9 +1,15 @@
 package openapi_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/sv-tools/openapi"
@@ -224,6 +230,25 @@ func TestValidator_ValidateSpec_ManuallyCreated(t *testing.T) {
 			opts: []openapi.ValidationOption{openapi.AllowUnusedComponents()},
 			err:  "at '': got string, want integer",
 		},
+		{
+			name: "link with invalid runtime expression",
+			spec: openapi.NewOpenAPIBuilder().Info(
+				openapi.NewInfoBuilder().
+					Title("Minimal Valid Spec").
+					Version("1.0.0").
+					Build(),
+			).AddComponent("UserAddress", openapi.NewLinkBuilder().
+				OperationRef("#/components/paths/address/get").
+				AddParameter("userId", "$request.path").
+				AddParameter("constant", "42").
+				Build(),
+			).AddComponent("address", openapi.NewPathItemBuilder().
+				Get(openapi.NewOperationBuilder().Build()).
+				Build(),
+			).Build(),
+			opts: []openapi.ValidationOption{openapi.AllowUnusedComponents()},
+			err:  "/components/links/UserAddress/parameters/userId: invalid runtime expression: \"$request.path\": name is required",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			v, err := openapi.NewValidator(tt.spec, tt.opts...)
@@ -336,3 +361,544 @@ func TestValidator_ValidateData(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_ValidateData_Keywords(t *testing.T) {
+	t.Parallel()
+
+	const spec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Type": {"type": "integer"},
+      "Enum": {"enum": ["cat", "dog"]},
+      "Const": {"const": 42},
+      "Object": {
+        "type": "object",
+        "required": ["id"],
+        "properties": {"id": {"type": "integer"}, "name": {"type": "string"}},
+        "additionalProperties": false
+      },
+      "Items": {"type": "array", "items": {"type": "string"}},
+      "Range": {"type": "number", "minimum": 1, "maximum": 10},
+      "ExclusiveRange": {"type": "number", "exclusiveMinimum": 1, "exclusiveMaximum": 10},
+      "Length": {"type": "string", "minLength": 2, "maxLength": 3},
+      "Pattern": {"type": "string", "pattern": "^[a-z]+$"},
+      "ArraySize": {"type": "array", "minItems": 1, "maxItems": 2, "uniqueItems": true},
+      "AdditionalProperties": {"type": "object", "additionalProperties": {"type": "integer"}},
+      "Ref": {"type": "object", "properties": {"pet": {"$ref": "#/components/schemas/Object"}}}
+    }
+  }
+}`
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(spec), &doc))
+	validator, err := openapi.NewValidator(&doc)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		schema string
+		data   string
+		err    string
+	}{
+		{schema: "Type", data: `1`},
+		{schema: "Type", data: `"1"`, err: "at '': got string, want integer"},
+		{schema: "Type", data: `1.5`, err: "at '': got number, want integer"},
+		{schema: "Enum", data: `"dog"`},
+		{schema: "Enum", data: `"bird"`, err: "at '': value must be one of 'cat', 'dog'"},
+		{schema: "Const", data: `42`},
+		{schema: "Const", data: `43`, err: "at '': value must be 42"},
+		{schema: "Object", data: `{"id": 1, "name": "foo"}`},
+		{schema: "Object", data: `{"name": "foo"}`, err: "at '': missing property 'id'"},
+		{schema: "Object", data: `{"id": "1"}`, err: "at '/id': got string, want integer"},
+		{schema: "Object", data: `{"id": 1, "tag": "foo"}`, err: "at '': additional properties 'tag' not allowed"},
+		{schema: "Items", data: `["a", "b"]`},
+		{schema: "Items", data: `["a", 1]`, err: "at '/1': got number, want string"},
+		{schema: "Range", data: `1`},
+		{schema: "Range", data: `10`},
+		{schema: "Range", data: `0`, err: "at '': minimum: got 0, want 1"},
+		{schema: "Range", data: `11`, err: "at '': maximum: got 11, want 10"},
+		{schema: "ExclusiveRange", data: `5`},
+		{schema: "ExclusiveRange", data: `1`, err: "at '': exclusiveMinimum: got 1, want 1"},
+		{schema: "ExclusiveRange", data: `10`, err: "at '': exclusiveMaximum: got 10, want 10"},
+		{schema: "Length", data: `"ab"`},
+		{schema: "Length", data: `"a"`, err: "at '': minLength: got 1, want 2"},
+		{schema: "Length", data: `"abcd"`, err: "at '': maxLength: got 4, want 3"},
+		{schema: "Pattern", data: `"abc"`},
+		{schema: "Pattern", data: `"ABC"`, err: "at '': 'ABC' does not match pattern '^[a-z]+$'"},
+		{schema: "ArraySize", data: `[1, 2]`},
+		{schema: "ArraySize", data: `[]`, err: "at '': minItems: got 0, want 1"},
+		{schema: "ArraySize", data: `[1, 2, 3]`, err: "at '': maxItems: got 3, want 2"},
+		{schema: "ArraySize", data: `[1, 1]`, err: "at '': items at 0 and 1 are equal"},
+		{schema: "AdditionalProperties", data: `{"a": 1}`},
+		{schema: "AdditionalProperties", data: `{"a": "1"}`, err: "at '/a': got string, want integer"},
+		{schema: "Ref", data: `{"pet": {"id": 1}}`},
+		{schema: "Ref", data: `{"pet": {"id": "1"}}`, err: "at '/pet/id': got string, want integer"},
+	} {
+		t.Run(tt.schema+" "+tt.data, func(t *testing.T) {
+			t.Parallel()
+
+			var data any
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &data))
+			err := validator.ValidateData("/components/schemas/"+tt.schema, data)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateData_Composition(t *testing.T) {
+	t.Parallel()
+
+	const spec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Cat": {"type": "object", "required": ["meow"], "properties": {"meow": {"type": "boolean"}}},
+      "Dog": {"type": "object", "required": ["bark"], "properties": {"bark": {"type": "boolean"}}},
+      "Named": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}},
+      "AllOf": {"allOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Named"}]},
+      "AnyOf": {"anyOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}]},
+      "OneOf": {"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}]},
+      "Not": {"not": {"$ref": "#/components/schemas/Cat"}}
+    }
+  }
+}`
+	var doc openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal([]byte(spec), &doc))
+	validator, err := openapi.NewValidator(&doc)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		schema string
+		data   string
+		err    string
+	}{
+		{schema: "AllOf", data: `{"meow": true, "name": "Tom"}`},
+		{schema: "AllOf", data: `{"meow": true}`, err: "missing property 'name'"},
+		{schema: "AnyOf", data: `{"bark": true}`},
+		{schema: "AnyOf", data: `{"meow": true, "bark": true}`},
+		{schema: "AnyOf", data: `{"name": "Tom"}`, err: "'anyOf' failed"},
+		{schema: "OneOf", data: `{"meow": true}`},
+		{schema: "OneOf", data: `{"meow": true, "bark": true}`, err: "'oneOf' failed, subschemas 0, 1 matched"},
+		{schema: "OneOf", data: `{"name": "Tom"}`, err: "'oneOf' failed, none matched"},
+		{schema: "Not", data: `{"bark": true}`},
+		{schema: "Not", data: `{"meow": true}`, err: "'not' failed"},
+	} {
+		t.Run(tt.schema+" "+tt.data, func(t *testing.T) {
+			t.Parallel()
+
+			var data any
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &data))
+			err := validator.ValidateData("/components/schemas/"+tt.schema, data)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateSpec_SortedErrors(t *testing.T) {
+	b := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Sorted Errors").Version("1.0.0").Build()).
+		Paths(openapi.NewPaths())
+	for i := range 12 {
+		b.AddTags(openapi.NewTagBuilder().Name(fmt.Sprintf("tag%d", i)).Build())
+		b.AddPath(fmt.Sprintf("path%d", i), openapi.NewPathItemBuilder().Build())
+	}
+	spec := b.Build()
+
+	var expected string
+	for range 10 {
+		v, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		err = v.ValidateSpec()
+		require.Error(t, err)
+		if expected == "" {
+			expected = err.Error()
+			continue
+		}
+		require.Equal(t, expected, err.Error())
+	}
+	require.Truef(t, strings.Index(expected, "/paths/path1:") < strings.Index(expected, "/paths/path10:"), "paths must be sorted lexically: %s", expected)
+	require.Truef(t, strings.Index(expected, "/tags/2:") < strings.Index(expected, "/tags/10:"), "indexes must be sorted numerically: %s", expected)
+	require.Truef(t, strings.Index(expected, "/paths/") < strings.Index(expected, "/tags/"), "locations must be sorted: %s", expected)
+}
+
+func TestValidator_Formats(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddComponent("ID", openapi.NewSchemaBuilder().Type(openapi.StringType).Format(openapi.UUIDFormat).Examples("not-a-uuid").Build()).
+		AddComponent("Phone", openapi.NewSchemaBuilder().Type(openapi.StringType).Format("phone").Build()).
+		Build()
+
+	checkPhone := func(v any) error {
+		if s, ok := v.(string); ok && !strings.HasPrefix(s, "+") {
+			return fmt.Errorf("phone number must start with '+'")
+		}
+		return nil
+	}
+
+	for _, tt := range []struct {
+		name     string
+		opts     []openapi.ValidationOption
+		specErr  string
+		phoneErr string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:    "built-in formats",
+			opts:    []openapi.ValidationOption{openapi.ValidateFormats()},
+			specErr: "/components/schemas/ID/examples/0: jsonschema validation failed",
+		},
+		{
+			name:     "registered format only",
+			opts:     []openapi.ValidationOption{openapi.RegisterFormat("phone", checkPhone)},
+			phoneErr: "phone number must start with '+'",
+		},
+		{
+			name:     "built-in and registered formats",
+			opts:     []openapi.ValidationOption{openapi.ValidateFormats(), openapi.RegisterFormat("phone", checkPhone)},
+			specErr:  "is not valid uuid",
+			phoneErr: "phone number must start with '+'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(spec, append(tt.opts, openapi.AllowUnusedComponents())...)
+			require.NoError(t, err)
+
+			err = validator.ValidateSpec()
+			if tt.specErr != "" {
+				require.ErrorContains(t, err, tt.specErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, validator.ValidateData("#/components/schemas/Phone", "+123456789"))
+			err = validator.ValidateData("#/components/schemas/Phone", "123456789")
+			if tt.phoneErr != "" {
+				require.ErrorContains(t, err, tt.phoneErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidator_Validate_Warnings(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().
+				AddParameters(
+					openapi.NewParameterBuilder().Name("tags[]").In(openapi.InQuery).Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build(),
+					openapi.NewParameterBuilder().Name("q").In(openapi.InQuery).AllowEmptyValue(true).Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).Build(),
+				).
+				Build()).
+			Build()).
+		Build()
+
+	t.Run("separate", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		errs, warnings := validator.Validate()
+		require.Empty(t, errs)
+		require.Len(t, warnings, 2)
+		require.ErrorContains(t, warnings[0], "/paths/~1pets/get/parameters/0/name: 'tags[]' contains reserved characters")
+		require.ErrorContains(t, warnings[1], "/paths/~1pets/get/parameters/1/allowEmptyValue: usage is not recommended")
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("as errors", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec, openapi.TreatWarningsAsErrors())
+		require.NoError(t, err)
+		err = validator.ValidateSpec()
+		require.ErrorContains(t, err, "contains reserved characters")
+		require.ErrorContains(t, err, "usage is not recommended")
+	})
+
+	t.Run("deprecated example", func(t *testing.T) {
+		schema := openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+		schema.Spec.Example = "foo"
+		doc := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddComponent("Name", schema).
+			Build()
+		validator, err := openapi.NewValidator(doc, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		errs, warnings := validator.Validate()
+		require.Empty(t, errs)
+		require.Len(t, warnings, 1)
+		require.Truef(t, errors.Is(warnings[0], openapi.ErrDeprecated), "expected ErrDeprecated, got %v", warnings[0])
+	})
+}
+
+func bigSpec(paths int) *openapi.Extendable[openapi.OpenAPI] {
+	builder := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+		AddComponent("Pet", openapi.NewSchemaBuilder().
+			Type(openapi.ObjectType).
+			AddProperty("id", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Examples(1, 2).Build()).
+			AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Examples("cat", "dog").Build()).
+			Build())
+	for i := range paths {
+		builder.AddPath(fmt.Sprintf("/pets%d/{id}", i), openapi.NewPathItemBuilder().
+			Put(openapi.NewOperationBuilder().
+				OperationID(fmt.Sprintf("updatePet%d", i)).
+				AddParameters(openapi.NewParameterBuilder().
+					Name("id").
+					In(openapi.InPath).
+					Required(true).
+					Schema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Examples(42).Build()).
+					Build()).
+				RequestBody(openapi.NewRequestBodyBuilder().
+					AddContent("application/json", openapi.NewMediaTypeBuilder().
+						Schema(openapi.NewSchemaBuilder().Ref("#/components/schemas/Pet").Build()).
+						Build()).
+					Build()).
+				Build()).
+			Build())
+	}
+	return builder.Build()
+}
+
+func TestValidator_FailFast(t *testing.T) {
+	spec := bigSpec(10)
+	spec.Spec.Info.Spec.Title = ""
+	spec.Spec.Info.Spec.Version = ""
+	spec.Spec.Paths.Spec.Paths["/pets0/{id}"].Spec.Spec.Put.Spec.Parameters[0].Spec.Spec.Required = false
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	errs, _ := validator.Validate()
+	require.Len(t, errs, 3)
+
+	validator, err = openapi.NewValidator(spec, openapi.FailFast())
+	require.NoError(t, err)
+	errs, warnings := validator.Validate()
+	require.Len(t, errs, 1)
+	require.Empty(t, warnings)
+	require.ErrorContains(t, errs[0], "/info/")
+
+	// the state is reset between the runs
+	spec.Spec.Info.Spec.Title = "test"
+	spec.Spec.Info.Spec.Version = "1.0.0"
+	errs, _ = validator.Validate()
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "/paths/~1pets0~1{id}/put/parameters/0/required")
+
+	spec.Spec.Paths.Spec.Paths["/pets0/{id}"].Spec.Spec.Put.Spec.Parameters[0].Spec.Spec.Required = true
+	require.NoError(t, validator.ValidateSpec())
+}
+
+func BenchmarkValidator_ValidateSpec(b *testing.B) {
+	spec := bigSpec(1000)
+	// the paths are validated in random order, so the error is found in the middle on average
+	spec.Spec.Paths.Spec.Paths["/pets0/{id}"].Spec.Spec.Put.Spec.Parameters[0].Spec.Spec.Required = false
+
+	for _, bb := range []struct {
+		name string
+		opts []openapi.ValidationOption
+	}{
+		{name: "all errors"},
+		{name: "fail fast", opts: []openapi.ValidationOption{openapi.FailFast()}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			validator, err := openapi.NewValidator(spec, bb.opts...)
+			require.NoError(b, err)
+			b.ResetTimer()
+			for range b.N {
+				if validator.ValidateSpec() == nil {
+					b.Fatal("expected error")
+				}
+			}
+		})
+	}
+}
+
+func componentsSpec(schemas int) *openapi.Extendable[openapi.OpenAPI] {
+	builder := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build())
+	for i := range schemas {
+		builder.AddComponent(fmt.Sprintf("Pet%d", i), openapi.NewSchemaBuilder().
+			Type(openapi.ObjectType).
+			AddProperty("id", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Examples(1, 2).Build()).
+			AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Examples("cat", "dog").Build()).
+			AddProperty("parent", openapi.NewSchemaBuilder().Ref(fmt.Sprintf("#/components/schemas/Pet%d", (i+1)%schemas)).Build()).
+			Build())
+		builder.AddComponent(fmt.Sprintf("id%d", i), openapi.NewParameterBuilder().
+			Name("id").
+			In(openapi.InQuery).
+			Schema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Examples(42).Build()).
+			Build())
+	}
+	return builder.Build()
+}
+
+func TestValidator_Concurrency(t *testing.T) {
+	spec := componentsSpec(50)
+	// add some errors
+	spec.Spec.Components.Spec.Schemas["Pet3"].Spec.Properties["id"].Spec.Examples = []any{"one"}
+	spec.Spec.Components.Spec.Schemas["invalid name"] = openapi.NewSchemaBuilder().Ref("#/components/schemas/Pet0").Build()
+	spec.Spec.Components.Spec.Schemas["Missing"] = openapi.NewSchemaBuilder().Ref("#/components/schemas/Missing1").Build()
+	// the same broken ref is reported at every location
+	for i := range 10 {
+		spec.Spec.Components.Spec.Schemas["Pet"+strconv.Itoa(i)].Spec.Properties["owner"] = openapi.NewSchemaBuilder().Ref("#/components/schemas/Missing1").Build()
+	}
+
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	expected := validator.ValidateSpec()
+	require.NotNil(t, expected)
+	require.ErrorContains(t, expected, "/components/schemas/Missing: spec not found")
+	for i := range 10 {
+		require.ErrorContains(t, expected, "/components/schemas/Pet"+strconv.Itoa(i)+"/properties/owner: spec not found")
+	}
+	require.ErrorContains(t, expected, "/components/schemas/Pet3/properties/id/examples/0: jsonschema validation failed")
+	require.ErrorContains(t, expected, "/components/schemas/invalid name: invalid name")
+
+	for _, n := range []int{1, 2, 8, 100} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.Concurrency(n))
+			require.NoError(t, err)
+			for range 3 {
+				require.Equal(t, expected.Error(), validator.ValidateSpec().Error())
+			}
+		})
+	}
+}
+
+func BenchmarkValidator_ValidateSpec_Concurrency(b *testing.B) {
+	spec := componentsSpec(1000)
+	for _, n := range []int{1, 4, 16} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.Concurrency(n))
+			require.NoError(b, err)
+			// compile the schemas before measurement
+			require.NoError(b, validator.ValidateSpec())
+			b.ResetTimer()
+			for range b.N {
+				_ = validator.ValidateSpec()
+			}
+		})
+	}
+}
+
+func TestBuildValid(t *testing.T) {
+	t.Run("parameter", func(t *testing.T) {
+		builder := openapi.NewParameterBuilder().Name("id").In(openapi.InPath).Schema(openapi.NewSchemaBuilder().Ref("#/components/schemas/ID").Build())
+		param, err := builder.BuildValid()
+		require.ErrorContains(t, err, "/required: must be `true` when `in` is 'path'")
+		require.Nil(t, param)
+
+		param, err = builder.Required(true).BuildValid()
+		require.NoError(t, err)
+		require.NotNil(t, param)
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		responses, err := openapi.NewResponsesBuilder().BuildValid()
+		require.ErrorContains(t, err, ": must contain at least one response code: required")
+		require.Nil(t, responses)
+
+		// the warning about missing successful response is ignored
+		responses, err = openapi.NewResponsesBuilder().AddResponse("404", openapi.NewResponseBuilder().Description("not found").Build()).BuildValid()
+		require.NoError(t, err)
+		require.NotNil(t, responses)
+	})
+
+	t.Run("link", func(t *testing.T) {
+		link, err := openapi.NewLinkBuilder().OperationID("getPet").OperationRef("#/paths/~1pets/get").BuildValid()
+		require.ErrorContains(t, err, "/operationRef&operationId: mutually exclusive")
+		require.Nil(t, link)
+
+		link, err = openapi.NewLinkBuilder().OperationID("getPet").AddParameter("id", "$response.body#/id").BuildValid()
+		require.NoError(t, err)
+		require.NotNil(t, link)
+	})
+}
+
+func TestValidator_ValidateContext(t *testing.T) {
+	const schemas = 1000
+	builder := openapi.NewOpenAPIBuilder().
+		OpenAPI("3.1.1").
+		Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build())
+	for i := range schemas {
+		builder.AddComponent(fmt.Sprintf("Name%d", i), openapi.NewSchemaBuilder().Type(openapi.StringType).Format("pet-name").Examples("Fluffy").Build())
+	}
+	spec := builder.Build()
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls int
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.RegisterFormat("pet-name", func(any) error {
+			calls++
+			if calls == 10 {
+				cancel()
+			}
+			return nil
+		}))
+		require.NoError(t, err)
+		errs, err := validator.ValidateContext(ctx)
+		require.Equal(t, true, errors.Is(err, context.Canceled))
+		require.Empty(t, errs)
+		require.Truef(t, calls < schemas/10, "the validation must be stopped promptly, but %d schemas are validated", calls)
+
+		// the validator can be used again
+		errs, err = validator.ValidateContext(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, errs)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.RegisterFormat("pet-name", func(any) error {
+			return errors.New("invalid pet name")
+		}))
+		require.NoError(t, err)
+		errs, err := validator.ValidateContext(context.Background())
+		require.NoError(t, err)
+		require.Len(t, errs, schemas)
+		require.ErrorContains(t, errs[0], "/components/schemas/Name0/examples/0: ")
+	})
+
+	t.Run("done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		_, err = validator.ValidateContext(ctx)
+		require.Equal(t, true, errors.Is(err, context.Canceled))
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		var wg sync.WaitGroup
+		for i := range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if i%2 == 0 {
+					errs, err := validator.ValidateContext(context.Background())
+					require.NoError(t, err)
+					require.Empty(t, errs)
+					return
+				}
+				require.NoError(t, validator.ValidateSpec())
+			}()
+		}
+		wg.Wait()
+	})
+}
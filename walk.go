@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrStopWalk is used as a return value from the visit function to stop the traversal.
+	// It is not returned as an error by Walk.
+	ErrStopWalk = errors.New("stop walk")
+	// ErrSkipNode is used as a return value from the visit function to skip the children of the current node.
+	// It is not returned as an error by Walk.
+	ErrSkipNode = errors.New("skip node")
+)
+
+// WalkFunc is the type of the function called by Walk to visit each node.
+type WalkFunc func(location string, node any) error
+
+// Walk traverses the given document depth-first and calls the visit function for each node.
+//
+// The node is a pointer to any object of the document, e.g. *Extendable[Operation], *Operation,
+// *RefOrSpec[Schema], *Schema, *Ref, *Discriminator, etc.
+// The wrappers and their specs (RefOrSpec, Extendable) are visited one by one with the same location,
+// the location is the JSON Pointer of the node in the form used by the validation, e.g. `/paths/~1pets/get`.
+// The references are not followed, the values of examples, defaults and extensions are not traversed.
+// The keys of the maps are visited in the sorted order.
+//
+// If the visit function returns ErrSkipNode, the children of the node are skipped;
+// ErrStopWalk stops the traversal and Walk returns nil; any other error stops the traversal and is returned.
+func Walk(doc *OpenAPI, visit WalkFunc) error {
+	w := walker{visit: visit, visited: make(map[pointerKey]bool)}
+	if err := w.walk(reflect.ValueOf(doc), ""); err != nil && !errors.Is(err, ErrStopWalk) {
+		return err
+	}
+	return nil
+}
+
+type walker struct {
+	visit   WalkFunc
+	visited map[pointerKey]bool
+}
+
+func (w *walker) walk(v reflect.Value, location string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() != reflect.Struct {
+			return w.walk(v.Elem(), location)
+		}
+		key := pointerKey{ptr: v.Pointer(), typ: v.Type()}
+		if w.visited[key] {
+			return nil
+		}
+		w.visited[key] = true
+		if err := w.visit(location, v.Interface()); err != nil {
+			if errors.Is(err, ErrSkipNode) {
+				return nil
+			}
+			return err
+		}
+		return w.walk(v.Elem(), location)
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() || f.Type.Kind() == reflect.Interface {
+				continue
+			}
+			loc := location
+			if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+				loc = joinLoc(location, name)
+			}
+			if err := w.walk(v.Field(i), loc); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() == reflect.Interface {
+			return nil
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		for _, k := range keys {
+			if err := w.walk(v.MapIndex(k), joinLoc(location, k.String())); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Interface {
+			return nil
+		}
+		for i := range v.Len() {
+			if err := w.walk(v.Index(i), joinLoc(location, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
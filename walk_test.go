@@ -0,0 +1,74 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestWalk(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "petstore.json"))
+	require.NoError(t, err)
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal(data, &spec))
+
+	var (
+		schemas   []string
+		refs      []string
+		responses int
+	)
+	require.NoError(t, openapi.Walk(spec.Spec, func(location string, node any) error {
+		switch n := node.(type) {
+		case *openapi.Schema:
+			schemas = append(schemas, location)
+		case *openapi.Ref:
+			refs = append(refs, location+" "+n.Ref)
+		case *openapi.Response:
+			responses++
+		}
+		return nil
+	}))
+	require.Equal(t, []string{
+		"/components/schemas/Error",
+		"/components/schemas/Error/properties/code",
+		"/components/schemas/Error/properties/message",
+		"/components/schemas/Pet",
+		"/components/schemas/Pet/properties/id",
+		"/components/schemas/Pet/properties/name",
+		"/components/schemas/Pet/properties/tag",
+		"/components/schemas/Pets",
+		"/paths/~1pets/get/responses/200/headers/x-next/schema",
+		"/paths/~1pets/get/parameters/0/schema",
+		"/paths/~1pets~1{petId}/get/parameters/0/schema",
+	}, schemas)
+	require.Equal(t, 6, responses)
+	require.Len(t, refs, 7)
+
+	t.Run("skip and stop", func(t *testing.T) {
+		var visited []string
+		require.NoError(t, openapi.Walk(spec.Spec, func(location string, node any) error {
+			switch node.(type) {
+			case *openapi.Components:
+				return openapi.ErrSkipNode
+			case *openapi.Operation:
+				visited = append(visited, location)
+				if len(visited) == 2 {
+					return openapi.ErrStopWalk
+				}
+			}
+			return nil
+		}))
+		require.Equal(t, []string{"/paths/~1pets/get", "/paths/~1pets/post"}, visited)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expected := errors.New("test")
+		err := openapi.Walk(spec.Spec, func(string, any) error { return expected })
+		require.Truef(t, errors.Is(err, expected), "unexpected error: %v", err)
+	})
+}
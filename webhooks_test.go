@@ -0,0 +1,60 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	const data = `{
+  "openapi": "3.1.1",
+  "info": {"title": "test", "version": "1.0.0"},
+  "webhooks": {
+    "newPet": {
+      "post": {
+        "requestBody": {
+          "description": "Information about a new pet in the system",
+          "content": {"application/json": {"schema": {"type": "object"}}}
+        },
+        "responses": {"200": {"description": "Return a 200 status to indicate that the data was received successfully"}}
+      }
+    }
+  }
+}`
+
+	t.Run("round trip", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, json.Unmarshal([]byte(data), &spec))
+		require.Len(t, spec.Spec.WebHooks, 1)
+		require.NotNil(t, spec.Spec.WebHooks["newPet"].Spec.Spec.Post)
+		newData, err := json.Marshal(spec)
+		require.NoError(t, err)
+		require.JSONEq(t, data, string(newData))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, json.Unmarshal([]byte(data), &spec))
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("invalid path item", func(t *testing.T) {
+		operation := openapi.NewOperationBuilder().Build()
+		operation.Spec.Responses = openapi.NewResponsesBuilder().
+			AddResponse("200", openapi.NewResponseBuilder().Build()).
+			Build().Spec
+		spec := openapi.NewOpenAPIBuilder().
+			OpenAPI("3.1.1").
+			Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+			AddWebHook("newPet", openapi.NewPathItemBuilder().Post(operation).Build()).
+			Build()
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		require.ErrorContains(t, validator.ValidateSpec(), "/webhooks/newPet/post/responses/200/description: required")
+	})
+}
@@ -1,5 +1,7 @@
 package openapi
 
+import "net/url"
+
 // XML is a metadata object that allows for more fine-tuned XML model definitions.
 // When using arrays, XML element names are not inferred (for singular/plural forms) and the name property SHOULD
 // be used to add that information.
@@ -46,8 +48,20 @@ type XML struct {
 	Wrapped bool `json:"wrapped,omitempty"`
 }
 
-func (o *XML) validateSpec(path string, validator *Validator) []*validationError {
-	return nil // nothing to validate
+func (o *XML) validateSpec(location string, validator *Validator) []*validationError {
+	var errs []*validationError
+	if err := validator.checkURL(o.Namespace); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "namespace"), err))
+	} else if u, _ := url.Parse(o.Namespace); o.Namespace != "" && !u.IsAbs() {
+		errs = append(errs, newValidationError(joinLoc(location, "namespace"), "must be an absolute URI, but got '%s'", o.Namespace))
+	}
+	if o.Prefix != "" && o.Namespace == "" {
+		errs = append(errs, newValidationError(joinLoc(location, "prefix"), "requires `namespace` to be set"))
+	}
+	if o.Attribute && o.Wrapped {
+		errs = append(errs, newValidationError(joinLoc(location, "attribute&wrapped"), ErrMutuallyExclusive))
+	}
+	return errs
 }
 
 type XMLBuilder struct {
@@ -0,0 +1,39 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/internal/require"
+)
+
+func TestXML_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		xml  *openapi.Extendable[openapi.XML]
+		err  string
+	}{
+		{name: "valid", xml: openapi.NewXMLBuilder().Name("name").Namespace("https://example.com/schema/sample").Prefix("sample").Build()},
+		{name: "urn", xml: openapi.NewXMLBuilder().Namespace("urn:example:sample").Build()},
+		{name: "relative namespace", xml: openapi.NewXMLBuilder().Namespace("/schema/sample").Build(), err: "xml/namespace: must be an absolute URI, but got '/schema/sample'"},
+		{name: "invalid namespace", xml: openapi.NewXMLBuilder().Namespace("http://[::1").Build(), err: "xml/namespace: invalid URL"},
+		{name: "prefix without namespace", xml: openapi.NewXMLBuilder().Prefix("sample").Build(), err: "xml/prefix: requires `namespace` to be set"},
+		{name: "wrapped attribute", xml: openapi.NewXMLBuilder().Attribute(true).Wrapped(true).Build(), err: "xml/attribute&wrapped: mutually exclusive"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				OpenAPI("3.1.1").
+				Info(openapi.NewInfoBuilder().Title("test").Version("1.0.0").Build()).
+				AddComponent("Name", openapi.NewSchemaBuilder().Type(openapi.StringType).XML(tt.xml).Build()).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			err = validator.ValidateSpec()
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "/components/schemas/Name/"+tt.err)
+			}
+		})
+	}
+}